@@ -38,48 +38,91 @@ func ScanOne(rows *sql.Rows, dest ...interface{}) error {
 func ScanFKs(t *schema.Table, rows *sql.Rows) error {
 	names := make(map[string]*schema.ForeignKey)
 	for rows.Next() {
-		var name, table, column, tSchema, refTable, refColumn, refSchema, updateRule, deleteRule string
-		if err := rows.Scan(&name, &table, &column, &tSchema, &refTable, &refColumn, &refSchema, &updateRule, &deleteRule); err != nil {
+		var r fkRow
+		if err := r.scan(rows); err != nil {
 			return err
 		}
-		fk, ok := names[name]
-		if !ok {
-			fk = &schema.ForeignKey{
-				Symbol:   name,
-				Table:    t,
-				RefTable: t,
-				OnDelete: schema.ReferenceOption(deleteRule),
-				OnUpdate: schema.ReferenceOption(updateRule),
-			}
-			if refTable != t.Name || tSchema != refSchema {
-				fk.RefTable = &schema.Table{Name: refTable, Schema: &schema.Schema{Name: refSchema}}
-			}
-			names[name] = fk
-			t.ForeignKeys = append(t.ForeignKeys, fk)
+		if err := r.add(t, names); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ScanSchemaFKs is like ScanFKs, but the rows may hold the foreign-keys of
+// multiple tables in the same schema, and each foreign-key is added to its
+// table in the given map (keyed by name). Rows of tables that do not exist
+// in the map are skipped.
+func ScanSchemaFKs(tables map[string]*schema.Table, rows *sql.Rows) error {
+	names := make(map[string]map[string]*schema.ForeignKey)
+	for rows.Next() {
+		var r fkRow
+		if err := r.scan(rows); err != nil {
+			return err
 		}
-		c, ok := t.Column(column)
+		t, ok := tables[r.table]
 		if !ok {
-			return fmt.Errorf("column %q was not found for fk %q", column, fk.Symbol)
+			continue
 		}
-		// Rows are ordered by ORDINAL_POSITION that specifies
-		// the position of the column in the FK definition.
-		if _, ok := fk.Column(c.Name); !ok {
-			fk.Columns = append(fk.Columns, c)
-			c.ForeignKeys = append(c.ForeignKeys, fk)
+		if names[t.Name] == nil {
+			names[t.Name] = make(map[string]*schema.ForeignKey)
 		}
+		if err := r.add(t, names[t.Name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fkRow holds a row that was scanned by ScanFKs or ScanSchemaFKs.
+type fkRow struct {
+	name, table, column, tSchema, refTable, refColumn, refSchema, updateRule, deleteRule string
+}
+
+func (r *fkRow) scan(rows *sql.Rows) error {
+	return rows.Scan(&r.name, &r.table, &r.column, &r.tSchema, &r.refTable, &r.refColumn, &r.refSchema, &r.updateRule, &r.deleteRule)
+}
 
-		// Stub referenced columns or link if it's a self-reference.
-		var rc *schema.Column
-		if fk.Table != fk.RefTable {
-			rc = &schema.Column{Name: refColumn}
-		} else if c, ok := t.Column(refColumn); ok {
-			rc = c
-		} else {
-			return fmt.Errorf("referenced column %q was not found for fk %q", refColumn, fk.Symbol)
+// add adds the row to its foreign-key in the table,
+// and creates the foreign-key if it does not exist.
+func (r *fkRow) add(t *schema.Table, names map[string]*schema.ForeignKey) error {
+	fk, ok := names[r.name]
+	if !ok {
+		fk = &schema.ForeignKey{
+			Symbol:   r.name,
+			Table:    t,
+			RefTable: t,
+			OnDelete: schema.ReferenceOption(r.deleteRule),
+			OnUpdate: schema.ReferenceOption(r.updateRule),
 		}
-		if _, ok := fk.RefColumn(rc.Name); !ok {
-			fk.RefColumns = append(fk.RefColumns, rc)
+		if r.refTable != t.Name || r.tSchema != r.refSchema {
+			fk.RefTable = &schema.Table{Name: r.refTable, Schema: &schema.Schema{Name: r.refSchema}}
 		}
+		names[r.name] = fk
+		t.ForeignKeys = append(t.ForeignKeys, fk)
+	}
+	c, ok := t.Column(r.column)
+	if !ok {
+		return fmt.Errorf("column %q was not found for fk %q", r.column, fk.Symbol)
+	}
+	// Rows are ordered by ORDINAL_POSITION that specifies
+	// the position of the column in the FK definition.
+	if _, ok := fk.Column(c.Name); !ok {
+		fk.Columns = append(fk.Columns, c)
+		c.ForeignKeys = append(c.ForeignKeys, fk)
+	}
+
+	// Stub referenced columns or link if it's a self-reference.
+	var rc *schema.Column
+	if fk.Table != fk.RefTable {
+		rc = &schema.Column{Name: r.refColumn}
+	} else if c, ok := t.Column(r.refColumn); ok {
+		rc = c
+	} else {
+		return fmt.Errorf("referenced column %q was not found for fk %q", r.refColumn, fk.Symbol)
+	}
+	if _, ok := fk.RefColumn(rc.Name); !ok {
+		fk.RefColumns = append(fk.RefColumns, rc)
 	}
 	return nil
 }
@@ -168,6 +211,12 @@ func VersionPermutations(dialect, version string) []string {
 type Builder struct {
 	bytes.Buffer
 	QuoteChar byte
+	// Unquoted, if set, reports if the given identifier
+	// can be written as-is, without being quoted.
+	Unquoted func(string) bool
+	// Schema, if set, holds the schema that unqualified names are
+	// resolved against. Tables in this schema are not qualified.
+	Schema string
 }
 
 // P writes a list of phrases to the builder separated and
@@ -190,7 +239,12 @@ func (b *Builder) P(phrases ...string) *Builder {
 
 // Ident writes the given string quoted as an SQL identifier.
 func (b *Builder) Ident(s string) *Builder {
-	if s != "" {
+	switch {
+	case s == "":
+	case b.Unquoted != nil && b.Unquoted(s):
+		b.WriteString(s)
+		b.WriteByte(' ')
+	default:
 		b.WriteByte(b.QuoteChar)
 		b.WriteString(s)
 		b.WriteByte(b.QuoteChar)
@@ -200,9 +254,9 @@ func (b *Builder) Ident(s string) *Builder {
 }
 
 // Table writes the table identifier to the builder, prefixed
-// with the schema name if exists.
+// with the schema name if exists and differs from b.Schema.
 func (b *Builder) Table(t *schema.Table) *Builder {
-	if t.Schema != nil {
+	if t.Schema != nil && (b.Schema == "" || t.Schema.Name != b.Schema) {
 		b.Ident(t.Schema.Name)
 		b.rewriteLastByte('.')
 	}
@@ -267,6 +321,8 @@ func (b *Builder) Wrap(f func(b *Builder)) *Builder {
 func (b *Builder) Clone() *Builder {
 	return &Builder{
 		QuoteChar: b.QuoteChar,
+		Unquoted:  b.Unquoted,
+		Schema:    b.Schema,
 		Buffer:    *bytes.NewBufferString(b.String()),
 	}
 }
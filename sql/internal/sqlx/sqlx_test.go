@@ -39,3 +39,18 @@ func TestBuilder(t *testing.T) {
 		})
 	require.Equal(t, `CREATE TABLE "users" ("a" int NOT NULL, "b" int NOT NULL, "c" int NOT NULL, PRIMARY KEY ("a", "b", "c"))`, b.String())
 }
+
+func TestBuilder_Schema(t *testing.T) {
+	s := &schema.Schema{Name: "public"}
+	b := &Builder{QuoteChar: '"'}
+	b.P("DROP TABLE").Table(&schema.Table{Name: "users", Schema: s})
+	require.Equal(t, `DROP TABLE "public"."users"`, b.String())
+
+	// Tables in the builder schema are not qualified.
+	b = &Builder{QuoteChar: '"', Schema: "public"}
+	b.P("DROP TABLE").Table(&schema.Table{Name: "users", Schema: s})
+	require.Equal(t, `DROP TABLE "users"`, b.String())
+	b = &Builder{QuoteChar: '"', Schema: "public"}
+	b.P("DROP TABLE").Table(&schema.Table{Name: "users", Schema: &schema.Schema{Name: "other"}})
+	require.Equal(t, `DROP TABLE "other"."users"`, b.Clone().String())
+}
@@ -0,0 +1,424 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"ariga.io/atlas/sql/internal/sqlx"
+	"ariga.io/atlas/sql/schema"
+)
+
+// FormatType converts schema type to its column form in the database.
+// An error is returned if the type cannot be recognized.
+func FormatType(t schema.Type) (string, error) {
+	var f string
+	switch t := t.(type) {
+	case *schema.StringType:
+		switch f = strings.ToLower(t.T); f {
+		// CHAR without length specifier is equivalent to CHAR(1).
+		case TypeChar, TypeNChar:
+			if t.Size > 0 {
+				f = fmt.Sprintf("%s(%d)", f, t.Size)
+			}
+		// Unlike CHAR, the size of VARCHAR2 is mandatory.
+		case TypeVarchar2, TypeNVarchar2:
+			if t.Size <= 0 {
+				return "", fmt.Errorf("oracle: missing size for %s type", f)
+			}
+			f = fmt.Sprintf("%s(%d)", f, t.Size)
+		// VARCHAR is a synonym for VARCHAR2.
+		case TypeVarchar:
+			if t.Size <= 0 {
+				return "", fmt.Errorf("oracle: missing size for %s type", f)
+			}
+			f = fmt.Sprintf("%s(%d)", TypeVarchar2, t.Size)
+		case TypeCLOB, TypeNCLOB, TypeLong:
+		default:
+			return "", fmt.Errorf("oracle: unexpected string type: %q", t.T)
+		}
+	case *schema.DecimalType:
+		switch f = strings.ToLower(t.T); f {
+		case TypeNumber:
+		// DECIMAL and NUMERIC are synonyms for NUMBER.
+		case TypeDecimal, TypeNumeric:
+			f = TypeNumber
+		default:
+			return "", fmt.Errorf("oracle: unexpected decimal type: %q", t.T)
+		}
+		switch p, s := t.Precision, t.Scale; {
+		case p == 0 && s == 0:
+		case p < 1 || p > maxNumberPrecision:
+			return "", fmt.Errorf("oracle: decimal type must have precision between 1 and %d: %d", maxNumberPrecision, p)
+		case s < minNumberScale || s > maxNumberScale:
+			return "", fmt.Errorf("oracle: decimal type must have scale between %d and %d: %d", minNumberScale, maxNumberScale, s)
+		case s == 0:
+			f = fmt.Sprintf("%s(%d)", f, p)
+		default:
+			f = fmt.Sprintf("%s(%d,%d)", f, p, s)
+		}
+	case *schema.IntegerType:
+		switch f = strings.ToLower(t.T); f {
+		case TypeInteger, TypeSmallInt:
+		// INT is a synonym for INTEGER.
+		case TypeInt:
+			f = TypeInteger
+		default:
+			return "", fmt.Errorf("oracle: unexpected integer type: %q", t.T)
+		}
+	case *schema.FloatType:
+		switch f = strings.ToLower(t.T); f {
+		case TypeBinaryFloat, TypeBinaryDouble:
+		case TypeFloat:
+			if t.Precision > 0 {
+				f = fmt.Sprintf("%s(%d)", f, t.Precision)
+			}
+		default:
+			return "", fmt.Errorf("oracle: unexpected float type: %q", t.T)
+		}
+	case *schema.BinaryType:
+		switch f = strings.ToLower(t.T); f {
+		case TypeRaw:
+			if t.Size > 0 {
+				f = fmt.Sprintf("%s(%d)", f, t.Size)
+			}
+		case TypeBLOB, TypeLongRaw, TypeBFile:
+		default:
+			return "", fmt.Errorf("oracle: unexpected binary type: %q", t.T)
+		}
+	case *RowIDType:
+		switch f = strings.ToLower(t.T); f {
+		case TypeRowID:
+		case TypeURowID:
+			if t.Size > 0 {
+				f = fmt.Sprintf("%s(%d)", f, t.Size)
+			}
+		default:
+			return "", fmt.Errorf("oracle: unexpected rowid type: %q", t.T)
+		}
+	case *schema.TimeType:
+		switch f = strings.ToLower(t.T); f {
+		case TypeDate:
+		case TypeTimestamp, TypeTimestampTZ, TypeTimestampLTZ:
+			// The fractional seconds precision follows the TIMESTAMP keyword.
+			if t.Precision != nil {
+				f = fmt.Sprintf("%s(%d)%s", TypeTimestamp, *t.Precision, strings.TrimPrefix(f, TypeTimestamp))
+			}
+		default:
+			return "", fmt.Errorf("oracle: unexpected time type: %q", t.T)
+		}
+	case *IntervalType:
+		switch f = strings.ToLower(t.T); f {
+		case TypeIntervalYM:
+			if t.Precision != nil {
+				f = fmt.Sprintf("interval year(%d) to month", *t.Precision)
+			}
+		case TypeIntervalDS:
+			day, second := "day", "second"
+			if t.Precision != nil {
+				day = fmt.Sprintf("day(%d)", *t.Precision)
+			}
+			if t.Scale != nil {
+				second = fmt.Sprintf("second(%d)", *t.Scale)
+			}
+			f = fmt.Sprintf("interval %s to %s", day, second)
+		default:
+			return "", fmt.Errorf("oracle: unexpected interval type: %q", t.T)
+		}
+	case *schema.SpatialType:
+		if f = strings.ToLower(t.T); f != TypeSDOGeometry {
+			return "", fmt.Errorf("oracle: unexpected spatial type: %q", t.T)
+		}
+	case *XMLType:
+		if f = strings.ToLower(t.T); f != TypeXML {
+			return "", fmt.Errorf("oracle: unexpected xml type: %q", t.T)
+		}
+	// Columns of collection types are declared by the type name.
+	case *CollectionType:
+		if t.T == "" {
+			return "", fmt.Errorf("oracle: missing name for collection type")
+		}
+		f = ident(t.T)
+		if t.Schema != "" {
+			f = ident(t.Schema) + "." + f
+		}
+	case *UserDefinedType:
+		if t.T == "" {
+			return "", fmt.Errorf("oracle: missing name for user-defined type")
+		}
+		f = ident(t.T)
+		if t.Schema != "" {
+			f = ident(t.Schema) + "." + f
+		}
+	case *schema.UnsupportedType:
+		return "", fmt.Errorf("oracle: unsupported type: %q", t.T)
+	default:
+		return "", fmt.Errorf("oracle: invalid schema type: %T", t)
+	}
+	return f, nil
+}
+
+// formatCollection returns the definition of the collection type that is used
+// by the CREATE TYPE statement. For example, "VARRAY(10) OF number(10) NOT NULL".
+func formatCollection(t *CollectionType) (string, error) {
+	elem, err := FormatType(t.Elem)
+	if err != nil {
+		return "", err
+	}
+	var f string
+	switch strings.ToUpper(t.Kind) {
+	case CollectionVarray:
+		if t.Size <= 0 {
+			return "", fmt.Errorf("oracle: missing size for VARRAY type %q", t.T)
+		}
+		f = fmt.Sprintf("VARRAY(%d) OF %s", t.Size, elem)
+	case CollectionTable:
+		f = fmt.Sprintf("TABLE OF %s", elem)
+	default:
+		return "", fmt.Errorf("oracle: unexpected collection kind %q for type %q", t.Kind, t.T)
+	}
+	if t.NotNull {
+		f += " NOT NULL"
+	}
+	return f, nil
+}
+
+// formatColumnType converts the column type to its form in the database. Unlike
+// FormatType, the length semantics of the column (if set) are included in the
+// formatted type. For example, "varchar2(10 CHAR)".
+func formatColumnType(c *schema.Column) (string, error) {
+	f, err := FormatType(c.Type.Type)
+	if err != nil {
+		return "", err
+	}
+	s := &LengthSemantics{}
+	if t, ok := c.Type.Type.(*schema.StringType); ok && t.Size > 0 && hasSemantics(t.T) && sqlx.Has(c.Attrs, s) {
+		f = fmt.Sprintf("%s %s)", strings.TrimSuffix(f, ")"), strings.ToUpper(s.T))
+	}
+	return f, nil
+}
+
+// ParseType returns the schema.Type value represented by the given raw type.
+// The raw value is expected to follow the format in the Oracle data dictionary
+// or as an input for the CREATE TABLE statement.
+func ParseType(typ string) (schema.Type, error) {
+	d, err := parseColumn(typ)
+	if err != nil {
+		return nil, err
+	}
+	// Unlike the data dictionary that reports the size of character
+	// types separately, VARCHAR2 types must be declared with a size.
+	switch d.typ {
+	case TypeVarchar2, TypeNVarchar2, TypeVarchar:
+		if d.size == 0 {
+			return nil, fmt.Errorf("oracle: missing size for %s type %q", d.typ, typ)
+		}
+	}
+	return columnType(d), nil
+}
+
+// ParseTypeContext is like ParseType, but types that are unknown to the driver
+// are looked up in the connected database. Names of object types are resolved
+// to a UserDefinedType that holds their owner, and unqualified names are resolved
+// against the current schema of the session. An error is returned if there is no
+// object type with the given name.
+func (c *conn) ParseTypeContext(ctx context.Context, typ string) (schema.Type, error) {
+	t, err := ParseType(typ)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := t.(*schema.UnsupportedType); !ok {
+		return t, nil
+	}
+	owner, name := objectName(typ)
+	rows, err := c.QueryContext(ctx, c.dictQuery(objectTypeQuery), owner, name)
+	if err != nil {
+		return nil, fmt.Errorf("oracle: querying type %q: %w", typ, err)
+	}
+	ut := &UserDefinedType{}
+	switch err := sqlx.ScanOne(rows, &ut.Schema, &ut.T); {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, fmt.Errorf("oracle: unknown type %q", typ)
+	case err != nil:
+		return nil, fmt.Errorf("oracle: scanning type %q: %w", typ, err)
+	}
+	return ut, nil
+}
+
+// objectName splits the (optionally qualified) name of an object type into its
+// owner and name. Unquoted identifiers are stored in uppercase by the database.
+func objectName(typ string) (owner, name string) {
+	parts := strings.SplitN(strings.TrimSpace(typ), ".", 2)
+	for i, p := range parts {
+		if p = strings.TrimSpace(p); len(p) > 1 && p[0] == '"' && p[len(p)-1] == '"' {
+			parts[i] = p[1 : len(p)-1]
+		} else {
+			parts[i] = strings.ToUpper(p)
+		}
+	}
+	if len(parts) == 1 {
+		return "", parts[0]
+	}
+	return parts[0], parts[1]
+}
+
+// parseColumnType is like ParseType, but also returns the column attributes
+// that are derived from the raw type. i.e. the explicit length semantics.
+func parseColumnType(typ string) (schema.Type, []schema.Attr, error) {
+	d, err := parseColumn(typ)
+	if err != nil {
+		return nil, nil, err
+	}
+	var attrs []schema.Attr
+	if d.semantics != "" {
+		attrs = append(attrs, &LengthSemantics{T: d.semantics})
+	}
+	return columnType(d), attrs, nil
+}
+
+// columnDesc represents a column descriptor.
+type columnDesc struct {
+	typ       string
+	size      int64
+	precision int64
+	scale     int64
+	// Optional precisions of the datetime and interval types. The fractional
+	// seconds precision (e.g. 6 in TIMESTAMP(6)), and the leading field precision
+	// of intervals (e.g. 3 in INTERVAL DAY(3) TO SECOND).
+	secondsPrecision *int
+	leadingPrecision *int
+	// Length semantics of character types (BYTE or CHAR).
+	semantics string
+}
+
+// reTypeArgs matches the arguments of data types.
+// For example, "(6)" in "TIMESTAMP(6) WITH TIME ZONE".
+var reTypeArgs = regexp.MustCompile(`\(\s*([^)]*)\)`)
+
+// parseColumn parses the given type into a column descriptor. The type
+// name is lower-cased and stripped from its arguments, and the arguments
+// are set on the descriptor according to the type.
+func parseColumn(s string) (*columnDesc, error) {
+	var (
+		args      []string
+		semantics string
+	)
+	for _, m := range reTypeArgs.FindAllStringSubmatch(s, -1) {
+		for _, a := range strings.Split(m[1], ",") {
+			fs := strings.Fields(a)
+			if len(fs) > 0 {
+				args = append(args, fs[0])
+			}
+			// Length semantics follow the size. e.g. "10 CHAR".
+			if len(fs) > 1 {
+				semantics = strings.ToUpper(fs[1])
+			}
+		}
+	}
+	c := &columnDesc{
+		typ: strings.Join(strings.Fields(strings.ToLower(reTypeArgs.ReplaceAllString(s, " "))), " "),
+	}
+	ints := make([]int64, len(args))
+	for i, a := range args {
+		// NUMBER(*,s) stands for the maximum precision.
+		if a == "*" {
+			ints[i] = maxNumberPrecision
+			continue
+		}
+		n, err := strconv.ParseInt(a, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("oracle: parse type argument %q of %q: %w", a, s, err)
+		}
+		ints[i] = n
+	}
+	switch c.typ {
+	case TypeChar, TypeNChar, TypeVarchar2, TypeNVarchar2, TypeVarchar, TypeRaw, TypeURowID:
+		if len(ints) > 0 {
+			c.size = ints[0]
+		}
+		if hasSemantics(c.typ) {
+			switch semantics {
+			case "":
+			case SemanticsByte, SemanticsChar:
+				c.semantics = semantics
+			default:
+				return nil, fmt.Errorf("oracle: unexpected length semantics %q of %q", semantics, s)
+			}
+		}
+	case TypeNumber, TypeDecimal, TypeNumeric:
+		if len(ints) > 0 {
+			c.precision = ints[0]
+			if c.precision < 1 || c.precision > maxNumberPrecision {
+				return nil, fmt.Errorf("oracle: precision of %q must be between 1 and %d", s, maxNumberPrecision)
+			}
+		}
+		if len(ints) > 1 {
+			c.scale = ints[1]
+			if c.scale < minNumberScale || c.scale > maxNumberScale {
+				return nil, fmt.Errorf("oracle: scale of %q must be between %d and %d", s, minNumberScale, maxNumberScale)
+			}
+		}
+	case TypeFloat:
+		if len(ints) > 0 {
+			c.precision = ints[0]
+		}
+	case TypeTimestamp, TypeTimestampTZ, TypeTimestampLTZ:
+		if len(ints) > 0 {
+			c.secondsPrecision = intp(ints[0])
+		}
+	case TypeIntervalYM:
+		if len(ints) > 0 {
+			c.leadingPrecision = intp(ints[0])
+		}
+	case TypeIntervalDS:
+		// Note that the fractional seconds precision can
+		// be set without setting the leading field precision.
+		switch {
+		case len(ints) > 1:
+			c.leadingPrecision, c.secondsPrecision = intp(ints[0]), intp(ints[1])
+		case len(ints) == 1 && strings.Contains(strings.ReplaceAll(strings.ToLower(s), " ", ""), "second("):
+			c.secondsPrecision = intp(ints[0])
+		case len(ints) == 1:
+			c.leadingPrecision = intp(ints[0])
+		}
+	}
+	return c, nil
+}
+
+// hasSemantics reports if the given character type can be declared with
+// length semantics. The national character types are always in characters.
+func hasSemantics(t string) bool {
+	switch strings.ToLower(t) {
+	case TypeChar, TypeVarchar2, TypeVarchar:
+		return true
+	}
+	return false
+}
+
+// lengthSemantics returns the length semantics of a character column
+// from the CHAR_USED column of the data dictionary ('B' or 'C').
+func lengthSemantics(t, charUsed string) string {
+	if !hasSemantics(t) {
+		return ""
+	}
+	switch charUsed {
+	case "B":
+		return SemanticsByte
+	case "C":
+		return SemanticsChar
+	}
+	return ""
+}
+
+func intp(n int64) *int {
+	v := int(n)
+	return &v
+}
@@ -0,0 +1,296 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package oracle
+
+import (
+	"testing"
+
+	"ariga.io/atlas/sql/schema"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseType_Precision(t *testing.T) {
+	tests := []struct {
+		typ    string
+		expect schema.Type
+		format string
+	}{
+		{
+			typ:    "TIMESTAMP",
+			expect: &schema.TimeType{T: TypeTimestamp},
+			format: "timestamp",
+		},
+		{
+			typ:    "TIMESTAMP(6)",
+			expect: &schema.TimeType{T: TypeTimestamp, Precision: intp(6)},
+			format: "timestamp(6)",
+		},
+		{
+			typ:    "TIMESTAMP(0)",
+			expect: &schema.TimeType{T: TypeTimestamp, Precision: intp(0)},
+			format: "timestamp(0)",
+		},
+		{
+			typ:    "TIMESTAMP(9) WITH TIME ZONE",
+			expect: &schema.TimeType{T: TypeTimestampTZ, Precision: intp(9)},
+			format: "timestamp(9) with time zone",
+		},
+		{
+			typ:    "TIMESTAMP(3) WITH LOCAL TIME ZONE",
+			expect: &schema.TimeType{T: TypeTimestampLTZ, Precision: intp(3)},
+			format: "timestamp(3) with local time zone",
+		},
+		{
+			typ:    "INTERVAL DAY(3) TO SECOND(6)",
+			expect: &IntervalType{T: TypeIntervalDS, Precision: intp(3), Scale: intp(6)},
+			format: "interval day(3) to second(6)",
+		},
+		{
+			typ:    "INTERVAL DAY TO SECOND(2)",
+			expect: &IntervalType{T: TypeIntervalDS, Scale: intp(2)},
+			format: "interval day to second(2)",
+		},
+		{
+			typ:    "INTERVAL DAY(5) TO SECOND",
+			expect: &IntervalType{T: TypeIntervalDS, Precision: intp(5)},
+			format: "interval day(5) to second",
+		},
+		{
+			typ:    "INTERVAL YEAR(4) TO MONTH",
+			expect: &IntervalType{T: TypeIntervalYM, Precision: intp(4)},
+			format: "interval year(4) to month",
+		},
+		{
+			typ:    "INTERVAL YEAR TO MONTH",
+			expect: &IntervalType{T: TypeIntervalYM},
+			format: "interval year to month",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			typ, err := ParseType(tt.typ)
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, typ)
+			f, err := FormatType(typ)
+			require.NoError(t, err)
+			require.Equal(t, tt.format, f)
+			// Formatted types are parsed back to the same type.
+			typ, err = ParseType(f)
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, typ)
+		})
+	}
+}
+
+func TestParseType_Number(t *testing.T) {
+	tests := []struct {
+		typ    string
+		expect schema.Type
+		format string
+	}{
+		{
+			typ:    "NUMBER",
+			expect: &schema.DecimalType{T: TypeNumber},
+			format: "number",
+		},
+		{
+			typ:    "NUMBER(*)",
+			expect: &schema.DecimalType{T: TypeNumber, Precision: 38},
+			format: "number(38)",
+		},
+		{
+			typ:    "NUMBER(*,2)",
+			expect: &schema.DecimalType{T: TypeNumber, Precision: 38, Scale: 2},
+			format: "number(38,2)",
+		},
+		{
+			typ:    "NUMBER(10,-2)",
+			expect: &schema.DecimalType{T: TypeNumber, Precision: 10, Scale: -2},
+			format: "number(10,-2)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			typ, err := ParseType(tt.typ)
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, typ)
+			f, err := FormatType(typ)
+			require.NoError(t, err)
+			require.Equal(t, tt.format, f)
+			typ, err = ParseType(f)
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, typ)
+		})
+	}
+	for _, typ := range []string{"NUMBER(0)", "NUMBER(39)", "NUMBER(10,-85)", "NUMBER(10,128)", "NUMBER(x)"} {
+		_, err := ParseType(typ)
+		require.Error(t, err, typ)
+	}
+	// Scales are limited to the range -84..127, and precisions to 1..38.
+	f, err := FormatType(&schema.DecimalType{T: TypeNumber, Precision: 38, Scale: -84})
+	require.NoError(t, err)
+	require.Equal(t, "number(38,-84)", f)
+	for _, typ := range []*schema.DecimalType{
+		{T: TypeNumber, Precision: 10, Scale: 128},
+		{T: TypeNumber, Precision: 10, Scale: -85},
+		{T: TypeNumber, Precision: 39, Scale: -2},
+		{T: TypeNumber, Scale: -2},
+	} {
+		_, err := FormatType(typ)
+		require.Error(t, err)
+	}
+}
+
+func TestParseType_Spatial(t *testing.T) {
+	for _, typ := range []string{"SDO_GEOMETRY", "MDSYS.SDO_GEOMETRY", "sdo_geometry"} {
+		parsed, err := ParseType(typ)
+		require.NoError(t, err)
+		require.Equal(t, &schema.SpatialType{T: TypeSDOGeometry}, parsed)
+		f, err := FormatType(parsed)
+		require.NoError(t, err)
+		require.Equal(t, "sdo_geometry", f)
+	}
+	_, err := FormatType(&schema.SpatialType{T: "geometry"})
+	require.Error(t, err)
+}
+
+func TestParseType_LOBAndRowID(t *testing.T) {
+	tests := []struct {
+		typ    string
+		expect schema.Type
+		format string
+	}{
+		{
+			typ:    "CLOB",
+			expect: &schema.StringType{T: TypeCLOB},
+			format: "clob",
+		},
+		{
+			typ:    "NCLOB",
+			expect: &schema.StringType{T: TypeNCLOB},
+			format: "nclob",
+		},
+		{
+			typ:    "BLOB",
+			expect: &schema.BinaryType{T: TypeBLOB},
+			format: "blob",
+		},
+		{
+			typ:    "ROWID",
+			expect: &RowIDType{T: TypeRowID},
+			format: "rowid",
+		},
+		{
+			typ:    "UROWID",
+			expect: &RowIDType{T: TypeURowID},
+			format: "urowid",
+		},
+		{
+			typ:    "UROWID(1000)",
+			expect: &RowIDType{T: TypeURowID, Size: 1000},
+			format: "urowid(1000)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			typ, err := ParseType(tt.typ)
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, typ)
+			f, err := FormatType(typ)
+			require.NoError(t, err)
+			require.Equal(t, tt.format, f)
+		})
+	}
+}
+
+func TestParseType_Legacy(t *testing.T) {
+	tests := []struct {
+		typ    string
+		expect schema.Type
+		format string
+	}{
+		{
+			typ:    "LONG",
+			expect: &schema.StringType{T: TypeLong},
+			format: "long",
+		},
+		{
+			typ:    "LONG RAW",
+			expect: &schema.BinaryType{T: TypeLongRaw},
+			format: "long raw",
+		},
+		{
+			typ:    "BFILE",
+			expect: &schema.BinaryType{T: TypeBFile},
+			format: "bfile",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			typ, err := ParseType(tt.typ)
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, typ)
+			f, err := FormatType(typ)
+			require.NoError(t, err)
+			require.Equal(t, tt.format, f)
+			// The formatted type is parsed back to the same type.
+			typ, err = ParseType(f)
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, typ)
+		})
+	}
+}
+
+func TestParseType_LengthSemantics(t *testing.T) {
+	tests := []struct {
+		typ    string
+		expect schema.Type
+		attrs  []schema.Attr
+		format string
+	}{
+		{
+			typ:    "VARCHAR2(10)",
+			expect: &schema.StringType{T: TypeVarchar2, Size: 10},
+			format: "varchar2(10)",
+		},
+		{
+			typ:    "VARCHAR2(10 CHAR)",
+			expect: &schema.StringType{T: TypeVarchar2, Size: 10},
+			attrs:  []schema.Attr{&LengthSemantics{T: SemanticsChar}},
+			format: "varchar2(10 CHAR)",
+		},
+		{
+			typ:    "varchar2(10 byte)",
+			expect: &schema.StringType{T: TypeVarchar2, Size: 10},
+			attrs:  []schema.Attr{&LengthSemantics{T: SemanticsByte}},
+			format: "varchar2(10 BYTE)",
+		},
+		{
+			typ:    "CHAR(1 CHAR)",
+			expect: &schema.StringType{T: TypeChar, Size: 1},
+			attrs:  []schema.Attr{&LengthSemantics{T: SemanticsChar}},
+			format: "char(1 CHAR)",
+		},
+		{
+			typ:    "NVARCHAR2(10)",
+			expect: &schema.StringType{T: TypeNVarchar2, Size: 10},
+			format: "nvarchar2(10)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			typ, attrs, err := parseColumnType(tt.typ)
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, typ)
+			require.Equal(t, tt.attrs, attrs)
+			f, err := formatColumnType(&schema.Column{Type: &schema.ColumnType{Type: typ}, Attrs: attrs})
+			require.NoError(t, err)
+			require.Equal(t, tt.format, f)
+		})
+	}
+	_, _, err := parseColumnType("VARCHAR2(10 WORDS)")
+	require.Error(t, err)
+}
@@ -0,0 +1,803 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"ariga.io/atlas/sql/internal/sqlx"
+	"ariga.io/atlas/sql/schema"
+)
+
+// A diff provides an Oracle implementation for sqlx.DiffDriver.
+type diff struct{ conn }
+
+// SchemaAttrDiff returns a changeset for migrating schema attributes from one state to the other.
+// Standalone sequences are schema attributes, and therefore, they are diffed here.
+func (d *diff) SchemaAttrDiff(from, to *schema.Schema) []schema.Change {
+	// The sequences that back emulated identity columns (< 12c) are
+	// managed together with their columns, and are not diffed here.
+	emulated := make(map[string]bool)
+	if !d.supportsIdentity() {
+		emulated = emulatedSequences(from, to)
+	}
+	var changes []schema.Change
+	for _, s1 := range sequences(from.Attrs) {
+		s2, ok := sequence(to.Attrs, s1.Name)
+		switch {
+		// Unmanaged sequences are not dropped. See WithUnmanagedSequences.
+		case emulated[s1.Name], !ok && d.unmanagedSeqs:
+		case !ok:
+			changes = append(changes, &schema.DropAttr{A: s1})
+		case seqChanged(s1, s2):
+			changes = append(changes, &schema.ModifyAttr{From: s1, To: s2})
+		}
+	}
+	for _, s2 := range sequences(to.Attrs) {
+		if _, ok := sequence(from.Attrs, s2.Name); !ok && !emulated[s2.Name] {
+			changes = append(changes, &schema.AddAttr{A: s2})
+		}
+	}
+	return changes
+}
+
+// TableAttrDiff returns a changeset for migrating table attributes from one state to the other.
+func (d *diff) TableAttrDiff(from, to *schema.Table) ([]schema.Change, error) {
+	// Views are compared by their definition only.
+	if v1, v2 := viewOf(from), viewOf(to); v1 != nil || v2 != nil {
+		return viewDiff(to.Name, v1, v2)
+	}
+	// ROWDEPENDENCIES can be set only when the table is created.
+	if sqlx.Has(from.Attrs, &RowDependencies{}) != sqlx.Has(to.Attrs, &RowDependencies{}) {
+		return nil, fmt.Errorf("oracle: changing the ROWDEPENDENCIES of table %q requires recreating the table", to.Name)
+	}
+	// Likewise, tables cannot be converted to (or from) global
+	// temporary tables, nor can their ON COMMIT mode be altered.
+	if t1, t2 := temporaryOf(from), temporaryOf(to); t1 != t2 {
+		return nil, fmt.Errorf("oracle: changing the temporary mode of table %q requires recreating the table", to.Name)
+	}
+	// Converting heap-organized tables to index-organized
+	// tables (or vice versa) requires rebuilding the table.
+	if o1, o2 := organizationOf(from), organizationOf(to); o1 != o2 {
+		return nil, fmt.Errorf("oracle: changing the organization of table %q from %s to %s requires recreating the table", to.Name, o1, o2)
+	}
+	var changes []schema.Change
+	if change := sqlx.CommentDiff(from.Attrs, to.Attrs); change != nil {
+		changes = append(changes, change)
+	}
+	if change := tablespaceDiff(from.Attrs, to.Attrs); change != nil {
+		changes = append(changes, change)
+	}
+	if change := parallelDiff(from.Attrs, to.Attrs); change != nil {
+		changes = append(changes, change)
+	}
+	// Auditing options are inspected only in audit mode, and
+	// therefore, they are not compared unless it is enabled.
+	if d.audit {
+		changes = append(changes, auditDiff(from.Attrs, to.Attrs)...)
+	}
+	// Likewise, ILM policies are compared only in ILM mode.
+	if d.ilm {
+		changes = append(changes, ilmDiff(from.Attrs, to.Attrs)...)
+	}
+	changes = append(changes, fkStateDiff(from, to)...)
+	// The CheckColumns attribute is added on inspection
+	// and therefore, it is ignored when comparing checks.
+	return append(changes, sqlx.CheckDiff(from, to, func(c1, c2 *schema.Check) bool {
+		return !stateChanged(checkState(c1), checkState(c2))
+	})...), nil
+}
+
+// fkStateDiff returns the state changes of the foreign keys that exist in both
+// tables. The states are stored in the table attributes (see ConstraintState),
+// and their changes are reported as ModifyAttr changes of these attributes.
+func fkStateDiff(from, to *schema.Table) []schema.Change {
+	var changes []schema.Change
+	for _, fk1 := range from.ForeignKeys {
+		if _, ok := to.ForeignKey(fk1.Symbol); !ok || fk1.Symbol == "" {
+			continue
+		}
+		cs1, ok1 := constraintState(from.Attrs, fk1.Symbol)
+		if !ok1 {
+			cs1 = &ConstraintState{Name: fk1.Symbol}
+		}
+		cs2, ok2 := constraintState(to.Attrs, fk1.Symbol)
+		if !ok2 {
+			cs2 = &ConstraintState{Name: fk1.Symbol}
+		}
+		if stateChanged(cs1, cs2) {
+			changes = append(changes, &schema.ModifyAttr{From: cs1, To: cs2})
+		}
+	}
+	return changes
+}
+
+// checkState returns the state of a CHECK constraint, or nil if it is in its default state.
+func checkState(c *schema.Check) *ConstraintState {
+	if cs := (&ConstraintState{}); sqlx.Has(c.Attrs, cs) {
+		return cs
+	}
+	return nil
+}
+
+// stateChanged reports if the state of a constraint was changed. A nil state
+// is the default one, and the constraint names are not compared.
+func stateChanged(from, to *ConstraintState) bool {
+	var s1, s2 ConstraintState
+	if from != nil {
+		s1 = *from
+	}
+	if to != nil {
+		s2 = *to
+	}
+	s1.Name, s2.Name = "", ""
+	return s1 != s2
+}
+
+// deferrableChanged reports if the DEFERRABLE or the INITIALLY DEFERRED state
+// of a constraint was changed. Unlike the ENABLE and VALIDATE states, these
+// cannot be altered in place, and the constraint must be re-created.
+func deferrableChanged(from, to *ConstraintState) bool {
+	var s1, s2 ConstraintState
+	if from != nil {
+		s1 = *from
+	}
+	if to != nil {
+		s2 = *to
+	}
+	return s1.Deferrable != s2.Deferrable || s1.InitiallyDeferred != s2.InitiallyDeferred
+}
+
+// ColumnChange returns the schema changes (if any) for migrating one column to the other.
+func (d *diff) ColumnChange(from, to *schema.Column) (schema.ChangeKind, error) {
+	change := sqlx.CommentChange(from.Attrs, to.Attrs)
+	if nullable(from) != nullable(to) {
+		change |= schema.ChangeNull
+	}
+	changed, err := d.typeChanged(from, to)
+	if err != nil {
+		return schema.NoChange, err
+	}
+	if changed {
+		change |= schema.ChangeType
+	}
+	if d.defaultChanged(from, to) {
+		change |= schema.ChangeDefault
+	}
+	if collation(from.Attrs) != collation(to.Attrs) {
+		change |= schema.ChangeCollation
+	}
+	if identityChanged(from.Attrs, to.Attrs) || virtualChanged(from.Attrs, to.Attrs) || invisibleChanged(from.Attrs, to.Attrs) || lobStorageChanged(from.Attrs, to.Attrs) || notNullStateChanged(from, to) {
+		change |= schema.ChangeAttr
+	}
+	return change, nil
+}
+
+// nullable reports if the column is nullable. Columns with disabled NOT NULL
+// constraints are reported as nullable by the database, but they are not.
+func nullable(c *schema.Column) bool {
+	_, ok := notNullState(c.Attrs)
+	return c.Type.Null && !ok
+}
+
+// notNullStateChanged reports if the state of the NOT NULL constraint of a column
+// was changed (e.g. from ENABLE VALIDATE to ENABLE NOVALIDATE). The constraint
+// names are generated by the database, and therefore, they are not compared.
+func notNullStateChanged(from, to *schema.Column) bool {
+	if nullable(from) || nullable(to) {
+		return false
+	}
+	cs1, _ := notNullState(from.Attrs)
+	cs2, _ := notNullState(to.Attrs)
+	return stateChanged(cs1, cs2)
+}
+
+// defaultChanged reports if the default value of a column was changed.
+func (d *diff) defaultChanged(from, to *schema.Column) bool {
+	d1, ok1 := sqlx.DefaultValue(from)
+	d2, ok2 := sqlx.DefaultValue(to)
+	if ok1 != ok2 || sqlx.Has(from.Attrs, &DefaultOnNull{}) != sqlx.Has(to.Attrs, &DefaultOnNull{}) {
+		return true
+	}
+	// CHAR and NCHAR values are blank-padded to the column length, and
+	// therefore, literals are compared without their trailing blanks.
+	if l1, l2 := charLiteral(from), charLiteral(to); l1 != "" && l2 != "" {
+		return l1 != l2
+	}
+	// Oracle keeps the DEFAULT clause as it was written, including its trailing
+	// whitespaces. ANSI functions are compared by their Oracle equivalents.
+	return defaultFunc(d1) != defaultFunc(d2)
+}
+
+// charLiteral returns the default literal of a CHAR (or NCHAR) column
+// without its trailing blanks, or an empty string if it has no such default.
+func charLiteral(c *schema.Column) string {
+	t, ok := c.Type.Type.(*schema.StringType)
+	if !ok || !strings.EqualFold(t.T, TypeChar) && !strings.EqualFold(t.T, TypeNChar) {
+		return ""
+	}
+	l, ok := c.Default.(*schema.Literal)
+	if !ok {
+		return ""
+	}
+	q := quote(strings.TrimSpace(l.V))
+	return strings.TrimRight(q[:len(q)-1], " ") + "'"
+}
+
+// collation returns the collation of a column from its attributes. Columns that
+// were declared without a collation use the default pseudo-collation, and names
+// are compared case-insensitively, as they are stored in uppercase.
+func collation(attrs []schema.Attr) string {
+	if c := (schema.Collation{}); sqlx.Has(attrs, &c) && c.V != "" {
+		return strings.ToUpper(c.V)
+	}
+	return DefaultCollation
+}
+
+// IsGeneratedIndexName reports if the index name was generated by the database.
+func (d *diff) IsGeneratedIndexName(_ *schema.Table, idx *schema.Index) bool {
+	return generatedName(idx.Name)
+}
+
+// generatedName reports if the given constraint or index name was generated by
+// the database. Unnamed constraints (and their backing indexes) are named
+// SYS_C<n> by the database. e.g. SYS_C0012345.
+func generatedName(name string) bool {
+	n := strings.TrimPrefix(name, "SYS_C")
+	if n == name || n == "" {
+		return false
+	}
+	for _, r := range n {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// IndexAttrChanged reports if the index attributes were changed.
+// The default type is NORMAL (B-tree) if no type was specified.
+func (*diff) IndexAttrChanged(from, to []schema.Attr) bool {
+	t1, t2 := indexType(from), indexType(to)
+	// Indexes on expressions (e.g. DESC parts) are reported
+	// as function-based, but they are defined the same way.
+	return strings.TrimPrefix(t1, "FUNCTION-BASED ") != strings.TrimPrefix(t2, "FUNCTION-BASED ") ||
+		tablespaceDiff(from, to) != nil || sqlx.Has(from, &LocalIndex{}) != sqlx.Has(to, &LocalIndex{})
+}
+
+// indexType returns the type of an index from its attributes. The primary
+// key index of index-organized tables (IOT - TOP) is a B-tree index.
+func indexType(attrs []schema.Attr) string {
+	t := &IndexType{T: "NORMAL"}
+	sqlx.Has(attrs, t)
+	if typ := strings.ToUpper(t.T); typ != "IOT - TOP" {
+		return typ
+	}
+	return "NORMAL"
+}
+
+// viewDiff returns the change for migrating the definition of a (materialized)
+// view. Tables cannot be converted to views (or vice versa), and neither can
+// views be converted to materialized views.
+func viewDiff(name string, from, to schema.Attr) ([]schema.Change, error) {
+	if reflect.TypeOf(from) != reflect.TypeOf(to) {
+		return nil, fmt.Errorf("oracle: changing the kind of %q (table, view or materialized view) requires recreating it", name)
+	}
+	changed := false
+	switch v1 := from.(type) {
+	case *View:
+		changed = strings.TrimSpace(v1.Def) != strings.TrimSpace(to.(*View).Def)
+	case *MaterializedView:
+		v2 := to.(*MaterializedView)
+		changed = strings.TrimSpace(v1.Def) != strings.TrimSpace(v2.Def) ||
+			!strings.EqualFold(v1.RefreshMethod, v2.RefreshMethod) ||
+			!strings.EqualFold(v1.RefreshMode, v2.RefreshMode) ||
+			!strings.EqualFold(v1.BuildMode, v2.BuildMode)
+	}
+	if !changed {
+		return nil, nil
+	}
+	return []schema.Change{&schema.ModifyAttr{From: from, To: to}}, nil
+}
+
+// tablespaceDiff returns the change for moving a table (or an index) to another
+// tablespace, or nil if the tablespace was not changed. Elements without the
+// Tablespace attribute in the desired state are kept in their tablespace.
+func tablespaceDiff(from, to []schema.Attr) schema.Change {
+	t1, t2 := &Tablespace{}, &Tablespace{}
+	switch ok1, ok2 := sqlx.Has(from, t1), sqlx.Has(to, t2); {
+	case !ok2:
+		return nil
+	case !ok1:
+		return &schema.AddAttr{A: t2}
+	case !strings.EqualFold(t1.Name, t2.Name):
+		return &schema.ModifyAttr{From: t1, To: t2}
+	}
+	return nil
+}
+
+// parallelDiff returns the change for migrating the PARALLEL clause of a table.
+// Like tablespaces, it is compared only if it is declared by the desired state,
+// and a degree (or instances) of 1 declares a table that is not parallelized.
+func parallelDiff(from, to []schema.Attr) schema.Change {
+	p2 := &Parallel{}
+	if !sqlx.Has(to, p2) {
+		return nil
+	}
+	p1 := parallelOf(from)
+	if p2 = parallelOf(to); *p1 == *p2 {
+		return nil
+	}
+	return &schema.ModifyAttr{From: p1, To: p2}
+}
+
+// parallelOf returns the PARALLEL clause of a table from its attributes, with
+// its unset values filled with their defaults. NOPARALLEL is DEGREE 1 INSTANCES 1.
+func parallelOf(attrs []schema.Attr) *Parallel {
+	p := &Parallel{}
+	sqlx.Has(attrs, p)
+	if p.Degree == 0 {
+		p.Degree = 1
+	}
+	if p.Instances == 0 {
+		p.Instances = 1
+	}
+	return p
+}
+
+// auditDiff returns the changes for migrating the auditing options of a table.
+// Options are matched by their name and condition, and are case-insensitive.
+func auditDiff(from, to []schema.Attr) []schema.Change {
+	var changes []schema.Change
+	for _, a1 := range audits(from) {
+		switch a2, ok := audit(to, a1); {
+		case !ok:
+			changes = append(changes, &schema.DropAttr{A: a1})
+		case !strings.EqualFold(a1.By, a2.By):
+			changes = append(changes, &schema.ModifyAttr{From: a1, To: a2})
+		}
+	}
+	for _, a2 := range audits(to) {
+		if _, ok := audit(from, a2); !ok {
+			changes = append(changes, &schema.AddAttr{A: a2})
+		}
+	}
+	return changes
+}
+
+// audits returns the auditing options from the given attributes.
+func audits(attrs []schema.Attr) []*Audit {
+	var as []*Audit
+	for _, a := range attrs {
+		if a, ok := a.(*Audit); ok {
+			as = append(as, a)
+		}
+	}
+	return as
+}
+
+// audit returns the auditing option from the attributes that matches the given one.
+func audit(attrs []schema.Attr, a *Audit) (*Audit, bool) {
+	for _, o := range audits(attrs) {
+		if strings.EqualFold(o.Option, a.Option) && strings.EqualFold(o.When, a.When) {
+			return o, true
+		}
+	}
+	return nil, false
+}
+
+// ilmDiff returns the changes for migrating the ILM policies of a table. Policy
+// names are generated by the database, and therefore, policies are matched
+// by their definition, and only their ENABLED state can be modified.
+func ilmDiff(from, to []schema.Attr) []schema.Change {
+	var changes []schema.Change
+	for _, p1 := range ilmPolicies(from) {
+		switch p2, ok := ilmPolicy(to, p1); {
+		case !ok:
+			changes = append(changes, &schema.DropAttr{A: p1})
+		case p1.Enabled != p2.Enabled:
+			changes = append(changes, &schema.ModifyAttr{From: p1, To: p2})
+		}
+	}
+	for _, p2 := range ilmPolicies(to) {
+		if _, ok := ilmPolicy(from, p2); !ok {
+			changes = append(changes, &schema.AddAttr{A: p2})
+		}
+	}
+	return changes
+}
+
+// ilmPolicies returns the ILM policies from the given table attributes.
+func ilmPolicies(attrs []schema.Attr) []*ILMPolicy {
+	var ps []*ILMPolicy
+	for _, a := range attrs {
+		if p, ok := a.(*ILMPolicy); ok {
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
+
+// ilmPolicy returns the ILM policy from the attributes that is defined like the given one.
+func ilmPolicy(attrs []schema.Attr, p *ILMPolicy) (*ILMPolicy, bool) {
+	for _, o := range ilmPolicies(attrs) {
+		if strings.EqualFold(o.Action, p.Action) && strings.EqualFold(o.Scope, p.Scope) &&
+			strings.EqualFold(o.Compression, p.Compression) && strings.EqualFold(o.Tier, p.Tier) &&
+			strings.EqualFold(o.Condition, p.Condition) && o.Days == p.Days {
+			return o, true
+		}
+	}
+	return nil, false
+}
+
+// IndexPartAttrChanged reports if the index-part attributes were changed.
+func (*diff) IndexPartAttrChanged(from, to []schema.Attr) bool {
+	p1, p2 := &IndexColumnProperty{}, &IndexColumnProperty{}
+	sqlx.Has(from, p1)
+	sqlx.Has(to, p2)
+	return p1.Desc != p2.Desc
+}
+
+// ReferenceChanged reports if the foreign key referential action was changed.
+func (*diff) ReferenceChanged(from, to schema.ReferenceOption) bool {
+	// According to Oracle, the NO ACTION rule is set
+	// if no referential action was defined in foreign key.
+	if from == "" {
+		from = schema.NoAction
+	}
+	if to == "" {
+		to = schema.NoAction
+	}
+	return from != to
+}
+
+func (d *diff) typeChanged(from, to *schema.Column) (bool, error) {
+	fromT, toT := from.Type.Type, to.Type.Type
+	if fromT == nil || toT == nil {
+		return false, fmt.Errorf("oracle: missing type information for column %q", from.Name)
+	}
+	if reflect.TypeOf(fromT) != reflect.TypeOf(toT) {
+		return true, nil
+	}
+	switch fromT := fromT.(type) {
+	case *schema.BinaryType, *schema.DecimalType, *schema.FloatType, *schema.IntegerType,
+		*schema.TimeType, *IntervalType, *RowIDType, *CollectionType, *UserDefinedType, *XMLType, *schema.SpatialType:
+		return formatChanged(fromT, toT)
+	case *schema.StringType:
+		changed, err := formatChanged(fromT, toT)
+		if err != nil {
+			return false, err
+		}
+		return changed || d.semantics(fromT, from.Attrs) != d.semantics(fromT, to.Attrs), nil
+	default:
+		return false, &sqlx.UnsupportedTypeError{Type: fromT}
+	}
+}
+
+// formatChanged reports if the formatted types are different.
+func formatChanged(from, to schema.Type) (bool, error) {
+	f1, err := FormatType(from)
+	if err != nil {
+		return false, err
+	}
+	f2, err := FormatType(to)
+	if err != nil {
+		return false, err
+	}
+	return f1 != f2, nil
+}
+
+// semantics returns the length semantics of a character column. Columns
+// without the LengthSemantics attribute follow the session semantics.
+func (d *diff) semantics(t *schema.StringType, attrs []schema.Attr) string {
+	if !hasSemantics(t.T) {
+		return ""
+	}
+	if s := (&LengthSemantics{}); sqlx.Has(attrs, s) {
+		return strings.ToUpper(s.T)
+	}
+	return d.defaultSemantics()
+}
+
+// Normalize implements the sqlx.Normalizer interface.
+func (d *diff) Normalize(from, to *schema.Table) {
+	d.normalize(from)
+	d.normalize(to)
+}
+
+func (d *diff) normalize(table *schema.Table) {
+	for _, c := range table.Columns {
+		normalizeType(c)
+	}
+}
+
+// normalizeType converts the column type to the form that is reported
+// by the data dictionary. i.e. aliases are folded to their base types,
+// and the optional arguments are set to their default values.
+func normalizeType(c *schema.Column) {
+	switch t := c.Type.Type.(type) {
+	case nil:
+	case *schema.IntegerType:
+		// INTEGER, INT and SMALLINT are stored as NUMBER(38), and
+		// are reported this way by the data dictionary.
+		c.Type.Type = &schema.DecimalType{T: TypeNumber, Precision: maxNumberPrecision}
+	case *schema.DecimalType:
+		// DECIMAL and NUMERIC are synonyms for NUMBER, but
+		// unlike NUMBER, their precision defaults to 38.
+		switch strings.ToLower(t.T) {
+		case TypeDecimal, TypeNumeric:
+			if t.Precision == 0 && t.Scale == 0 {
+				t.Precision = maxNumberPrecision
+			}
+			t.T = TypeNumber
+		}
+	case *schema.FloatType:
+		// FLOAT without precision is equivalent to FLOAT(126).
+		if t.T == TypeFloat && t.Precision == 0 {
+			t.Precision = 126
+		}
+	case *schema.StringType:
+		switch t.T {
+		case TypeChar, TypeNChar:
+			// CHAR without length specifier
+			// is equivalent to CHAR(1).
+			if t.Size == 0 {
+				t.Size = 1
+			}
+		case TypeVarchar:
+			// VARCHAR is a synonym for VARCHAR2.
+			t.T = TypeVarchar2
+		}
+	case *schema.TimeType:
+		// The default fractional seconds precision is 6.
+		if t.T != TypeDate && t.Precision == nil {
+			t.Precision = intp(6)
+		}
+	case *IntervalType:
+		// The default leading field precision is 2,
+		// and the fractional seconds precision is 6.
+		if t.Precision == nil {
+			t.Precision = intp(2)
+		}
+		if t.T == TypeIntervalDS && t.Scale == nil {
+			t.Scale = intp(6)
+		}
+	}
+}
+
+// NormalizeRealm implements the schema.Normalizer interface. See
+// NormalizeSchema for details. The realm is normalized in place.
+func (d *Driver) NormalizeRealm(ctx context.Context, r *schema.Realm) (*schema.Realm, error) {
+	for _, s := range r.Schemas {
+		if _, err := d.NormalizeSchema(ctx, s); err != nil {
+			return nil, err
+		}
+	}
+	normalizeSynonyms(r.Attrs)
+	return r, nil
+}
+
+// NormalizeSchema implements the schema.Normalizer interface. It converts a schema
+// that was defined in its natural form (e.g. HCL) to the form it is reported by the
+// inspection, and allows diffing it against an inspected schema. Identifiers are
+// converted to their storage form, type aliases are folded to their base types, and
+// string literals are converted to SQL literals. The schema is normalized in place.
+func (d *Driver) NormalizeSchema(_ context.Context, s *schema.Schema) (*schema.Schema, error) {
+	s.Name = storageName(s.Name)
+	for _, a := range s.Attrs {
+		if seq, ok := a.(*Sequence); ok {
+			seq.Name = storageName(seq.Name)
+		}
+	}
+	normalizeSynonyms(s.Attrs)
+	for _, t := range s.Tables {
+		t.Name = storageName(t.Name)
+		for _, c := range t.Columns {
+			c.Name = storageName(c.Name)
+			normalizeType(c)
+			if x, ok := c.Default.(*schema.Literal); ok {
+				x.V = sqlLiteral(x.V)
+			}
+		}
+		if t.PrimaryKey != nil {
+			t.PrimaryKey.Name = storageName(t.PrimaryKey.Name)
+		}
+		for _, idx := range t.Indexes {
+			idx.Name = storageName(idx.Name)
+		}
+		for _, fk := range t.ForeignKeys {
+			fk.Symbol = storageName(fk.Symbol)
+		}
+		for _, a := range t.Attrs {
+			if c, ok := a.(*schema.Check); ok {
+				c.Name = storageName(c.Name)
+			}
+		}
+	}
+	return s, nil
+}
+
+// normalizeSynonyms converts the names of the synonyms in the given
+// attributes, and the names of the objects they reference.
+func normalizeSynonyms(attrs []schema.Attr) {
+	for _, a := range attrs {
+		if s, ok := a.(*Synonym); ok {
+			s.Name = storageName(s.Name)
+			s.TableSchema = storageName(s.TableSchema)
+			s.Table = storageName(s.Table)
+		}
+	}
+}
+
+// reUnquotedName matches lowercase names that are valid unquoted identifiers.
+var reUnquotedName = regexp.MustCompile(`^[a-z][a-z0-9_$#]*$`)
+
+// storageName returns the name of an object as it is stored in the data
+// dictionary. Unquoted identifiers are stored in uppercase, and since schema
+// documents do not distinguish between quoted and unquoted identifiers,
+// lowercase names are treated as unquoted. Names in mixed case, or names
+// that must be quoted (e.g. "my table"), are kept as-is.
+func storageName(name string) string {
+	if !reUnquotedName.MatchString(name) {
+		return name
+	}
+	return strings.ToUpper(name)
+}
+
+// sqlLiteral converts a double-quoted string literal (e.g. a string
+// attribute in HCL) to its SQL form. Other literals are returned as-is.
+func sqlLiteral(x string) string {
+	if !sqlx.IsQuoted(x, '"') {
+		return x
+	}
+	u, err := strconv.Unquote(x)
+	if err != nil {
+		return x
+	}
+	return "'" + strings.ReplaceAll(u, "'", "''") + "'"
+}
+
+// Default IDENTITY generation.
+const defaultIdentityGen = "ALWAYS"
+
+// identityChanged reports if one of the identity attributes was changed.
+func identityChanged(from, to []schema.Attr) bool {
+	i1, ok1 := identity(from)
+	i2, ok2 := identity(to)
+	if !ok1 && !ok2 || ok1 != ok2 {
+		return ok1 != ok2
+	}
+	// The START WITH value of emulated identity columns
+	// (< 12c) is not known on inspection, and it is unset.
+	startChanged := i1.Sequence.Start != 0 && i2.Sequence.Start != 0 && i1.Sequence.Start != i2.Sequence.Start
+	return i1.Generation != i2.Generation || startChanged || i1.Sequence.Increment != i2.Sequence.Increment
+}
+
+func identity(attrs []schema.Attr) (*Identity, bool) {
+	i := &Identity{}
+	if !sqlx.Has(attrs, i) {
+		return nil, false
+	}
+	if i.Generation == "" {
+		i.Generation = defaultIdentityGen
+	}
+	if i.Sequence == nil {
+		i.Sequence = &Sequence{Start: defaultSeqStart, Increment: defaultSeqIncrement}
+		return i, true
+	}
+	// The sequence is shared with the attribute, and
+	// it is copied to keep the defaults out of it.
+	seq := *i.Sequence
+	i.Sequence = &seq
+	// The START WITH value of inspected sequences is not known.
+	if i.Sequence.Start == 0 && i.Sequence.Last == 0 {
+		i.Sequence.Start = defaultSeqStart
+	}
+	if i.Sequence.Increment == 0 {
+		i.Sequence.Increment = defaultSeqIncrement
+	}
+	return i, true
+}
+
+// temporaryOf returns the ON COMMIT mode of a global
+// temporary table, or an empty string for regular tables.
+func temporaryOf(t *schema.Table) string {
+	tt := &Temporary{}
+	if !sqlx.Has(t.Attrs, tt) {
+		return ""
+	}
+	if tt.OnCommit == "" {
+		return OnCommitDelete
+	}
+	return strings.ToUpper(tt.OnCommit)
+}
+
+// organizationOf returns the organization of a table. Tables
+// without the Organization attribute are heap-organized.
+func organizationOf(t *schema.Table) string {
+	o := &Organization{}
+	if !sqlx.Has(t.Attrs, o) || o.T == "" {
+		return OrganizationHeap
+	}
+	return strings.ToUpper(o.T)
+}
+
+// sequences returns the standalone sequences from the given schema attributes.
+func sequences(attrs []schema.Attr) []*Sequence {
+	var seqs []*Sequence
+	for _, a := range attrs {
+		if s, ok := a.(*Sequence); ok {
+			seqs = append(seqs, s)
+		}
+	}
+	return seqs
+}
+
+// emulatedSequences returns the names of the sequences that back the identity
+// columns of the given schemas in versions that do not support identity (< 12c).
+func emulatedSequences(schemas ...*schema.Schema) map[string]bool {
+	names := make(map[string]bool)
+	for _, s := range schemas {
+		for _, t := range s.Tables {
+			for _, c := range t.Columns {
+				if _, ok := identity(c.Attrs); ok {
+					seq, _ := identityObjectNames(t.Name, c.Name)
+					names[seq] = true
+				}
+			}
+		}
+	}
+	return names
+}
+
+// sequence returns the sequence with the given name from the schema attributes.
+func sequence(attrs []schema.Attr, name string) (*Sequence, bool) {
+	for _, s := range sequences(attrs) {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// seqChanged reports if one of the sequence options was changed. The START WITH
+// value is compared only if it is set on both sequences, as it is not reported
+// by the inspection, and the current value of the sequence (Last) is ignored.
+func seqChanged(from, to *Sequence) bool {
+	f, t := *from, *to
+	f.Attr, t.Attr = nil, nil
+	f.Last, t.Last = 0, 0
+	if f.Start == 0 || t.Start == 0 {
+		f.Start, t.Start = 0, 0
+	}
+	return f != t
+}
+
+// invisibleChanged reports if the visibility of a column was changed.
+func invisibleChanged(from, to []schema.Attr) bool {
+	return sqlx.Has(from, &Invisible{}) != sqlx.Has(to, &Invisible{})
+}
+
+// virtualChanged reports if the expression of a virtual column was changed.
+func virtualChanged(from, to []schema.Attr) bool {
+	v1, v2 := &Virtual{}, &Virtual{}
+	return sqlx.Has(from, v1) != sqlx.Has(to, v2) || strings.TrimSpace(v1.Expr) != strings.TrimSpace(v2.Expr)
+}
+
+// lobStorageChanged reports if the storage options of a LOB column were changed.
+// The storage is compared only if it is set on both columns, as LOBs that are
+// declared without it use the defaults of the database.
+func lobStorageChanged(from, to []schema.Attr) bool {
+	s1, s2 := &LOBStorage{}, &LOBStorage{}
+	if !sqlx.Has(from, s1) || !sqlx.Has(to, s2) {
+		return false
+	}
+	return s1.SecureFile != s2.SecureFile || s1.OutOfRow != s2.OutOfRow || !s1.SecureFile && (s1.PctVersion != s2.PctVersion || s1.Retention != s2.Retention)
+}
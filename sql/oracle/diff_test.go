@@ -0,0 +1,527 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package oracle
+
+import (
+	"testing"
+
+	"ariga.io/atlas/sql/schema"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff_TableDiff(t *testing.T) {
+	type testcase struct {
+		name        string
+		from, to    *schema.Table
+		wantChanges []schema.Change
+		wantErr     bool
+	}
+	tests := []testcase{
+		{
+			name: "no changes",
+			from: &schema.Table{Name: "USERS", Schema: &schema.Schema{Name: "ATLAS"}},
+			to:   &schema.Table{Name: "USERS"},
+		},
+		{
+			name: "change primary key columns",
+			from: func() *schema.Table {
+				t := &schema.Table{Name: "USERS", Schema: &schema.Schema{Name: "ATLAS"}, Columns: []*schema.Column{{Name: "ID", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 10}}}}}
+				t.PrimaryKey = &schema.Index{
+					Parts: []*schema.IndexPart{{C: t.Columns[0]}},
+				}
+				return t
+			}(),
+			to:      &schema.Table{Name: "USERS"},
+			wantErr: true,
+		},
+		func() testcase {
+			var (
+				from = &schema.Table{
+					Name: "USERS",
+					Columns: []*schema.Column{
+						{Name: "ID", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 10}}},
+						{Name: "PRICE", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 10, Scale: 2}}},
+						{Name: "NAME", Type: &schema.ColumnType{Raw: "VARCHAR2", Type: &schema.StringType{T: "varchar2", Size: 100}}, Attrs: []schema.Attr{&schema.Charset{V: "AL32UTF8"}}},
+						{Name: "TOTAL", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 38}}, Default: &schema.Literal{V: "0"}},
+					},
+				}
+				to = &schema.Table{
+					Name: "USERS",
+					Columns: []*schema.Column{
+						{Name: "ID", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 10}}},
+						{Name: "PRICE", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 12, Scale: 2}}},
+						{Name: "NAME", Type: &schema.ColumnType{Raw: "VARCHAR2", Type: &schema.StringType{T: "varchar2", Size: 100}}, Attrs: []schema.Attr{&LengthSemantics{T: SemanticsChar}}},
+						{Name: "TOTAL", Type: &schema.ColumnType{Raw: "INTEGER", Type: &schema.IntegerType{T: "integer"}}, Default: &schema.Literal{V: "0 "}},
+						{Name: "EMAIL", Type: &schema.ColumnType{Raw: "VARCHAR2", Type: &schema.StringType{T: "varchar2", Size: 255}, Null: true}},
+					},
+				}
+			)
+			return testcase{
+				name: "columns",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&schema.ModifyColumn{From: from.Columns[1], To: to.Columns[1], Change: schema.ChangeType},
+					&schema.ModifyColumn{From: from.Columns[2], To: to.Columns[2], Change: schema.ChangeType},
+					&schema.AddColumn{C: to.Columns[4]},
+				},
+			}
+		}(),
+		func() testcase {
+			var (
+				from = &schema.Table{
+					Name: "USERS",
+					Columns: []*schema.Column{
+						{Name: "ID", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 38}}, Attrs: []schema.Attr{&Identity{Generation: "BY DEFAULT", Sequence: &Sequence{Start: 1, Increment: 1}}}},
+						{Name: "SEQ", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 38}}},
+					},
+				}
+				to = &schema.Table{
+					Name: "USERS",
+					Columns: []*schema.Column{
+						{Name: "ID", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 38}}, Attrs: []schema.Attr{&Identity{Generation: "BY DEFAULT"}}},
+						{Name: "SEQ", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 38}}, Attrs: []schema.Attr{&Identity{Sequence: &Sequence{Start: 100}}}},
+					},
+				}
+			)
+			return testcase{
+				name: "identity",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&schema.ModifyColumn{From: from.Columns[1], To: to.Columns[1], Change: schema.ChangeAttr},
+				},
+			}
+		}(),
+		func() testcase {
+			var (
+				from = &schema.Table{
+					Name: "USERS",
+					Columns: []*schema.Column{
+						{Name: "A", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 10}}},
+						{Name: "B", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 10}}},
+					},
+				}
+				to = &schema.Table{
+					Name: "USERS",
+					Columns: []*schema.Column{
+						{Name: "A", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 10}}},
+						{Name: "B", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 10}}},
+					},
+				}
+			)
+			from.Indexes = []*schema.Index{
+				{Name: "A_IDX", Table: from, Parts: []*schema.IndexPart{{SeqNo: 1, C: from.Columns[0]}}, Attrs: []schema.Attr{&IndexType{T: "NORMAL"}}},
+				{Name: "B_IDX", Table: from, Parts: []*schema.IndexPart{{SeqNo: 1, C: from.Columns[1]}}, Attrs: []schema.Attr{&IndexType{T: "NORMAL"}}},
+				{Name: "AB_IDX", Table: from, Parts: []*schema.IndexPart{{SeqNo: 1, C: from.Columns[0]}, {SeqNo: 2, C: from.Columns[1], Attrs: []schema.Attr{&IndexColumnProperty{Desc: true}}}}, Attrs: []schema.Attr{&IndexType{T: "FUNCTION-BASED NORMAL"}}},
+			}
+			to.Indexes = []*schema.Index{
+				{Name: "A_IDX", Table: to, Parts: []*schema.IndexPart{{SeqNo: 1, C: to.Columns[0]}}},
+				{Name: "AB_IDX", Table: to, Parts: []*schema.IndexPart{{SeqNo: 1, C: to.Columns[0]}, {SeqNo: 2, C: to.Columns[1], Attrs: []schema.Attr{&IndexColumnProperty{Desc: true}}}}, Attrs: []schema.Attr{&IndexType{T: "BITMAP"}}},
+			}
+			return testcase{
+				name: "indexes",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&schema.DropIndex{I: from.Indexes[1]},
+					&schema.ModifyIndex{From: from.Indexes[2], To: to.Indexes[1], Change: schema.ChangeAttr},
+				},
+			}
+		}(),
+		func() testcase {
+			var (
+				from = &schema.Table{Name: "USERS", Columns: []*schema.Column{{Name: "A", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 10}}}}}
+				to   = &schema.Table{Name: "USERS", Columns: []*schema.Column{{Name: "A", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 10}}}}}
+			)
+			from.Indexes = []*schema.Index{{Name: "A_IDX", Table: from, Parts: []*schema.IndexPart{{SeqNo: 1, C: from.Columns[0]}}, Attrs: []schema.Attr{&IndexType{T: "NORMAL"}}}}
+			to.Indexes = []*schema.Index{{Name: "A_IDX", Table: to, Parts: []*schema.IndexPart{{SeqNo: 1, C: to.Columns[0]}}, Attrs: []schema.Attr{&LocalIndex{}}}}
+			return testcase{
+				name: "index locality",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&schema.ModifyIndex{From: from.Indexes[0], To: to.Indexes[0], Change: schema.ChangeAttr},
+				},
+			}
+		}(),
+		// ROWDEPENDENCIES cannot be altered.
+		{
+			name:    "row dependencies",
+			from:    &schema.Table{Name: "T1"},
+			to:      &schema.Table{Name: "T1", Attrs: []schema.Attr{&RowDependencies{}}},
+			wantErr: true,
+		},
+		{
+			name:    "temporary table",
+			from:    &schema.Table{Name: "T1", Attrs: []schema.Attr{&Temporary{OnCommit: OnCommitDelete}}},
+			to:      &schema.Table{Name: "T1", Attrs: []schema.Attr{&Temporary{OnCommit: OnCommitPreserve}}},
+			wantErr: true,
+		},
+		{
+			name: "add check",
+			from: &schema.Table{Name: "T1", Attrs: []schema.Attr{&schema.Check{Name: "T1_C1_CHECK", Expr: `"C1" > 1`, Attrs: []schema.Attr{&CheckColumns{Columns: []string{"C1"}}}}}},
+			to: &schema.Table{Name: "T1", Attrs: []schema.Attr{
+				&schema.Check{Name: "T1_C1_CHECK", Expr: `"C1" > 1`},
+				&schema.Check{Name: "T1_C2_CHECK", Expr: `"C2" > 1`},
+			}},
+			wantChanges: []schema.Change{
+				&schema.AddCheck{
+					C: &schema.Check{Name: "T1_C2_CHECK", Expr: `"C2" > 1`},
+				},
+			},
+		},
+		func() testcase {
+			fk := func(t *schema.Table, onUpdate, onDelete schema.ReferenceOption) *schema.Table {
+				t.Columns = []*schema.Column{{Name: "PID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}}}
+				t.ForeignKeys = []*schema.ForeignKey{{Symbol: "T1_FK", Table: t, Columns: t.Columns, RefTable: t, RefColumns: t.Columns, OnUpdate: onUpdate, OnDelete: onDelete}}
+				t.Columns[0].ForeignKeys = t.ForeignKeys
+				return t
+			}
+			// Inspected foreign keys always have the NO ACTION rule
+			// for updates, while it is usually unset in the desired state.
+			from := fk(&schema.Table{Name: "T1"}, schema.NoAction, schema.Cascade)
+			to := fk(&schema.Table{Name: "T1"}, "", schema.SetNull)
+			return testcase{
+				name: "modify foreign key delete rule",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&schema.ModifyForeignKey{From: from.ForeignKeys[0], To: to.ForeignKeys[0], Change: schema.ChangeDeleteAction},
+				},
+			}
+		}(),
+		func() testcase {
+			fk := func(t *schema.Table) *schema.Table {
+				t.Columns = []*schema.Column{{Name: "PID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}}}
+				t.ForeignKeys = []*schema.ForeignKey{{Symbol: "T1_FK", Table: t, Columns: t.Columns, RefTable: t, RefColumns: t.Columns, OnUpdate: schema.NoAction}}
+				t.Columns[0].ForeignKeys = t.ForeignKeys
+				return t
+			}
+			// The desired foreign key is in its default state.
+			from := fk(&schema.Table{Name: "T1", Attrs: []schema.Attr{&ConstraintState{Name: "T1_FK", NoValidate: true}}})
+			to := fk(&schema.Table{Name: "T1"})
+			return testcase{
+				name: "modify foreign key state",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&schema.ModifyAttr{From: &ConstraintState{Name: "T1_FK", NoValidate: true}, To: &ConstraintState{Name: "T1_FK"}},
+				},
+			}
+		}(),
+		func() testcase {
+			var (
+				from = &schema.Table{Name: "T1", Attrs: []schema.Attr{&schema.Check{Name: "T1_C1_CHECK", Expr: `"C1" > 1`}}}
+				to   = &schema.Table{Name: "T1", Attrs: []schema.Attr{&schema.Check{Name: "T1_C1_CHECK", Expr: `"C1" > 1`, Attrs: []schema.Attr{&ConstraintState{Name: "T1_C1_CHECK", Disabled: true}}}}}
+			)
+			return testcase{
+				name: "modify check state",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&schema.ModifyCheck{From: from.Attrs[0].(*schema.Check), To: to.Attrs[0].(*schema.Check)},
+				},
+			}
+		}(),
+		{
+			name: "change organization",
+			from: &schema.Table{Name: "T1"},
+			to:   &schema.Table{Name: "T1", Attrs: []schema.Attr{&Organization{T: OrganizationIndex}}},
+			// Converting a heap table to an IOT requires recreating it.
+			wantErr: true,
+		},
+		func() testcase {
+			pk := func(t *schema.Table, attrs ...schema.Attr) *schema.Table {
+				t.Columns = []*schema.Column{{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}}}
+				t.PrimaryKey = &schema.Index{Name: "T1_PK", Unique: true, Table: t, Parts: []*schema.IndexPart{{SeqNo: 1, C: t.Columns[0]}}, Attrs: attrs}
+				return t
+			}
+			// The primary key of an inspected IOT is reported as an IOT - TOP index.
+			return testcase{
+				name: "index-organized table",
+				from: pk(&schema.Table{Name: "T1", Attrs: []schema.Attr{&Organization{T: OrganizationIndex, PctThreshold: 50}}}, &IndexType{T: "IOT - TOP"}, &ConType{T: "P"}),
+				to:   pk(&schema.Table{Name: "T1", Attrs: []schema.Attr{&Organization{T: "index"}}}),
+			}
+		}(),
+		{
+			name: "modify comment",
+			from: &schema.Table{Name: "T1", Attrs: []schema.Attr{&schema.Comment{Text: "t1"}}},
+			to:   &schema.Table{Name: "T1", Attrs: []schema.Attr{&schema.Comment{Text: "t1!"}}},
+			wantChanges: []schema.Change{
+				&schema.ModifyAttr{
+					From: &schema.Comment{Text: "t1"},
+					To:   &schema.Comment{Text: "t1!"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		db, m, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{m}.version("19.0.0.0.0")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := drv.TableDiff(tt.from, tt.to)
+			require.Equal(t, tt.wantErr, err != nil)
+			require.EqualValues(t, tt.wantChanges, changes)
+		})
+	}
+}
+
+func TestDiff_LengthSemantics(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.params("19.0.0.0.0", SemanticsChar)
+	drv, err := Open(db)
+	require.NoError(t, err)
+	// Columns without the LengthSemantics attribute follow the session semantics.
+	from := &schema.Table{Name: "T1", Columns: []*schema.Column{{Name: "C1", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar2", Size: 10}}}}}
+	to := &schema.Table{Name: "T1", Columns: []*schema.Column{{Name: "C1", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar2", Size: 10}}, Attrs: []schema.Attr{&LengthSemantics{T: SemanticsChar}}}}}
+	changes, err := drv.TableDiff(from, to)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+	to.Columns[0].Attrs = []schema.Attr{&LengthSemantics{T: SemanticsByte}}
+	changes, err = drv.TableDiff(from, to)
+	require.NoError(t, err)
+	require.Equal(t, []schema.Change{&schema.ModifyColumn{From: from.Columns[0], To: to.Columns[0], Change: schema.ChangeType}}, changes)
+}
+
+func TestDiff_Collation(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	column := func(collation string) *schema.Column {
+		c := &schema.Column{Name: "C1", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar2", Size: 10}}}
+		if collation != "" {
+			c.Attrs = append(c.Attrs, &schema.Collation{V: collation})
+		}
+		return c
+	}
+	for _, tt := range []struct {
+		from, to string
+		changed  bool
+	}{
+		// The default pseudo-collation is equal to no collation.
+		{from: "", to: DefaultCollation},
+		{from: "BINARY_CI", to: "binary_ci"},
+		{from: "", to: "BINARY_CI", changed: true},
+		{from: "BINARY_CI", to: "USING_NLS_SORT_CI", changed: true},
+	} {
+		from := &schema.Table{Name: "T1", Columns: []*schema.Column{column(tt.from)}}
+		to := &schema.Table{Name: "T1", Columns: []*schema.Column{column(tt.to)}}
+		changes, err := drv.TableDiff(from, to)
+		require.NoError(t, err)
+		if !tt.changed {
+			require.Empty(t, changes)
+			continue
+		}
+		require.Equal(t, []schema.Change{&schema.ModifyColumn{From: from.Columns[0], To: to.Columns[0], Change: schema.ChangeCollation}}, changes)
+	}
+}
+
+func TestDiff_DefaultFuncs(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	for _, tt := range []struct {
+		from, to string
+		changed  bool
+	}{
+		{from: "SYSTIMESTAMP", to: "CURRENT_TIMESTAMP"},
+		{from: "SYSTIMESTAMP ", to: "now()"},
+		{from: "SYSDATE", to: "current_date"},
+		{from: "LOCALTIMESTAMP", to: "localtimestamp"},
+		{from: "SYSDATE", to: "CURRENT_TIMESTAMP", changed: true},
+	} {
+		from := &schema.Table{Name: "T1", Columns: []*schema.Column{{Name: "C1", Type: &schema.ColumnType{Type: &schema.TimeType{T: "timestamp"}}, Default: &schema.RawExpr{X: tt.from}}}}
+		to := &schema.Table{Name: "T1", Columns: []*schema.Column{{Name: "C1", Type: &schema.ColumnType{Type: &schema.TimeType{T: "timestamp"}}, Default: &schema.RawExpr{X: tt.to}}}}
+		changes, err := drv.TableDiff(from, to)
+		require.NoError(t, err)
+		if !tt.changed {
+			require.Empty(t, changes, tt.to)
+			continue
+		}
+		require.Equal(t, []schema.Change{&schema.ModifyColumn{From: from.Columns[0], To: to.Columns[0], Change: schema.ChangeDefault}}, changes)
+	}
+}
+
+func TestDiff_CharDefaults(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	for _, tt := range []struct {
+		typ      string
+		from, to string
+		changed  bool
+	}{
+		{typ: TypeChar, from: "'A  '", to: "'A'"},
+		{typ: TypeChar, from: "'A  '", to: "A"},
+		{typ: TypeNChar, from: "N'A  '", to: "N'A'"},
+		{typ: TypeChar, from: "'A  '", to: "'B'", changed: true},
+		{typ: TypeChar, from: "'  A'", to: "'A'", changed: true},
+		// Values of VARCHAR2 columns are not padded.
+		{typ: TypeVarchar2, from: "'A  '", to: "'A'", changed: true},
+	} {
+		typ := &schema.ColumnType{Type: &schema.StringType{T: tt.typ, Size: 3}}
+		from := &schema.Table{Name: "T1", Columns: []*schema.Column{{Name: "C1", Type: typ, Default: &schema.Literal{V: tt.from}}}}
+		to := &schema.Table{Name: "T1", Columns: []*schema.Column{{Name: "C1", Type: typ, Default: &schema.Literal{V: tt.to}}}}
+		changes, err := drv.TableDiff(from, to)
+		require.NoError(t, err)
+		if !tt.changed {
+			require.Empty(t, changes, tt.to)
+			continue
+		}
+		require.Equal(t, []schema.Change{&schema.ModifyColumn{From: from.Columns[0], To: to.Columns[0], Change: schema.ChangeDefault}}, changes)
+	}
+}
+
+func TestDiff_SchemaDiff(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	from := &schema.Schema{
+		Tables: []*schema.Table{
+			{Name: "USERS"},
+			{Name: "PETS"},
+		},
+	}
+	to := &schema.Schema{
+		Tables: []*schema.Table{
+			{
+				Name: "USERS",
+				Columns: []*schema.Column{
+					{Name: "T2_ID", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 10}}},
+				},
+			},
+			{Name: "GROUPS"},
+		},
+	}
+	from.Tables[0].Schema = from
+	from.Tables[1].Schema = from
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.ModifyTable{T: to.Tables[0], Changes: []schema.Change{&schema.AddColumn{C: to.Tables[0].Columns[0]}}},
+		&schema.DropTable{T: from.Tables[1]},
+		&schema.AddTable{T: to.Tables[1]},
+	}, changes)
+}
+
+func TestDiff_SequenceDiff(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	from := &schema.Schema{
+		Name: "ATLAS",
+		Attrs: []schema.Attr{
+			&Sequence{Name: "A_SEQ", Start: 1, Increment: 1, Cache: 20},
+			&Sequence{Name: "B_SEQ", Start: 1, Increment: 1, Cache: 20},
+			&Sequence{Name: "C_SEQ", Start: 1, Increment: 1, Cache: 20},
+		},
+	}
+	to := &schema.Schema{
+		Name: "ATLAS",
+		Attrs: []schema.Attr{
+			&Sequence{Name: "A_SEQ", Start: 1, Increment: 1, Cache: 20},
+			&Sequence{Name: "B_SEQ", Start: 1, Increment: 2, Cache: 20},
+			&Sequence{Name: "D_SEQ", Start: 1, Increment: 1},
+		},
+	}
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.ModifySchema{S: to, Changes: []schema.Change{
+			&schema.ModifyAttr{From: from.Attrs[1], To: to.Attrs[1]},
+			&schema.DropAttr{A: from.Attrs[2]},
+			&schema.AddAttr{A: to.Attrs[2]},
+		}},
+	}, changes)
+
+	// Used sequences are inspected with their last number and without
+	// their START WITH value, and they are not reported as changed.
+	from.Attrs[0] = &Sequence{Name: "A_SEQ", Last: 21, Increment: 1, Cache: 20}
+	changes, err = drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.Len(t, changes[0].(*schema.ModifySchema).Changes, 3)
+
+	// The last sequence of the schema can be dropped.
+	changes, err = drv.SchemaDiff(&schema.Schema{Name: "ATLAS", Attrs: from.Attrs[:1]}, &schema.Schema{Name: "ATLAS"})
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, []schema.Change{&schema.DropAttr{A: from.Attrs[0]}}, changes[0].(*schema.ModifySchema).Changes)
+
+	// Unmanaged sequences are kept if the desired schema does not declare them.
+	mock{m}.version("19.0.0.0.0")
+	drv, err = Open(db, WithUnmanagedSequences())
+	require.NoError(t, err)
+	changes, err = drv.SchemaDiff(from, &schema.Schema{Name: "ATLAS"})
+	require.NoError(t, err)
+	require.Empty(t, changes)
+	changes, err = drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.Equal(t, []schema.Change{
+		&schema.ModifySchema{S: to, Changes: []schema.Change{
+			&schema.ModifyAttr{From: from.Attrs[1], To: to.Attrs[1]},
+			&schema.AddAttr{A: to.Attrs[2]},
+		}},
+	}, changes)
+}
+
+func TestDiff_EmulatedIdentitySequences(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("11.2.0.4.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	users := func(id *Identity) *schema.Table {
+		return &schema.Table{Name: "USERS", Columns: []*schema.Column{{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number"}}, Attrs: []schema.Attr{id}}}}
+	}
+	from := schema.New("ATLAS").AddTables(users(&Identity{Generation: "ALWAYS", Sequence: &Sequence{Increment: 1, Last: 21}, SequenceName: "USERS_ID_SEQ"}))
+	from.AddAttrs(
+		&Sequence{Name: "USERS_ID_SEQ", Last: 21, Increment: 1, Cache: 20},
+		&Sequence{Name: "ORDERS_SEQ", Start: 1, Increment: 1},
+	)
+	to := schema.New("ATLAS").AddTables(users(&Identity{}))
+	to.AddAttrs(&Sequence{Name: "ORDERS_SEQ", Start: 1, Increment: 1})
+	// The sequence of the emulated identity column is not dropped,
+	// and the column is not reported as changed.
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
+
+func TestDiff_IdentityDefaults(t *testing.T) {
+	seq := &Sequence{Start: 10}
+	attrs := []schema.Attr{&Identity{Sequence: seq}}
+	require.False(t, identityChanged(attrs, []schema.Attr{&Identity{Sequence: &Sequence{Start: 10, Increment: 1}}}))
+	// Defaults are not written to the sequence of the attribute.
+	require.Equal(t, &Sequence{Start: 10}, seq)
+}
+
+func TestDiff_IsGeneratedIndexName(t *testing.T) {
+	d := &diff{}
+	for name, expect := range map[string]bool{
+		"SYS_C0012345": true,
+		"SYS_C":        false,
+		"SYS_CX1":      false,
+		"USERS_PK":     false,
+	} {
+		require.Equal(t, expect, d.IsGeneratedIndexName(nil, &schema.Index{Name: name}), name)
+	}
+}
@@ -0,0 +1,477 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"ariga.io/atlas/sql/internal/sqlx"
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/schema"
+
+	"golang.org/x/mod/semver"
+)
+
+const (
+	// minVersion is the minimum Oracle release supported by the driver (11g).
+	minVersion = "11.0.0"
+	// Releases that introduced the features that are gated by the driver.
+	version12c = "12.1.0"
+	version18c = "18.0.0"
+)
+
+type (
+	// Driver represents an Oracle driver for introspecting database schemas,
+	// generating diff between schema elements and apply migrations changes.
+	Driver struct {
+		conn
+		schema.Differ
+		schema.Inspector
+		migrate.PlanApplier
+	}
+
+	// database connection and its information.
+	conn struct {
+		schema.ExecQuerier
+		// System variables that are set on `Open`.
+		version string
+		// The default length semantics of character columns
+		// in the session (NLS_LENGTH_SEMANTICS), BYTE or CHAR.
+		lengthSemantics string
+		// The collation that is used by the session for comparing character
+		// values (derived from NLS_COMP and NLS_SORT), and the character set
+		// of the database (NLS_CHARACTERSET).
+		collate, charset string
+		// The name of the container (PDB) the session is connected
+		// to in multitenant setups. Empty for versions < 12c.
+		container string
+		// Options that are set on `Open`.
+		rawConstraints bool
+		ilm            bool
+		dba            bool
+		portable       bool
+		sessionSchema  bool
+		separatePK     bool
+		lastDDL        bool
+		audit          bool
+		unmanagedSeqs  bool
+		// Name prefixes of objects that are excluded from inspection.
+		excludePrefixes []string
+	}
+
+	// Option allows configuring the driver on Open.
+	Option func(*conn)
+)
+
+// WithRawConstraints configures the driver to capture the DDL of the table
+// constraints using DBMS_METADATA.GET_DDL on inspection, and store it in the
+// RawConstraint attribute. It allows keeping the constraint attributes that
+// are not modeled by the driver (e.g. the storage of their backing indexes).
+func WithRawConstraints() Option {
+	return func(c *conn) {
+		c.rawConstraints = true
+	}
+}
+
+// WithILM configures the driver to inspect the Automatic Data Optimization
+// (ILM) policies of the tables, store them in the ILMPolicy attribute, and diff them.
+// The option is ignored for versions that do not support ILM (< 12c).
+func WithILM() Option {
+	return func(c *conn) {
+		c.ilm = true
+	}
+}
+
+// WithLastDDL configures the driver to capture the time of the last DDL
+// statement that modified each table (ALL_OBJECTS.LAST_DDL_TIME) on
+// inspection, and store it in the LastDDL attribute. It allows tooling
+// to detect schema drift by comparing it against a known baseline.
+func WithLastDDL() Option {
+	return func(c *conn) {
+		c.lastDDL = true
+	}
+}
+
+// WithAudit configures the driver to inspect the object auditing options of the
+// tables (DBA_OBJ_AUDIT_OPTS), store them in Audit attributes, and diff them, to
+// not drop auditing settings silently. Querying the options requires access to
+// the DBA_* views. Unified audit policies are not per-object, and are not handled.
+func WithAudit() Option {
+	return func(c *conn) {
+		c.audit = true
+	}
+}
+
+// WithDBAViews configures the driver to inspect the database using the DBA_*
+// data dictionary views instead of the ALL_* views. Unlike the ALL_* views,
+// which describe only the objects that are accessible to the current user,
+// the DBA_* views describe all objects in the database. If the current user
+// is not privileged to query them, the driver falls back to the ALL_* views.
+func WithDBAViews() Option {
+	return func(c *conn) {
+		c.dba = true
+	}
+}
+
+// WithContainer configures the driver to switch the session to the given
+// container (e.g. a pluggable database) using ALTER SESSION SET CONTAINER on
+// Open. Note that the statement affects only the session it was executed on,
+// and therefore, the option should be used with a single connection (sql.Conn).
+// The container name is case-sensitive.
+func WithContainer(name string) Option {
+	return func(c *conn) {
+		c.container = name
+	}
+}
+
+// WithPortable configures the planner to emit only the logical definition of
+// tables and indexes, and suppress their Oracle-specific physical clauses (e.g.
+// TABLESPACE, ROWDEPENDENCIES or REVERSE). It is useful for keeping the planned
+// DDL portable between Oracle installations, or other databases.
+func WithPortable() Option {
+	return func(c *conn) {
+		c.portable = true
+	}
+}
+
+// WithSessionSchema configures the planner to switch the current schema of the
+// session using ALTER SESSION SET CURRENT_SCHEMA before the statements of each
+// schema, and to emit the names of its objects unqualified. By default, object
+// names are qualified with their schema names. Note that the session schema is
+// not restored after the changes are applied.
+func WithSessionSchema() Option {
+	return func(c *conn) {
+		c.sessionSchema = true
+	}
+}
+
+// WithSeparatePrimaryKeys configures the planner to create the primary keys of
+// new tables in two steps: creating their unique index with CREATE UNIQUE INDEX,
+// and then adding the constraint using it with ALTER TABLE ADD PRIMARY KEY USING
+// INDEX. It allows tuning the index creation (e.g. ONLINE or PARALLEL) of large
+// tables separately. Index-organized tables are always created with an inline
+// primary key, as it is part of their definition.
+func WithSeparatePrimaryKeys() Option {
+	return func(c *conn) {
+		c.separatePK = true
+	}
+}
+
+// WithUnmanagedSequences configures the differ to not drop the existing sequences
+// that are missing from the desired state. It should be used when the desired
+// state is loaded from a format that cannot express sequences (e.g. HCL), and
+// the sequences of the schema are managed outside of Atlas.
+func WithUnmanagedSequences() Option {
+	return func(c *conn) {
+		c.unmanagedSeqs = true
+	}
+}
+
+// WithExcludePrefixes configures the inspector to skip tables, views and
+// sequences whose names start with one of the given prefixes. It is useful for
+// excluding objects that are managed by frameworks, like Oracle APEX (APEX$,
+// WWV_), from schemas that are managed by Atlas. Prefixes are case-sensitive.
+func WithExcludePrefixes(prefixes ...string) Option {
+	return func(c *conn) {
+		c.excludePrefixes = append(c.excludePrefixes, prefixes...)
+	}
+}
+
+// Open opens a new Oracle driver.
+func Open(db schema.ExecQuerier, opts ...Option) (*Driver, error) {
+	c := conn{ExecQuerier: db}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.container != "" {
+		if _, err := db.ExecContext(context.Background(), Build("ALTER SESSION SET CONTAINER =").Ident(c.container).String()); err != nil {
+			return nil, fmt.Errorf("oracle: switching to container %q: %w", c.container, err)
+		}
+	}
+	rows, err := db.QueryContext(context.Background(), paramsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("oracle: scanning system variables: %w", err)
+	}
+	var version, semantics, comp, sort, charset sql.NullString
+	if err := sqlx.ScanOne(rows, &version, &semantics, &comp, &sort, &charset); err != nil {
+		return nil, fmt.Errorf("oracle: failed scanning system variables: %w", err)
+	}
+	if c.version, err = parseVersion(version.String); err != nil {
+		return nil, err
+	}
+	// BYTE is the default value of NLS_LENGTH_SEMANTICS.
+	c.lengthSemantics = SemanticsByte
+	if strings.EqualFold(semantics.String, SemanticsChar) {
+		c.lengthSemantics = SemanticsChar
+	}
+	c.collate, c.charset = sessionCollation(comp.String, sort.String), charset.String
+	if c.ltV(minVersion) {
+		return nil, fmt.Errorf("oracle: unsupported oracle version: %s", c.version)
+	}
+	// Multitenant architecture was introduced in 12c.
+	if c.supportsContainers() {
+		rows, err := db.QueryContext(context.Background(), containerQuery)
+		if err != nil {
+			return nil, fmt.Errorf("oracle: querying container name: %w", err)
+		}
+		var name sql.NullString
+		if err := sqlx.ScanOne(rows, &name); err != nil {
+			return nil, fmt.Errorf("oracle: scanning container name: %w", err)
+		}
+		c.container = name.String
+	}
+	if c.dba {
+		rows, err := db.QueryContext(context.Background(), dbaViewsQuery)
+		switch {
+		// The DBA_* views do not exist for users that were not
+		// granted the SELECT_CATALOG_ROLE (or a similar privilege).
+		case err != nil && isPrivilegeErr(err):
+			c.dba = false
+		case err != nil:
+			return nil, fmt.Errorf("oracle: checking data dictionary access: %w", err)
+		default:
+			if err := rows.Close(); err != nil {
+				return nil, fmt.Errorf("oracle: checking data dictionary access: %w", err)
+			}
+		}
+	}
+	return &Driver{
+		conn:        c,
+		Differ:      &sqlx.Diff{DiffDriver: &diff{c}},
+		Inspector:   &inspect{c},
+		PlanApplier: &planApply{c},
+	}, nil
+}
+
+// Version returns the version of the connected database server
+// in its "major.minor.patch" form. For example, "19.0.0".
+func (d *Driver) Version() string {
+	return d.version
+}
+
+// Collation returns the collation that is used by the session for comparing
+// character values, and therefore, by columns that use the default collation
+// (USING_NLS_COMP). For example, "BINARY" or "GENERIC_M_CI".
+func (d *Driver) Collation() string {
+	return d.collate
+}
+
+// Charset returns the database character set. For example, "AL32UTF8".
+func (d *Driver) Charset() string {
+	return d.charset
+}
+
+// sessionCollation returns the collation that is used for comparing character
+// values according to the NLS_COMP and NLS_SORT parameters of the session.
+// Comparisons are binary, unless NLS_COMP is set to LINGUISTIC (or ANSI), and
+// missing parameters are treated as their default values (i.e. BINARY).
+func sessionCollation(comp, sort string) string {
+	if comp == "" || sort == "" || strings.EqualFold(comp, "BINARY") {
+		return "BINARY"
+	}
+	return strings.ToUpper(sort)
+}
+
+// excluded reports if the object with the given name
+// is excluded from inspection by one of its prefixes.
+func (c *conn) excluded(name string) bool {
+	for _, p := range c.excludePrefixes {
+		if strings.HasPrefix(name, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// supportsIdentity reports if the connected database supports
+// identity columns (and the ALL_TAB_IDENTITY_COLS view).
+func (c *conn) supportsIdentity() bool {
+	return c.gteV(version12c)
+}
+
+// supportsDefaultOnNull reports if the connected database supports
+// column defaults that are used also for explicit NULL values.
+func (c *conn) supportsDefaultOnNull() bool {
+	return c.gteV(version12c)
+}
+
+// supportsInvisible reports if the connected
+// database supports invisible columns.
+func (c *conn) supportsInvisible() bool {
+	return c.gteV(version12c)
+}
+
+// supportsContainers reports if the connected database supports
+// the multitenant architecture (CDB and PDBs).
+func (c *conn) supportsContainers() bool {
+	return c.gteV(version12c)
+}
+
+// Container returns the name of the container the session is connected
+// to in multitenant setups. For example, "ORCLPDB1" or "CDB$ROOT".
+// An empty string is returned for versions that do not support it.
+func (d *Driver) Container() string {
+	return d.container
+}
+
+// supportsILM reports if the connected database supports
+// Automatic Data Optimization (ILM) policies.
+func (c *conn) supportsILM() bool {
+	return c.gteV(version12c)
+}
+
+// supportsFastAddColumn reports if the connected database adds nullable columns
+// with a default value as a metadata-only operation. Before 12c, only NOT NULL
+// columns with a default value are added without updating the existing rows.
+func (c *conn) supportsFastAddColumn() bool {
+	return c.gteV(version12c)
+}
+
+// supportsScalableSeq reports if the connected database supports scalable
+// sequences and the KEEP and NOKEEP attributes of sequences.
+func (c *conn) supportsScalableSeq() bool {
+	return c.gteV(version18c)
+}
+
+// supportsCollation reports if the connected database supports declaring
+// the collation of character columns (data-bound collation).
+func (c *conn) supportsCollation() bool {
+	return c.gteV(version18c)
+}
+
+// compareV returns an integer comparing two versions according to
+// semantic version precedence.
+func (c *conn) compareV(w string) int {
+	return semver.Compare("v"+c.version, "v"+w)
+}
+
+// gteV reports if the connection version is >= w.
+func (c *conn) gteV(w string) bool { return c.compareV(w) >= 0 }
+
+// ltV reports if the connection version is < w.
+func (c *conn) ltV(w string) bool { return c.compareV(w) == -1 }
+
+// dictQuery returns the given data dictionary query, using the
+// DBA_* views instead of the ALL_* views if the option is enabled.
+func (c *conn) dictQuery(query string) string {
+	if !c.dba {
+		return query
+	}
+	return reAllViews.ReplaceAllString(query, "DBA_")
+}
+
+// reAllViews matches the prefix of the ALL_* data dictionary views.
+var reAllViews = regexp.MustCompile(`\bALL_`)
+
+// isPrivilegeErr reports if the given error is returned for objects that
+// do not exist (ORA-00942), or for insufficient privileges (ORA-01031).
+func isPrivilegeErr(err error) bool {
+	return strings.Contains(err.Error(), "ORA-00942") || strings.Contains(err.Error(), "ORA-01031")
+}
+
+// defaultSemantics returns the default length semantics of the connection.
+func (c *conn) defaultSemantics() string {
+	if c.lengthSemantics == "" {
+		return SemanticsByte
+	}
+	return c.lengthSemantics
+}
+
+// parseVersion converts an Oracle release number (e.g. "11.2.0.4.0")
+// to its semver form by keeping the first 3 components (e.g. "11.2.0").
+func parseVersion(v string) (string, error) {
+	parts := strings.Split(strings.TrimSpace(v), ".")
+	if len(parts) > 3 {
+		parts = parts[:3]
+	}
+	for len(parts) < 3 {
+		parts = append(parts, "0")
+	}
+	for i, p := range parts {
+		// Drop leading zeros, as they are not allowed by semver.
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return "", fmt.Errorf("oracle: malformed version: %s", v)
+		}
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// Query to check if the current user can access the DBA_* views.
+const dbaViewsQuery = `SELECT 1 FROM DBA_TABLES WHERE ROWNUM = 1`
+
+// Query to get the name of the container of the session.
+const containerQuery = `SELECT SYS_CONTEXT('USERENV', 'CON_NAME') FROM DUAL`
+
+// Query to get the release number of the database server, the default length
+// semantics and the collation parameters of the session, and the character set
+// of the database. Parameters are selected using scalar subqueries, so that the
+// query always returns a single row, and missing parameters are returned as NULL.
+const paramsQuery = `SELECT (SELECT VERSION FROM PRODUCT_COMPONENT_VERSION WHERE PRODUCT LIKE 'Oracle%' AND ROWNUM = 1) AS VERSION, (SELECT VALUE FROM NLS_SESSION_PARAMETERS WHERE PARAMETER = 'NLS_LENGTH_SEMANTICS') AS NLS_LENGTH_SEMANTICS, (SELECT VALUE FROM NLS_SESSION_PARAMETERS WHERE PARAMETER = 'NLS_COMP') AS NLS_COMP, (SELECT VALUE FROM NLS_SESSION_PARAMETERS WHERE PARAMETER = 'NLS_SORT') AS NLS_SORT, (SELECT VALUE FROM NLS_DATABASE_PARAMETERS WHERE PARAMETER = 'NLS_CHARACTERSET') AS NLS_CHARACTERSET FROM DUAL`
+
+// Length semantics of character columns.
+const (
+	SemanticsByte = "BYTE"
+	SemanticsChar = "CHAR"
+)
+
+// DefaultCollation is the pseudo-collation that is used by character columns
+// that were declared without a collation. It resolves to the collation that
+// is set by the NLS_COMP parameter of the session (BINARY by default).
+const DefaultCollation = "USING_NLS_COMP"
+
+// Standard column types (and their aliases) as defined in
+// the Oracle Database SQL Language Reference.
+const (
+	TypeChar      = "char"
+	TypeNChar     = "nchar"
+	TypeVarchar2  = "varchar2"
+	TypeNVarchar2 = "nvarchar2"
+	TypeVarchar   = "varchar" // varchar2.
+
+	TypeNumber       = "number"
+	TypeFloat        = "float"
+	TypeBinaryFloat  = "binary_float"
+	TypeBinaryDouble = "binary_double"
+	TypeInteger      = "integer"  // number(38).
+	TypeInt          = "int"      // number(38).
+	TypeSmallInt     = "smallint" // number(38).
+	TypeDecimal      = "decimal"  // number.
+	TypeNumeric      = "numeric"  // number.
+
+	TypeDate         = "date"
+	TypeTimestamp    = "timestamp"
+	TypeTimestampTZ  = "timestamp with time zone"
+	TypeTimestampLTZ = "timestamp with local time zone"
+	TypeIntervalYM   = "interval year to month"
+	TypeIntervalDS   = "interval day to second"
+
+	TypeRaw     = "raw"
+	TypeLongRaw = "long raw"
+	TypeLong    = "long"
+
+	TypeCLOB  = "clob"
+	TypeNCLOB = "nclob"
+	TypeBLOB  = "blob"
+	TypeBFile = "bfile"
+
+	TypeRowID  = "rowid"
+	TypeURowID = "urowid"
+
+	TypeXML         = "xmltype"
+	TypeSDOGeometry = "sdo_geometry"
+)
+
+// Kinds of user-defined collection types.
+const (
+	CollectionVarray = "VARRAY"
+	CollectionTable  = "TABLE"
+)
@@ -0,0 +1,153 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package oracle
+
+import (
+	"errors"
+	"testing"
+
+	"ariga.io/atlas/sql/internal/sqltest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDriver_Open(t *testing.T) {
+	tests := []struct {
+		version string
+		expect  string
+		wantErr bool
+	}{
+		{version: "11.2.0.4.0", expect: "11.2.0"},
+		{version: "12.1.0", expect: "12.1.0"},
+		{version: "19.0.0", expect: "19.0.0"},
+		{version: "21.0.0.0.0", expect: "21.0.0"},
+		{version: "10.2.0.5.0", wantErr: true},
+		{version: "malformed", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			db, m, err := sqlmock.New()
+			require.NoError(t, err)
+			mock{m}.version(tt.version)
+			drv, err := Open(db)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, drv.Version())
+		})
+	}
+}
+
+func TestDriver_Params(t *testing.T) {
+	tests := []struct {
+		name      string
+		rows      string
+		semantics string
+		collation string
+		charset   string
+	}{
+		{
+			name: "default",
+			rows: `
+ VERSION    | NLS_LENGTH_SEMANTICS | NLS_COMP | NLS_SORT | NLS_CHARACTERSET
+------------+----------------------+----------+----------+------------------
+ 19.0.0.0.0 | BYTE                 | BINARY   | BINARY   | AL32UTF8
+`,
+			semantics: SemanticsByte,
+			collation: "BINARY",
+			charset:   "AL32UTF8",
+		},
+		{
+			name: "linguistic",
+			rows: `
+ VERSION    | NLS_LENGTH_SEMANTICS | NLS_COMP   | NLS_SORT     | NLS_CHARACTERSET
+------------+----------------------+------------+--------------+------------------
+ 19.0.0.0.0 | CHAR                 | LINGUISTIC | GENERIC_M_CI | WE8MSWIN1252
+`,
+			semantics: SemanticsChar,
+			collation: "GENERIC_M_CI",
+			charset:   "WE8MSWIN1252",
+		},
+		{
+			// NLS_SORT is ignored by comparisons if NLS_COMP is BINARY.
+			name: "binary",
+			rows: `
+ VERSION    | NLS_LENGTH_SEMANTICS | NLS_COMP | NLS_SORT | NLS_CHARACTERSET
+------------+----------------------+----------+----------+------------------
+ 19.0.0.0.0 | BYTE                 | BINARY   | GERMAN   | AL32UTF8
+`,
+			semantics: SemanticsByte,
+			collation: "BINARY",
+			charset:   "AL32UTF8",
+		},
+		{
+			// Missing parameters are returned as NULL.
+			name: "missing",
+			rows: `
+ VERSION    | NLS_LENGTH_SEMANTICS | NLS_COMP | NLS_SORT | NLS_CHARACTERSET
+------------+----------------------+----------+----------+------------------
+ 19.0.0.0.0 |                      |          |          |
+`,
+			semantics: SemanticsByte,
+			collation: "BINARY",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, m, err := sqlmock.New()
+			require.NoError(t, err)
+			m.ExpectQuery(sqltest.Escape(paramsQuery)).
+				WillReturnRows(sqltest.Rows(tt.rows))
+			mock{m}.container("ORCLPDB1")
+			drv, err := Open(db)
+			require.NoError(t, err)
+			require.Equal(t, "19.0.0", drv.Version())
+			require.Equal(t, tt.semantics, drv.lengthSemantics)
+			require.Equal(t, tt.collation, drv.Collation())
+			require.Equal(t, tt.charset, drv.Charset())
+			require.NoError(t, m.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDriver_Container(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	require.Equal(t, "ORCLPDB1", drv.Container())
+
+	// Not supported before 12c.
+	db, m, err = sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("11.2.0.4.0")
+	drv, err = Open(db)
+	require.NoError(t, err)
+	require.Empty(t, drv.Container())
+
+	// Switch to the given container on Open.
+	db, m, err = sqlmock.New()
+	require.NoError(t, err)
+	m.ExpectExec(sqltest.Escape(`ALTER SESSION SET CONTAINER = SALESPDB`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	m.ExpectQuery(sqltest.Escape(paramsQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"VERSION", "NLS_LENGTH_SEMANTICS", "NLS_COMP", "NLS_SORT", "NLS_CHARACTERSET"}).AddRow("19.0.0.0.0", SemanticsByte, "BINARY", "BINARY", "AL32UTF8"))
+	mock{m}.container("SALESPDB")
+	drv, err = Open(db, WithContainer("SALESPDB"))
+	require.NoError(t, err)
+	require.Equal(t, "SALESPDB", drv.Container())
+	require.NoError(t, m.ExpectationsWereMet())
+
+	db, m, err = sqlmock.New()
+	require.NoError(t, err)
+	m.ExpectExec(sqltest.Escape(`ALTER SESSION SET CONTAINER = MISSING`)).
+		WillReturnError(errors.New("ORA-65011: Pluggable database MISSING does not exist."))
+	_, err = Open(db, WithContainer("MISSING"))
+	require.Error(t, err)
+}
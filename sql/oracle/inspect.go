@@ -0,0 +1,3136 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"ariga.io/atlas/sql/internal/sqlx"
+	"ariga.io/atlas/sql/schema"
+)
+
+// An inspect provides an Oracle implementation for schema.Inspector.
+type inspect struct{ conn }
+
+var _ schema.Inspector = (*inspect)(nil)
+
+// InspectRealm returns schema descriptions of all resources in the given realm.
+func (i *inspect) InspectRealm(ctx context.Context, opts *schema.InspectRealmOption) (*schema.Realm, error) {
+	schemas, err := i.schemas(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	realm := &schema.Realm{Schemas: schemas, Attrs: i.realmAttrs()}
+	for _, s := range schemas {
+		names, err := i.tableNames(ctx, s.Name, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := i.inspectTables(ctx, s, names, nil); err != nil {
+			return nil, err
+		}
+		if err := i.views(ctx, s, nil); err != nil {
+			return nil, err
+		}
+		if err := i.sequences(ctx, s); err != nil {
+			return nil, err
+		}
+		if err := i.synonyms(ctx, s); err != nil {
+			return nil, err
+		}
+		s.Realm = realm
+	}
+	if err := i.publicSynonyms(ctx, realm); err != nil {
+		return nil, err
+	}
+	sqlx.LinkSchemaTables(schemas)
+	return realm, nil
+}
+
+// InspectSchema returns schema descriptions of the tables in the given schema.
+// If the schema name is empty, the result will be the attached schema.
+func (i *inspect) InspectSchema(ctx context.Context, name string, opts *schema.InspectOptions) (s *schema.Schema, err error) {
+	var schemas []*schema.Schema
+	switch name {
+	case "":
+		if name, err = i.currentSchema(ctx); err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, &schema.Schema{Name: name})
+	default:
+		if schemas, err = i.schemas(ctx, &schema.InspectRealmOption{Schemas: []string{name}}); err != nil {
+			return nil, err
+		}
+		if len(schemas) == 0 {
+			return nil, &schema.NotExistError{
+				Err: fmt.Errorf("oracle: schema %q was not found", name),
+			}
+		}
+	}
+	names, err := i.tableNames(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	s = schemas[0]
+	if err := i.inspectTables(ctx, s, names, opts); err != nil {
+		return nil, err
+	}
+	if err := i.views(ctx, s, opts); err != nil {
+		return nil, err
+	}
+	if err := i.sequences(ctx, s); err != nil {
+		return nil, err
+	}
+	if err := i.synonyms(ctx, s); err != nil {
+		return nil, err
+	}
+	sqlx.LinkSchemaTables(schemas)
+	s.Realm = &schema.Realm{Schemas: schemas, Attrs: i.realmAttrs()}
+	return s, nil
+}
+
+// realmAttrs returns the attributes of the database that were read on Open:
+// the character set of the database (NLS_CHARACTERSET) and the collation of
+// the session. The character set determines how many bytes a character takes,
+// and therefore, affects the BYTE/CHAR length semantics of character columns.
+func (i *inspect) realmAttrs() []schema.Attr {
+	var attrs []schema.Attr
+	if i.charset != "" {
+		attrs = append(attrs, &schema.Charset{V: i.charset})
+	}
+	if i.collate != "" {
+		attrs = append(attrs, &schema.Collation{V: i.collate})
+	}
+	return attrs
+}
+
+// currentSchema returns the schema attached to the session. Some connection
+// setups (e.g. proxies) leave the CURRENT_SCHEMA empty, and in this case, the
+// session user is used, as it is the default schema for name resolution.
+func (i *inspect) currentSchema(ctx context.Context) (string, error) {
+	rows, err := i.QueryContext(ctx, currentSchemaQuery)
+	if err != nil {
+		return "", fmt.Errorf("oracle: query attached schema: %w", err)
+	}
+	var current, user sql.NullString
+	if err := sqlx.ScanOne(rows, &current, &user); err != nil {
+		return "", fmt.Errorf("oracle: scan attached schema: %w", err)
+	}
+	switch {
+	case sqlx.ValidString(current):
+		return current.String, nil
+	case sqlx.ValidString(user):
+		return user.String, nil
+	default:
+		return "", errors.New("oracle: could not resolve the attached schema: both CURRENT_SCHEMA and SESSION_USER are empty")
+	}
+}
+
+// InspectTable returns the schema description of the given table.
+func (i *inspect) InspectTable(ctx context.Context, name string, opts *schema.InspectTableOptions) (*schema.Table, error) {
+	return i.inspectTable(ctx, name, opts, nil)
+}
+
+// inspectTables inspects the given schema tables and appends them to the schema.
+// If specific tables were not requested and the schema has multiple tables, the
+// tables, their columns, indexes, foreign keys, checks and extra attributes are
+// queried once for the whole schema, and the rows are fanned out to their tables,
+// instead of querying them table by table.
+func (i *inspect) inspectTables(ctx context.Context, s *schema.Schema, names []string, opts *schema.InspectOptions) error {
+	topts := &schema.InspectTableOptions{Schema: s.Name}
+	if opts != nil {
+		topts.Mode = opts.Mode
+	}
+	if len(names) < 2 || opts != nil && len(opts.Tables) > 0 {
+		for _, name := range names {
+			t, err := i.inspectTable(ctx, name, topts, s)
+			if err != nil {
+				return err
+			}
+			s.Tables = append(s.Tables, t)
+		}
+		return nil
+	}
+	tables, err := i.schemaTables(ctx, s, names)
+	if err != nil {
+		return err
+	}
+	columns, err := i.schemaColumns(ctx, s.Name)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		t := tables[name]
+		t.Columns = columns[t.Name]
+		s.Tables = append(s.Tables, t)
+	}
+	if topts.Mode.Is(schema.InspectIndexes) {
+		if err := i.schemaIndexes(ctx, s.Name, tables); err != nil {
+			return err
+		}
+	}
+	if topts.Mode.Is(schema.InspectForeignKeys) {
+		if err := i.schemaFKs(ctx, s.Name, tables); err != nil {
+			return err
+		}
+	}
+	if topts.Mode.Is(schema.InspectChecks) {
+		if err := i.schemaChecks(ctx, s.Name, tables); err != nil {
+			return err
+		}
+	}
+	return i.tableExtras(ctx, &tableRows{owner: s.Name, tables: tables})
+}
+
+// inspectTable inspects the table with the given name.
+func (i *inspect) inspectTable(ctx context.Context, name string, opts *schema.InspectTableOptions, top *schema.Schema) (*schema.Table, error) {
+	t, err := i.tableAt(ctx, name, opts, top)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.columns(ctx, t); err != nil {
+		return nil, err
+	}
+	var mode schema.InspectMode
+	if opts != nil {
+		mode = opts.Mode
+	}
+	if mode.Is(schema.InspectIndexes) {
+		if err := i.indexes(ctx, t); err != nil {
+			return nil, err
+		}
+	}
+	if mode.Is(schema.InspectForeignKeys) {
+		if err := i.fks(ctx, t); err != nil {
+			return nil, err
+		}
+	}
+	if mode.Is(schema.InspectChecks) {
+		if err := i.checks(ctx, t); err != nil {
+			return nil, err
+		}
+	}
+	if err := i.tableExtras(ctx, &tableRows{t: t}); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// tableAt returns the table with the given name, linked to its top element
+// (if provided), with the locations of its external data (if any).
+func (i *inspect) tableAt(ctx context.Context, name string, opts *schema.InspectTableOptions, top *schema.Schema) (*schema.Table, error) {
+	t, err := i.table(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	if top != nil {
+		// Link the table to its top element if provided.
+		t.Schema = top
+	}
+	if r := (&tableRows{t: t}); r.any(isExternal) {
+		if err := i.externalLocations(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// schemaTables queries the given tables of the schema using a single query,
+// and returns them by their names, linked to the schema, with the locations
+// of their external data (if any).
+func (i *inspect) schemaTables(ctx context.Context, s *schema.Schema, names []string) (map[string]*schema.Table, error) {
+	rows, err := i.QueryContext(ctx, i.dictQuery(schemaTablesQuery), s.Name)
+	if err != nil {
+		return nil, fmt.Errorf("oracle: querying schema %q tables: %w", s.Name, err)
+	}
+	defer rows.Close()
+	var (
+		tables = make(map[string]*schema.Table, len(names))
+		wanted = make(map[string]bool, len(names))
+	)
+	for _, name := range names {
+		wanted[name] = true
+	}
+	for rows.Next() {
+		var (
+			r    tableRow
+			name string
+		)
+		if err := rows.Scan(append(r.dest(), &name)...); err != nil {
+			return nil, fmt.Errorf("oracle: scanning table: %w", err)
+		}
+		// Nested, overflow and materialized view container tables.
+		if !wanted[name] {
+			continue
+		}
+		t := r.table(name)
+		t.Schema = s
+		tables[name] = t
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if _, ok := tables[name]; !ok {
+			return nil, &schema.NotExistError{
+				Err: fmt.Errorf("oracle: table %q was not found", name),
+			}
+		}
+	}
+	if r := (&tableRows{owner: s.Name, tables: tables}); r.any(isExternal) {
+		if err := i.externalLocations(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	return tables, nil
+}
+
+// tableExtras queries the table attributes that are inspected on demand,
+// i.e. ILM policies, raw constraints definitions, auditing options and the
+// last DDL time, the identity columns that are emulated by triggers (< 12c),
+// and the definitions of collection types that are used by the columns.
+// Each of them is queried once for all tables of the given rows.
+func (i *inspect) tableExtras(ctx context.Context, r *tableRows) error {
+	if r.any(hasUnsupported) {
+		if err := i.collectionTypes(ctx, r); err != nil {
+			return err
+		}
+	}
+	// Columns that are not of collection types may be of object types.
+	if r.any(hasUnsupported) {
+		if err := i.objectTypes(ctx, r); err != nil {
+			return err
+		}
+	}
+	if r.any(hasLOB) {
+		if err := i.lobStorage(ctx, r); err != nil {
+			return err
+		}
+	}
+	if !i.supportsIdentity() && r.any(hasColumns) {
+		if err := i.identityTriggers(ctx, r); err != nil {
+			return err
+		}
+	}
+	if i.ilm && i.supportsILM() {
+		if err := i.ilmPolicies(ctx, r); err != nil {
+			return err
+		}
+	}
+	if i.lastDDL {
+		if err := i.lastDDLTime(ctx, r); err != nil {
+			return err
+		}
+	}
+	if i.rawConstraints {
+		if err := i.constraintsDDL(ctx, r); err != nil {
+			return err
+		}
+	}
+	if i.audit {
+		if err := i.auditOptions(ctx, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tableRows resolves the tables of the rows of the queries that are run
+// either for a single table (t), or for all tables of a schema (owner).
+// Rows of schema-wide queries hold the name of their table in their last
+// column, and rows of tables that are not inspected are skipped.
+type tableRows struct {
+	t      *schema.Table
+	owner  string
+	tables map[string]*schema.Table
+	name   string
+}
+
+// query runs the per-table query, or the schema-wide query if the rows are not of a single table.
+func (r *tableRows) query(ctx context.Context, i *inspect, query, schemaQuery string) (*sql.Rows, error) {
+	if r.t != nil {
+		return i.QueryContext(ctx, i.dictQuery(query), r.t.Schema.Name, r.t.Name)
+	}
+	return i.QueryContext(ctx, i.dictQuery(schemaQuery), r.owner)
+}
+
+// dest returns the given scan destinations, followed by the table name for schema-wide rows.
+func (r *tableRows) dest(dest ...interface{}) []interface{} {
+	if r.t != nil {
+		return dest
+	}
+	return append(dest, &r.name)
+}
+
+// table returns the table of the last scanned row.
+func (r *tableRows) table() (*schema.Table, bool) {
+	if r.t != nil {
+		return r.t, true
+	}
+	t, ok := r.tables[r.name]
+	return t, ok
+}
+
+// any reports if any of the tables satisfies the given predicate.
+func (r *tableRows) any(f func(*schema.Table) bool) bool {
+	if r.t != nil {
+		return f(r.t)
+	}
+	for _, t := range r.tables {
+		if f(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the quoted table name, or the schema name for schema-wide rows.
+func (r *tableRows) String() string {
+	if r.t != nil {
+		return strconv.Quote(r.t.Name)
+	}
+	return fmt.Sprintf("schema %q", r.owner)
+}
+
+// table returns the table from the database, or a NotExistError if the table was not found.
+func (i *inspect) table(ctx context.Context, name string, opts *schema.InspectTableOptions) (*schema.Table, error) {
+	var (
+		args  = []interface{}{name}
+		query = tableQuery
+	)
+	if opts != nil && opts.Schema != "" {
+		query = tableSchemaQuery
+		args = append(args, opts.Schema)
+	}
+	var (
+		r         tableRow
+		rows, err = i.QueryContext(ctx, i.dictQuery(query), args...)
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := sqlx.ScanOne(rows, r.dest()...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &schema.NotExistError{
+				Err: fmt.Errorf("oracle: table %q was not found", name),
+			}
+		}
+		return nil, err
+	}
+	return r.table(name), nil
+}
+
+// tableRow holds a row of the tables queries.
+type tableRow struct {
+	schema, comment, degree, instances, deps, temp, duration sql.NullString
+	extType, extDir, extParams, tablespace                   sql.NullString
+	iotType, pctThreshold, overflow, mapping                 sql.NullString
+}
+
+func (r *tableRow) dest() []interface{} {
+	return []interface{}{&r.schema, &r.comment, &r.degree, &r.instances, &r.deps, &r.temp, &r.duration, &r.extType, &r.extDir, &r.extParams, &r.tablespace, &r.iotType, &r.pctThreshold, &r.overflow, &r.mapping}
+}
+
+// table returns the table with the given name from the row.
+func (r *tableRow) table(name string) *schema.Table {
+	t := &schema.Table{Name: name, Schema: &schema.Schema{Name: r.schema.String}}
+	if sqlx.ValidString(r.comment) {
+		t.Attrs = append(t.Attrs, &schema.Comment{
+			Text: r.comment.String,
+		})
+	}
+	if p := parallel(r.degree.String, r.instances.String); p != nil {
+		t.Attrs = append(t.Attrs, p)
+	}
+	if r.deps.String == "ENABLED" {
+		t.Attrs = append(t.Attrs, &RowDependencies{})
+	}
+	if r.temp.String == "Y" {
+		t.Attrs = append(t.Attrs, temporary(r.duration.String))
+	}
+	// Partitioned, temporary and external tables
+	// do not reside in a specific tablespace.
+	if sqlx.ValidString(r.tablespace) {
+		t.Attrs = append(t.Attrs, &Tablespace{Name: r.tablespace.String})
+	}
+	// The rows of index-organized tables are stored in their primary
+	// key index, and the table itself has no segment (and tablespace).
+	if r.iotType.String == "IOT" {
+		pct, _ := strconv.Atoi(r.pctThreshold.String)
+		t.Attrs = append(t.Attrs, &Organization{
+			T:            OrganizationIndex,
+			PctThreshold: pct,
+			Overflow:     sqlx.ValidString(r.overflow),
+			Mapping:      sqlx.ValidString(r.mapping),
+		})
+	}
+	if sqlx.ValidString(r.extType) {
+		t.Attrs = append(t.Attrs, &External{
+			Type:      r.extType.String,
+			Directory: r.extDir.String,
+			Params:    strings.TrimSpace(r.extParams.String),
+		})
+	}
+	return t
+}
+
+// temporary returns the Temporary attribute of a global temporary table
+// from its DURATION. Rows of SYS$TRANSACTION tables are deleted on commit,
+// and rows of SYS$SESSION tables are preserved until the session ends.
+func temporary(duration string) *Temporary {
+	if duration == "SYS$SESSION" {
+		return &Temporary{OnCommit: OnCommitPreserve}
+	}
+	return &Temporary{OnCommit: OnCommitDelete}
+}
+
+// parallel returns the Parallel attribute of a table from its DEGREE
+// and INSTANCES values, or nil if the table is not parallel-enabled.
+// Both are stored as left-padded strings. e.g. "         4", "   DEFAULT".
+func parallel(degree, instances string) *Parallel {
+	p := &Parallel{}
+	for _, v := range []struct {
+		s string
+		n *int
+	}{{degree, &p.Degree}, {instances, &p.Instances}} {
+		switch s := strings.TrimSpace(v.s); s {
+		case "", "1":
+			*v.n = 1
+		case "DEFAULT":
+			*v.n = ParallelDefault
+		default:
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				n = ParallelDefault
+			}
+			*v.n = n
+		}
+	}
+	// NOPARALLEL, which is the default mode.
+	if p.Degree == 1 && p.Instances == 1 {
+		return nil
+	}
+	return p
+}
+
+// columns queries and appends the columns of the given table.
+func (i *inspect) columns(ctx context.Context, t *schema.Table) error {
+	query := columnsQuery
+	switch {
+	case !i.supportsIdentity():
+		query = columnsQueryNoIdentity
+	case !i.supportsCollation():
+		query = columnsQueryNoCollation
+	}
+	rows, err := i.QueryContext(ctx, i.dictQuery(query), t.Schema.Name, t.Name)
+	if err != nil {
+		return fmt.Errorf("oracle: querying %q columns: %w", t.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := i.addColumn(ctx, t, rows); err != nil {
+			return fmt.Errorf("oracle: %w", err)
+		}
+	}
+	return rows.Close()
+}
+
+// schemaColumns queries the columns of all tables in the given schema using a
+// single query, instead of querying them table by table, and returns them by
+// the name of their tables.
+func (i *inspect) schemaColumns(ctx context.Context, name string) (map[string][]*schema.Column, error) {
+	query := schemaColumnsQuery
+	switch {
+	case !i.supportsIdentity():
+		query = schemaColumnsQueryNoIdentity
+	case !i.supportsCollation():
+		query = schemaColumnsQueryNoCollation
+	}
+	rows, err := i.QueryContext(ctx, i.dictQuery(query), name)
+	if err != nil {
+		return nil, fmt.Errorf("oracle: querying schema %q columns: %w", name, err)
+	}
+	defer rows.Close()
+	columns := make(map[string][]*schema.Column)
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("oracle: scanning schema %q columns: %w", name, err)
+		}
+		var table string
+		c, err := i.scanColumn(rows, &table)
+		if err != nil {
+			return nil, fmt.Errorf("oracle: %w", err)
+		}
+		if c != nil {
+			columns[table] = append(columns[table], c)
+		}
+	}
+	return columns, rows.Close()
+}
+
+// addColumn scans the current row and adds a new column from it to the table.
+// The row is expected to hold the following columns (in this order):
+//
+//	COLUMN_NAME, DATA_TYPE, NULLABLE, DATA_DEFAULT, DATA_LENGTH, CHAR_LENGTH,
+//	DATA_PRECISION, DATA_SCALE, CHARACTER_SET_NAME, IDENTITY_COLUMN,
+//	GENERATION_TYPE, IDENTITY_OPTIONS, COMMENTS, VIRTUAL_COLUMN, HIDDEN_COLUMN,
+//	USER_GENERATED, CHAR_USED, COLLATION, DEFAULT_ON_NULL, SEQUENCE_NAME
+//
+// The context is checked before scanning the row, to stop the inspection of
+// large tables promptly when it is done.
+func (i *inspect) addColumn(ctx context.Context, t *schema.Table, rows *sql.Rows) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("scanning %q columns: %w", t.Name, err)
+	}
+	c, err := i.scanColumn(rows)
+	if err != nil {
+		return err
+	}
+	if c != nil {
+		t.Columns = append(t.Columns, c)
+	}
+	return nil
+}
+
+// scanColumn scans the current row into a column. The row is expected to hold
+// the columns that are described in addColumn, followed by the extra columns
+// that are scanned into the given destinations. A nil column is returned for
+// hidden columns that were generated by the database.
+func (i *inspect) scanColumn(rows *sql.Rows, extra ...interface{}) (*schema.Column, error) {
+	var (
+		datalen, charlen, precision, scale                                                                                                         sql.NullInt64
+		name, typ, nullable, defaults, charset, identity, generation, idopts, comment, virtual, hidden, user, charUsed, collation, onNull, seqName sql.NullString
+	)
+	dest := append([]interface{}{&name, &typ, &nullable, &defaults, &datalen, &charlen, &precision, &scale, &charset, &identity, &generation, &idopts, &comment, &virtual, &hidden, &user, &charUsed, &collation, &onNull, &seqName}, extra...)
+	if err := rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+	// Hidden columns that are generated by the database (e.g. for function-based
+	// indexes, extended statistics or unused columns) are not part of the table
+	// definition. Unlike them, invisible columns are hidden but user-generated.
+	if user.String == "NO" {
+		return nil, nil
+	}
+	c := &schema.Column{
+		Name: name.String,
+		Type: &schema.ColumnType{
+			Raw:  typ.String,
+			Null: nullable.String == "Y",
+		},
+	}
+	d, err := parseColumn(typ.String)
+	if err != nil {
+		return nil, err
+	}
+	d.size, d.precision, d.scale = datalen.Int64, precision.Int64, scale.Int64
+	// Character columns are declared (and limited)
+	// by their length in characters.
+	if charlen.Int64 > 0 {
+		d.size = charlen.Int64
+	}
+	// NUMBER(*,s) is presented with a NULL precision, but
+	// its precision is the maximum allowed by the database.
+	if !precision.Valid && scale.Valid {
+		d.precision = maxNumberPrecision
+	}
+	c.Type.Type = columnType(d)
+	switch {
+	case identity.String == "YES":
+		// The DATA_DEFAULT of identity columns holds the backing
+		// sequence (e.g. "ISEQ$$_1234".nextval) and is not user-defined.
+		c.Attrs = append(c.Attrs, &Identity{
+			Generation:   generation.String,
+			Sequence:     identitySequence(idopts.String),
+			SequenceName: seqName.String,
+		})
+	case virtual.String == "YES":
+		// The DATA_DEFAULT of virtual columns holds their expression.
+		c.Attrs = append(c.Attrs, &Virtual{
+			Expr: strings.TrimSpace(defaults.String),
+		})
+	case sqlx.ValidString(defaults):
+		c.Default = defaultExpr(defaults.String)
+		// The default value is used also for explicit NULL values.
+		if onNull.String == "YES" {
+			c.Attrs = append(c.Attrs, &DefaultOnNull{})
+		}
+	}
+	if sqlx.ValidString(comment) {
+		c.Attrs = append(c.Attrs, &schema.Comment{
+			Text: comment.String,
+		})
+	}
+	if sqlx.ValidString(charset) {
+		c.Attrs = append(c.Attrs, &schema.Charset{
+			V: charset.String,
+		})
+	}
+	// Columns that use the pseudo-collation USING_NLS_COMP, which is the default
+	// collation of tables, are declared without a collation. Other collations,
+	// including the pseudo-collations that are bound to the NLS_SORT parameter
+	// (e.g. USING_NLS_SORT_CI), are kept as-is.
+	if sqlx.ValidString(collation) && collation.String != DefaultCollation {
+		c.Attrs = append(c.Attrs, &schema.Collation{
+			V: collation.String,
+		})
+	}
+	// User-generated columns that are hidden are invisible columns.
+	if hidden.String == "YES" {
+		c.Attrs = append(c.Attrs, &Invisible{})
+	}
+	// Columns that follow the session semantics are declared without them.
+	if s := lengthSemantics(d.typ, charUsed.String); s != "" && s != i.defaultSemantics() {
+		c.Attrs = append(c.Attrs, &LengthSemantics{T: s})
+	}
+	return c, nil
+}
+
+func columnType(c *columnDesc) schema.Type {
+	var typ schema.Type
+	switch t := c.typ; t {
+	case TypeChar, TypeNChar, TypeVarchar2, TypeNVarchar2, TypeVarchar:
+		typ = &schema.StringType{T: t, Size: int(c.size)}
+	case TypeNumber, TypeDecimal, TypeNumeric:
+		typ = &schema.DecimalType{T: t, Precision: int(c.precision), Scale: int(c.scale)}
+	case TypeInteger, TypeInt, TypeSmallInt:
+		typ = &schema.IntegerType{T: t}
+	case TypeFloat:
+		typ = &schema.FloatType{T: t, Precision: int(c.precision)}
+	case TypeBinaryFloat, TypeBinaryDouble:
+		typ = &schema.FloatType{T: t}
+	// LOBs are unbounded, and therefore, are not sized.
+	case TypeCLOB, TypeNCLOB:
+		typ = &schema.StringType{T: t}
+	case TypeRaw:
+		typ = &schema.BinaryType{T: t, Size: int(c.size)}
+	case TypeBLOB:
+		typ = &schema.BinaryType{T: t}
+	// LONG and LONG RAW are deprecated in favor of LOBs, and
+	// BFILE is a locator of a binary file that is stored outside
+	// the database. Like LOBs, their size is not declared.
+	case TypeLong:
+		typ = &schema.StringType{T: t}
+	case TypeLongRaw, TypeBFile:
+		typ = &schema.BinaryType{T: t}
+	case TypeRowID:
+		typ = &RowIDType{T: t}
+	case TypeURowID:
+		typ = &RowIDType{T: t, Size: int(c.size)}
+	case TypeDate:
+		typ = &schema.TimeType{T: t}
+	case TypeTimestamp, TypeTimestampTZ, TypeTimestampLTZ:
+		typ = &schema.TimeType{T: t, Precision: c.secondsPrecision}
+	case TypeIntervalYM:
+		typ = &IntervalType{T: t, Precision: c.leadingPrecision}
+	case TypeIntervalDS:
+		typ = &IntervalType{T: t, Precision: c.leadingPrecision, Scale: c.secondsPrecision}
+	// XMLTYPE is an object type that is owned by SYS,
+	// and can be declared with its schema qualifier.
+	case TypeXML, "sys." + TypeXML:
+		typ = &XMLType{T: TypeXML}
+	// SDO_GEOMETRY is the object type of Oracle Spatial, owned by MDSYS. Note that
+	// the geometry metadata of the columns (USER_SDO_GEOM_METADATA) and their
+	// spatial (domain) indexes are not handled yet.
+	case TypeSDOGeometry, "mdsys." + TypeSDOGeometry:
+		typ = &schema.SpatialType{T: TypeSDOGeometry}
+	default:
+		typ = &schema.UnsupportedType{T: t}
+	}
+	return typ
+}
+
+// identitySequence parses the IDENTITY_OPTIONS of an identity column. For example:
+//
+//	START WITH: 1, INCREMENT BY: 1, MAX_VALUE: 9999999999999999999999999999, ...
+func identitySequence(opts string) *Sequence {
+	var (
+		minv, maxv string
+		seq        = &Sequence{Start: defaultSeqStart, Increment: defaultSeqIncrement}
+	)
+	for _, opt := range strings.Split(opts, ",") {
+		kv := strings.SplitN(opt, ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		k, v := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		switch k {
+		case "MIN_VALUE":
+			minv = v
+		case "MAX_VALUE":
+			maxv = v
+		case "ORDER_FLAG":
+			seq.Order = v == "Y"
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			continue
+		}
+		switch k {
+		case "START WITH":
+			seq.Start = n
+		case "INCREMENT BY":
+			seq.Increment = n
+		}
+	}
+	seq.Min, seq.Max = seqBounds(minv, maxv, seq.Increment)
+	return seq
+}
+
+// seqBounds returns the MINVALUE and MAXVALUE of a sequence from their
+// textual form in the data dictionary. The default bounds (NOMINVALUE and
+// NOMAXVALUE) depend on the direction of the sequence, and are returned as
+// zero. Explicit bounds that overflow an int64 are clamped to its range.
+func seqBounds(minv, maxv string, incr int64) (min, max int64) {
+	defMin, defMax := "1", seqMaxValue
+	if incr < 0 {
+		defMin, defMax = seqMinValue, "-1"
+	}
+	return seqBound(minv, defMin), seqBound(maxv, defMax)
+}
+
+func seqBound(v, def string) int64 {
+	switch v = strings.TrimSpace(v); {
+	case v == "" || v == def:
+		return 0
+	case strings.HasPrefix(v, "-"):
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n
+		}
+		return math.MinInt64
+	default:
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n
+		}
+		return math.MaxInt64
+	}
+}
+
+// indexes queries and appends the indexes of the given table.
+func (i *inspect) indexes(ctx context.Context, t *schema.Table) error {
+	rows, err := i.QueryContext(ctx, i.dictQuery(indexesQuery), t.Schema.Name, t.Name)
+	if err != nil {
+		return fmt.Errorf("oracle: querying %q indexes: %w", t.Name, err)
+	}
+	defer rows.Close()
+	if err := i.addIndexes(ctx, t, rows); err != nil {
+		return err
+	}
+	return rows.Err()
+}
+
+// schemaIndexes queries the indexes of all tables in the given schema using a
+// single query, and adds them to their tables in the given map.
+func (i *inspect) schemaIndexes(ctx context.Context, name string, tables map[string]*schema.Table) error {
+	rows, err := i.QueryContext(ctx, i.dictQuery(schemaIndexesQuery), name)
+	if err != nil {
+		return fmt.Errorf("oracle: querying schema %q indexes: %w", name, err)
+	}
+	defer rows.Close()
+	names := make(map[string]map[string]*schema.Index)
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("oracle: scanning schema %q indexes: %w", name, err)
+		}
+		var (
+			r     indexRow
+			table string
+		)
+		if err := rows.Scan(append(r.dest(), &table)...); err != nil {
+			return fmt.Errorf("oracle: scanning index: %w", err)
+		}
+		// Indexes of tables that are not inspected (e.g. materialized views).
+		t, ok := tables[table]
+		if !ok {
+			continue
+		}
+		if names[table] == nil {
+			names[table] = make(map[string]*schema.Index)
+		}
+		if err := r.add(t, names[table]); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// addIndexes scans the rows and adds the indexes to the table.
+// The rows are expected to hold the following columns (in this order):
+//
+//	INDEX_NAME, INDEX_TYPE, UNIQUENESS, CONSTRAINT_TYPE,
+//	COLUMN_NAME, DESCEND, COLUMN_EXPRESSION, TABLESPACE_NAME,
+//	LOCALITY
+//
+// The context is checked between rows, and its error is returned if it is done.
+func (i *inspect) addIndexes(ctx context.Context, t *schema.Table, rows *sql.Rows) error {
+	names := make(map[string]*schema.Index)
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("oracle: scanning %q indexes: %w", t.Name, err)
+		}
+		var r indexRow
+		if err := rows.Scan(r.dest()...); err != nil {
+			return fmt.Errorf("oracle: scanning index: %w", err)
+		}
+		if err := r.add(t, names); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexRow holds a row of the indexes queries. See addIndexes for its columns.
+type indexRow struct {
+	name, typ, uniq                                      string
+	contype, column, descend, expr, tablespace, locality sql.NullString
+}
+
+func (r *indexRow) dest() []interface{} {
+	return []interface{}{&r.name, &r.typ, &r.uniq, &r.contype, &r.column, &r.descend, &r.expr, &r.tablespace, &r.locality}
+}
+
+// add adds the row to its index in the table, and
+// creates the index if it does not exist in names.
+func (r *indexRow) add(t *schema.Table, names map[string]*schema.Index) error {
+	column, expr := r.column, r.expr
+	idx, ok := names[r.name]
+	if !ok {
+		idx = &schema.Index{
+			Name:   r.name,
+			Unique: r.uniq == "UNIQUE",
+			Table:  t,
+			Attrs: []schema.Attr{
+				&IndexType{T: r.typ},
+			},
+		}
+		if sqlx.ValidString(r.contype) {
+			idx.Attrs = append(idx.Attrs, &ConType{T: r.contype.String})
+		}
+		if sqlx.ValidString(r.tablespace) {
+			idx.Attrs = append(idx.Attrs, &Tablespace{Name: r.tablespace.String})
+		}
+		if r.locality.String == "LOCAL" {
+			idx.Attrs = append(idx.Attrs, &LocalIndex{})
+		}
+		names[r.name] = idx
+		if r.contype.String == "P" {
+			t.PrimaryKey = idx
+		} else {
+			t.Indexes = append(t.Indexes, idx)
+		}
+	}
+	part := &schema.IndexPart{
+		SeqNo: len(idx.Parts) + 1,
+		Attrs: []schema.Attr{
+			&IndexColumnProperty{Desc: r.descend.String == "DESC"},
+		},
+	}
+	// Descending index keys are stored as function-based keys with a
+	// system-generated column name, and the column name as expression.
+	if sqlx.ValidString(expr) && sqlx.IsQuoted(strings.TrimSpace(expr.String), '"') {
+		if name, err := sqlx.Unquote(strings.TrimSpace(expr.String)); err == nil {
+			if _, ok := t.Column(name); ok {
+				column, expr = sql.NullString{String: name, Valid: true}, sql.NullString{}
+			}
+		}
+	}
+	switch {
+	case sqlx.ValidString(expr):
+		part.X = &schema.RawExpr{
+			X: expr.String,
+		}
+	case sqlx.ValidString(column):
+		part.C, ok = t.Column(column.String)
+		if !ok {
+			return fmt.Errorf("oracle: column %q was not found for index %q", column.String, idx.Name)
+		}
+		part.C.Indexes = append(part.C.Indexes, idx)
+	default:
+		return fmt.Errorf("oracle: invalid part for index %q", idx.Name)
+	}
+	idx.Parts = append(idx.Parts, part)
+	return nil
+}
+
+// fks queries and appends the foreign keys of the given table.
+func (i *inspect) fks(ctx context.Context, t *schema.Table) error {
+	rows, err := i.QueryContext(ctx, i.dictQuery(fksQuery), t.Schema.Name, t.Name)
+	if err != nil {
+		return fmt.Errorf("oracle: querying %q foreign keys: %w", t.Name, err)
+	}
+	defer rows.Close()
+	if err := sqlx.ScanFKs(t, rows); err != nil {
+		return fmt.Errorf("oracle: %w", err)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(t.ForeignKeys) == 0 {
+		return nil
+	}
+	return i.fkStates(ctx, t)
+}
+
+// fkStates queries the states of the foreign keys of the given table. Unlike
+// checks, foreign keys do not hold attributes, and their non-default states are
+// appended to the table attributes as ConstraintState attributes.
+func (i *inspect) fkStates(ctx context.Context, t *schema.Table) error {
+	rows, err := i.QueryContext(ctx, i.dictQuery(fkStatesQuery), t.Schema.Name, t.Name)
+	if err != nil {
+		return fmt.Errorf("oracle: querying %q foreign key states: %w", t.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, deferrable, deferred, status, validated sql.NullString
+		if err := rows.Scan(&name, &deferrable, &deferred, &status, &validated); err != nil {
+			return fmt.Errorf("oracle: scanning foreign key state: %w", err)
+		}
+		if cs := constraintStateOf(name.String, deferrable.String, deferred.String, status.String, validated.String); cs != nil {
+			t.Attrs = append(t.Attrs, cs)
+		}
+	}
+	return rows.Err()
+}
+
+// schemaFKs queries the foreign keys (and their states) of all tables in the
+// given schema using a single query, and adds them to their tables in the map.
+func (i *inspect) schemaFKs(ctx context.Context, name string, tables map[string]*schema.Table) error {
+	rows, err := i.QueryContext(ctx, i.dictQuery(schemaFKsQuery), name)
+	if err != nil {
+		return fmt.Errorf("oracle: querying schema %q foreign keys: %w", name, err)
+	}
+	defer rows.Close()
+	if err := sqlx.ScanSchemaFKs(tables, rows); err != nil {
+		return fmt.Errorf("oracle: %w", err)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	var hasFKs bool
+	for _, t := range tables {
+		hasFKs = hasFKs || len(t.ForeignKeys) > 0
+	}
+	if !hasFKs {
+		return nil
+	}
+	return i.schemaFKStates(ctx, name, tables)
+}
+
+// schemaFKStates is like fkStates, but queries the states of the foreign
+// keys of all tables in the given schema.
+func (i *inspect) schemaFKStates(ctx context.Context, name string, tables map[string]*schema.Table) error {
+	rows, err := i.QueryContext(ctx, i.dictQuery(schemaFKStatesQuery), name)
+	if err != nil {
+		return fmt.Errorf("oracle: querying schema %q foreign key states: %w", name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var table, fk, deferrable, deferred, status, validated sql.NullString
+		if err := rows.Scan(&fk, &deferrable, &deferred, &status, &validated, &table); err != nil {
+			return fmt.Errorf("oracle: scanning foreign key state: %w", err)
+		}
+		t, ok := tables[table.String]
+		if !ok {
+			continue
+		}
+		if cs := constraintStateOf(fk.String, deferrable.String, deferred.String, status.String, validated.String); cs != nil {
+			t.Attrs = append(t.Attrs, cs)
+		}
+	}
+	return rows.Err()
+}
+
+// constraintStateOf returns the ConstraintState of a constraint from its
+// ALL_CONSTRAINTS columns, or nil if the constraint is in its default state
+// (i.e. NOT DEFERRABLE INITIALLY IMMEDIATE ENABLE VALIDATE).
+func constraintStateOf(name, deferrable, deferred, status, validated string) *ConstraintState {
+	cs := &ConstraintState{
+		Name:              name,
+		Deferrable:        deferrable == "DEFERRABLE",
+		InitiallyDeferred: deferred == "DEFERRED",
+		Disabled:          status == "DISABLED",
+		NoValidate:        validated == "NOT VALIDATED",
+	}
+	if !cs.Deferrable && !cs.Disabled && !cs.NoValidate {
+		return nil
+	}
+	return cs
+}
+
+// constraintState returns the state of the named constraint from the given attributes.
+func constraintState(attrs []schema.Attr, name string) (*ConstraintState, bool) {
+	for _, a := range attrs {
+		if cs, ok := a.(*ConstraintState); ok && cs.Name == name {
+			return cs, true
+		}
+	}
+	return nil, false
+}
+
+// checks queries and appends the check constraints of the given table.
+func (i *inspect) checks(ctx context.Context, t *schema.Table) error {
+	rows, err := i.QueryContext(ctx, i.dictQuery(checksQuery), t.Schema.Name, t.Name)
+	if err != nil {
+		return fmt.Errorf("oracle: querying %q check constraints: %w", t.Name, err)
+	}
+	defer rows.Close()
+	if err := i.addChecks(ctx, t, rows); err != nil {
+		return err
+	}
+	return rows.Err()
+}
+
+// schemaChecks queries the check constraints of all tables in the given schema
+// using a single query, and adds them to their tables in the given map.
+func (i *inspect) schemaChecks(ctx context.Context, name string, tables map[string]*schema.Table) error {
+	rows, err := i.QueryContext(ctx, i.dictQuery(schemaChecksQuery), name)
+	if err != nil {
+		return fmt.Errorf("oracle: querying schema %q check constraints: %w", name, err)
+	}
+	defer rows.Close()
+	names := make(map[string]map[string]*schema.Check)
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("oracle: scanning schema %q check constraints: %w", name, err)
+		}
+		var (
+			r     checkRow
+			table string
+		)
+		if err := rows.Scan(append(r.dest(), &table)...); err != nil {
+			return fmt.Errorf("oracle: scanning check: %w", err)
+		}
+		t, ok := tables[table]
+		if !ok {
+			continue
+		}
+		if names[table] == nil {
+			names[table] = make(map[string]*schema.Check)
+		}
+		if err := r.add(t, names[table]); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// addChecks scans the rows and adds the checks to the table.
+// The rows are expected to hold the following columns (in this order):
+//
+//	CONSTRAINT_NAME, SEARCH_CONDITION, COLUMN_NAME,
+//	DEFERRABLE, DEFERRED, STATUS, VALIDATED
+//
+// The context is checked between rows, and its error is returned if it is done.
+func (i *inspect) addChecks(ctx context.Context, t *schema.Table, rows *sql.Rows) error {
+	names := make(map[string]*schema.Check)
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("oracle: scanning %q check constraints: %w", t.Name, err)
+		}
+		var r checkRow
+		if err := rows.Scan(r.dest()...); err != nil {
+			return fmt.Errorf("oracle: scanning check: %w", err)
+		}
+		if err := r.add(t, names); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRow holds a row of the checks queries. See addChecks for its columns.
+type checkRow struct {
+	name, clause, column                    string
+	deferrable, deferred, status, validated sql.NullString
+}
+
+func (r *checkRow) dest() []interface{} {
+	return []interface{}{&r.name, &r.clause, &r.column, &r.deferrable, &r.deferred, &r.status, &r.validated}
+}
+
+// add adds the row to its check in the table, and
+// creates the check if it does not exist in names.
+func (r *checkRow) add(t *schema.Table, names map[string]*schema.Check) error {
+	col, ok := t.Column(r.column)
+	if !ok {
+		return fmt.Errorf("oracle: column %q was not found for check %q", r.column, r.name)
+	}
+	// NOT NULL constraints are stored as CHECK constraints, but they are
+	// already represented by the column nullability. Their non-default
+	// states (e.g. ENABLE NOVALIDATE) are attached to their columns.
+	if r.clause == fmt.Sprintf("%q IS NOT NULL", r.column) {
+		if cs := constraintStateOf(r.name, r.deferrable.String, r.deferred.String, r.status.String, r.validated.String); cs != nil {
+			col.Attrs = append(col.Attrs, cs)
+		}
+		return nil
+	}
+	check, ok := names[r.name]
+	if !ok {
+		check = &schema.Check{Name: r.name, Expr: r.clause, Attrs: []schema.Attr{&CheckColumns{}}}
+		if cs := constraintStateOf(r.name, r.deferrable.String, r.deferred.String, r.status.String, r.validated.String); cs != nil {
+			check.Attrs = append(check.Attrs, cs)
+		}
+		names[r.name] = check
+		t.Attrs = append(t.Attrs, check)
+	}
+	c := check.Attrs[0].(*CheckColumns)
+	c.Columns = append(c.Columns, r.column)
+	return nil
+}
+
+// views queries and appends the views and the materialized views of the given
+// schema. Views are represented as tables that hold the View (or MaterializedView)
+// attribute, and their columns are inspected using the same query used for tables.
+func (i *inspect) views(ctx context.Context, s *schema.Schema, opts *schema.InspectOptions) error {
+	var views []*schema.Table
+	for _, q := range []struct {
+		query, queryArgs string
+		scan             func(*sql.Rows) (*schema.Table, error)
+	}{
+		{query: viewsQuery, queryArgs: viewsQueryArgs, scan: scanView},
+		{query: mviewsQuery, queryArgs: mviewsQueryArgs, scan: scanMView},
+	} {
+		query, args := q.query, []interface{}{s.Name}
+		if opts != nil && len(opts.Tables) > 0 {
+			query, args = inStrings(opts.Tables, q.queryArgs, args)
+		}
+		rows, err := i.QueryContext(ctx, i.dictQuery(query), args...)
+		if err != nil {
+			return fmt.Errorf("oracle: querying schema %q views: %w", s.Name, err)
+		}
+		for rows.Next() {
+			v, err := q.scan(rows)
+			if err != nil {
+				rows.Close()
+				return fmt.Errorf("oracle: scanning view: %w", err)
+			}
+			if i.excluded(v.Name) {
+				continue
+			}
+			v.Schema = s
+			views = append(views, v)
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return fmt.Errorf("oracle: scanning schema %q views: %w", s.Name, err)
+		}
+		if err := rows.Close(); err != nil {
+			return err
+		}
+	}
+	for _, v := range views {
+		if err := i.columns(ctx, v); err != nil {
+			return err
+		}
+		s.Tables = append(s.Tables, v)
+	}
+	return nil
+}
+
+// scanView scans a row of the viewsQuery.
+func scanView(rows *sql.Rows) (*schema.Table, error) {
+	var name, text string
+	if err := rows.Scan(&name, &text); err != nil {
+		return nil, err
+	}
+	return &schema.Table{
+		Name: name,
+		Attrs: []schema.Attr{
+			&View{Def: text},
+		},
+	}, nil
+}
+
+// scanMView scans a row of the mviewsQuery.
+func scanMView(rows *sql.Rows) (*schema.Table, error) {
+	var name, text, method, mode, build string
+	if err := rows.Scan(&name, &text, &method, &mode, &build); err != nil {
+		return nil, err
+	}
+	return &schema.Table{
+		Name: name,
+		Attrs: []schema.Attr{
+			&MaterializedView{
+				Def:           text,
+				RefreshMethod: method,
+				RefreshMode:   mode,
+				BuildMode:     build,
+			},
+		},
+	}, nil
+}
+
+// sequences queries and appends the standalone sequences of the given schema
+// as schema attributes. Sequences that back identity columns are skipped, as
+// they are reported by the Identity attribute of their columns.
+func (i *inspect) sequences(ctx context.Context, s *schema.Schema) error {
+	query := sequencesQuery
+	if !i.supportsScalableSeq() {
+		query = sequencesQueryNoScale
+	}
+	rows, err := i.QueryContext(ctx, i.dictQuery(query), s.Name)
+	if err != nil {
+		return fmt.Errorf("oracle: querying schema %q sequences: %w", s.Name, err)
+	}
+	defer rows.Close()
+	// The sequences that back emulated identity columns (< 12c)
+	// are reported by the Identity attribute of their columns.
+	emulated := make(map[string]bool)
+	if !i.supportsIdentity() {
+		emulated = emulatedSequences(s)
+	}
+	for rows.Next() {
+		var (
+			name, cycle, order, keep, scale, extend, shard, session, minv, maxv string
+			incr, cache, last                                                   int64
+		)
+		if err := rows.Scan(&name, &minv, &maxv, &incr, &cycle, &cache, &last, &order, &keep, &scale, &extend, &shard, &session); err != nil {
+			return fmt.Errorf("oracle: scanning sequence: %w", err)
+		}
+		if i.excluded(name) || emulated[name] {
+			continue
+		}
+		seq := &Sequence{
+			Name:      name,
+			Last:      last,
+			Increment: incr,
+			Cache:     cache,
+			Cycle:     cycle == "Y",
+			Order:     order == "Y",
+			Keep:      keep == "Y",
+			Scale:     scale == "Y",
+			Shard:     shard == "Y",
+			Extend:    extend == "Y",
+			Session:   session == "Y",
+		}
+		seq.Min, seq.Max = seqBounds(minv, maxv, incr)
+		s.Attrs = append(s.Attrs, seq)
+	}
+	return rows.Err()
+}
+
+// identityTriggers queries the triggers that emulate identity columns in
+// versions that do not support them (< 12c), and sets the Identity attribute
+// of their columns. The sequence and the trigger of such columns are named
+// <TABLE>_<COLUMN>_SEQ and <TABLE>_<COLUMN>_TRG, as they are created on planning.
+func (i *inspect) identityTriggers(ctx context.Context, r *tableRows) error {
+	rows, err := r.query(ctx, i, identityTriggersQuery, schemaIdentityTriggersQuery)
+	if err != nil {
+		return fmt.Errorf("oracle: querying %s identity triggers: %w", r, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			name       string
+			when       sql.NullString
+			incr, last int64
+		)
+		if err := rows.Scan(r.dest(&name, &when, &incr, &last)...); err != nil {
+			return fmt.Errorf("oracle: scanning identity trigger: %w", err)
+		}
+		t, ok := r.table()
+		if !ok || !strings.HasPrefix(name, t.Name+"_") {
+			continue
+		}
+		c, ok := t.Column(strings.TrimSuffix(strings.TrimPrefix(name, t.Name+"_"), "_TRG"))
+		if !ok {
+			continue
+		}
+		seq, _ := identityObjectNames(t.Name, c.Name)
+		id := &Identity{
+			Generation:   defaultIdentityGen,
+			Sequence:     &Sequence{Increment: incr, Last: last},
+			SequenceName: seq,
+		}
+		// Values can be set explicitly only on columns that
+		// are generated BY DEFAULT, i.e. WHEN (new.C IS NULL).
+		if sqlx.ValidString(when) {
+			id.Generation = "BY DEFAULT"
+		}
+		c.Attrs = append(c.Attrs, id)
+	}
+	return rows.Err()
+}
+
+// IdentityExhaustion returns the number of values that the sequence backing the
+// given identity column can still generate before it reaches its MAXVALUE (or
+// MINVALUE, for descending sequences). It allows detecting identity columns that
+// are about to overflow before running a migration. The calculation is based on
+// the LAST_NUMBER of the sequence, and therefore, cached values are considered
+// as used. Results that overflow an int64 are clamped to math.MaxInt64.
+func (i *inspect) IdentityExhaustion(ctx context.Context, schemaName, table, column string) (int64, error) {
+	if !i.supportsIdentity() {
+		return 0, fmt.Errorf("oracle: identity columns are not supported by version %s", i.version)
+	}
+	rows, err := i.QueryContext(ctx, i.dictQuery(identitySequenceQuery), schemaName, table, column)
+	if err != nil {
+		return 0, fmt.Errorf("oracle: querying identity sequence of column %q: %w", column, err)
+	}
+	var (
+		last, minv, maxv string
+		incr             int64
+	)
+	switch err := sqlx.ScanOne(rows, &last, &minv, &maxv, &incr); {
+	case errors.Is(err, sql.ErrNoRows):
+		return 0, &schema.NotExistError{
+			Err: fmt.Errorf("oracle: identity column %q was not found in table %q", column, table),
+		}
+	case err != nil:
+		return 0, fmt.Errorf("oracle: scanning identity sequence of column %q: %w", column, err)
+	}
+	return seqRemaining(last, minv, maxv, incr)
+}
+
+// seqRemaining returns the number of values a sequence can generate from its
+// last number until it reaches its bound. Values are given in their textual form,
+// as the bounds of sequences may exceed the int64 range (e.g. NOMAXVALUE).
+func seqRemaining(last, minv, maxv string, incr int64) (int64, error) {
+	var l, bound big.Int
+	if _, ok := l.SetString(strings.TrimSpace(last), 10); !ok {
+		return 0, fmt.Errorf("oracle: invalid sequence value %q", last)
+	}
+	b := maxv
+	if incr < 0 {
+		b = minv
+	}
+	if _, ok := bound.SetString(strings.TrimSpace(b), 10); !ok {
+		return 0, fmt.Errorf("oracle: invalid sequence bound %q", b)
+	}
+	if incr == 0 {
+		return 0, errors.New("oracle: invalid sequence increment 0")
+	}
+	// remaining = (bound - last) / increment + 1, if the bound was not passed.
+	n := new(big.Int).Sub(&bound, &l)
+	n.Quo(n, big.NewInt(incr))
+	if n.Sign() < 0 {
+		return 0, nil
+	}
+	n.Add(n, big.NewInt(1))
+	if !n.IsInt64() {
+		return math.MaxInt64, nil
+	}
+	return n.Int64(), nil
+}
+
+// synonyms queries and appends the private synonyms of the given schema.
+func (i *inspect) synonyms(ctx context.Context, s *schema.Schema) error {
+	rows, err := i.QueryContext(ctx, i.dictQuery(synonymsQuery), s.Name)
+	if err != nil {
+		return fmt.Errorf("oracle: querying schema %q synonyms: %w", s.Name, err)
+	}
+	defer rows.Close()
+	syns, err := scanSynonyms(rows)
+	if err != nil {
+		return err
+	}
+	s.Attrs = append(s.Attrs, syns...)
+	return rows.Err()
+}
+
+// publicSynonyms queries and appends the public synonyms to the realm.
+// Only synonyms that point to objects owned by the inspected schemas
+// are reported, as every installation defines public synonyms for the
+// Oracle-maintained objects (e.g. the data dictionary views).
+func (i *inspect) publicSynonyms(ctx context.Context, r *schema.Realm) error {
+	if len(r.Schemas) == 0 {
+		return nil
+	}
+	names := make([]string, len(r.Schemas))
+	for i, s := range r.Schemas {
+		names[i] = s.Name
+	}
+	query, args := inStrings(names, publicSynonymsQuery, nil)
+	rows, err := i.QueryContext(ctx, i.dictQuery(query), args...)
+	if err != nil {
+		return fmt.Errorf("oracle: querying public synonyms: %w", err)
+	}
+	defer rows.Close()
+	syns, err := scanSynonyms(rows)
+	if err != nil {
+		return err
+	}
+	r.Attrs = append(r.Attrs, syns...)
+	return rows.Err()
+}
+
+// scanSynonyms scans the rows of the synonyms queries.
+// The rows are expected to hold the following columns (in this order):
+//
+//	OWNER, SYNONYM_NAME, TABLE_OWNER, TABLE_NAME, DB_LINK
+func scanSynonyms(rows *sql.Rows) ([]schema.Attr, error) {
+	var syns []schema.Attr
+	for rows.Next() {
+		var (
+			owner, name, tOwner, tName string
+			link                       sql.NullString
+		)
+		if err := rows.Scan(&owner, &name, &tOwner, &tName, &link); err != nil {
+			return nil, fmt.Errorf("oracle: scanning synonym: %w", err)
+		}
+		syns = append(syns, &Synonym{
+			Name:        name,
+			Public:      owner == "PUBLIC",
+			TableSchema: tOwner,
+			Table:       tName,
+			DBLink:      link.String,
+		})
+	}
+	return syns, nil
+}
+
+// ilmPolicies queries and appends the ILM policies of the tables.
+func (i *inspect) ilmPolicies(ctx context.Context, r *tableRows) error {
+	rows, err := r.query(ctx, i, ilmQuery, schemaILMQuery)
+	if err != nil {
+		return fmt.Errorf("oracle: querying %s ilm policies: %w", r, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			p                          ILMPolicy
+			enabled                    string
+			compression, tier, condtyp sql.NullString
+			days                       sql.NullInt64
+		)
+		if err := rows.Scan(r.dest(&p.Name, &p.Action, &p.Scope, &compression, &tier, &condtyp, &days, &enabled)...); err != nil {
+			return fmt.Errorf("oracle: scanning ilm policy: %w", err)
+		}
+		t, ok := r.table()
+		if !ok {
+			continue
+		}
+		p.Compression, p.Tier, p.Condition = compression.String, tier.String, condtyp.String
+		p.Days, p.Enabled = int(days.Int64), enabled == "YES"
+		t.Attrs = append(t.Attrs, &p)
+	}
+	return rows.Err()
+}
+
+// externalLocations queries the locations (data files) of the external tables.
+func (i *inspect) externalLocations(ctx context.Context, r *tableRows) error {
+	rows, err := r.query(ctx, i, externalLocationsQuery, schemaExternalLocationsQuery)
+	if err != nil {
+		return fmt.Errorf("oracle: querying %s external locations: %w", r, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			l   ExternalLocation
+			dir sql.NullString
+		)
+		if err := rows.Scan(r.dest(&dir, &l.Name)...); err != nil {
+			return fmt.Errorf("oracle: scanning external location: %w", err)
+		}
+		t, ok := r.table()
+		if !ok {
+			continue
+		}
+		ext, ok := externalOf(t)
+		if !ok {
+			continue
+		}
+		// Locations in the default directory are not prefixed.
+		if dir.String != ext.Directory {
+			l.Directory = dir.String
+		}
+		ext.Locations = append(ext.Locations, l)
+	}
+	return rows.Err()
+}
+
+// lastDDLTime queries the time of the last DDL statement that modified
+// the tables, and appends it to the table attributes.
+func (i *inspect) lastDDLTime(ctx context.Context, r *tableRows) error {
+	rows, err := r.query(ctx, i, lastDDLQuery, schemaLastDDLQuery)
+	if err != nil {
+		return fmt.Errorf("oracle: querying %s last ddl time: %w", r, err)
+	}
+	defer rows.Close()
+	// Tables that were dropped after they were inspected are not listed.
+	for rows.Next() {
+		var v sql.NullString
+		if err := rows.Scan(r.dest(&v)...); err != nil {
+			return fmt.Errorf("oracle: scanning %s last ddl time: %w", r, err)
+		}
+		t, ok := r.table()
+		if !ok || !sqlx.ValidString(v) {
+			continue
+		}
+		ts, err := time.Parse(lastDDLLayout, v.String)
+		if err != nil {
+			return fmt.Errorf("oracle: parsing %q last ddl time: %w", t.Name, err)
+		}
+		t.Attrs = append(t.Attrs, &LastDDL{T: ts})
+	}
+	return rows.Err()
+}
+
+// auditOptions queries the auditing options of the tables and appends them
+// to the table attributes. Each option is reported in the form of "S/F",
+// where S and F are the modes of auditing successful and unsuccessful
+// statements: "-" for none, "S" for BY SESSION and "A" for BY ACCESS.
+func (i *inspect) auditOptions(ctx context.Context, r *tableRows) error {
+	rows, err := r.query(ctx, i, auditOptsQuery, schemaAuditOptsQuery)
+	if err != nil {
+		return fmt.Errorf("oracle: querying %s audit options: %w", r, err)
+	}
+	defer rows.Close()
+	// Tables without auditing options are not listed.
+	for rows.Next() {
+		opts := make([]sql.NullString, len(auditOptions))
+		dest := make([]interface{}, len(opts))
+		for i := range opts {
+			dest[i] = &opts[i]
+		}
+		if err := rows.Scan(r.dest(dest...)...); err != nil {
+			return fmt.Errorf("oracle: scanning %s audit options: %w", r, err)
+		}
+		t, ok := r.table()
+		if !ok {
+			continue
+		}
+		for i, o := range opts {
+			modes := strings.Split(o.String, "/")
+			if len(modes) != 2 {
+				continue
+			}
+			switch succ, fail := modes[0], modes[1]; {
+			case succ == fail:
+				t.Attrs = appendAudit(t.Attrs, auditOptions[i], succ, "")
+			default:
+				t.Attrs = appendAudit(t.Attrs, auditOptions[i], succ, AuditSuccessful)
+				t.Attrs = appendAudit(t.Attrs, auditOptions[i], fail, AuditNotSuccessful)
+			}
+		}
+	}
+	return rows.Err()
+}
+
+// appendAudit appends the Audit attribute of the given option and mode, if it is audited.
+func appendAudit(attrs []schema.Attr, option, mode, when string) []schema.Attr {
+	switch mode {
+	case "S":
+		return append(attrs, &Audit{Option: option, By: AuditBySession, When: when})
+	case "A":
+		return append(attrs, &Audit{Option: option, By: AuditByAccess, When: when})
+	}
+	return attrs
+}
+
+// hasUnsupported reports if the table has columns with types that are not
+// recognized by their name, such as user-defined types.
+func hasUnsupported(t *schema.Table) bool {
+	for _, c := range t.Columns {
+		if _, ok := c.Type.Type.(*schema.UnsupportedType); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// collectionTypes queries the collection types (VARRAY and nested tables)
+// that are used by the table columns, and replaces the unsupported types of
+// these columns with their definitions.
+func (i *inspect) collectionTypes(ctx context.Context, r *tableRows) error {
+	rows, err := r.query(ctx, i, collTypesQuery, schemaCollTypesQuery)
+	if err != nil {
+		return fmt.Errorf("oracle: querying %s collection types: %w", r, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			column, owner, name, kind, elem, nulls string
+			bound, length, precision, scale        sql.NullInt64
+		)
+		if err := rows.Scan(r.dest(&column, &owner, &name, &kind, &bound, &elem, &length, &precision, &scale, &nulls)...); err != nil {
+			return fmt.Errorf("oracle: scanning %s collection types: %w", r, err)
+		}
+		t, ok := r.table()
+		if !ok {
+			continue
+		}
+		c, ok := t.Column(column)
+		if !ok {
+			continue
+		}
+		d, err := parseColumn(elem)
+		if err != nil {
+			return err
+		}
+		d.size, d.precision, d.scale = length.Int64, precision.Int64, scale.Int64
+		ct := &CollectionType{
+			T:    name,
+			Kind: CollectionTable,
+			Elem: columnType(d),
+			// Null information is not stored for
+			// elements that were declared NOT NULL.
+			NotNull: nulls == "NO",
+		}
+		if kind == "VARYING ARRAY" {
+			ct.Kind, ct.Size = CollectionVarray, bound.Int64
+		}
+		if owner != t.Schema.Name {
+			ct.Schema = owner
+		}
+		c.Type.Type = ct
+	}
+	return rows.Err()
+}
+
+// hasColumns reports if the table has columns.
+func hasColumns(t *schema.Table) bool {
+	return len(t.Columns) > 0
+}
+
+// isExternal reports if the table is an external table.
+func isExternal(t *schema.Table) bool {
+	_, ok := externalOf(t)
+	return ok
+}
+
+// externalOf returns the External attribute of the table, if it is an external table.
+func externalOf(t *schema.Table) (*External, bool) {
+	for _, a := range t.Attrs {
+		if ext, ok := a.(*External); ok {
+			return ext, true
+		}
+	}
+	return nil, false
+}
+
+// hasLOB reports if the table has CLOB, NCLOB or BLOB columns.
+func hasLOB(t *schema.Table) bool {
+	for _, c := range t.Columns {
+		switch tt := c.Type.Type.(type) {
+		case *schema.StringType:
+			if tt.T == TypeCLOB || tt.T == TypeNCLOB {
+				return true
+			}
+		case *schema.BinaryType:
+			if tt.T == TypeBLOB {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lobStorage queries the storage options of the LOB columns
+// of the tables, and appends them to the columns attributes.
+func (i *inspect) lobStorage(ctx context.Context, r *tableRows) error {
+	rows, err := r.query(ctx, i, lobsQuery, schemaLobsQuery)
+	if err != nil {
+		return fmt.Errorf("oracle: querying %s lob storage: %w", r, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			column, secure, inRow string
+			pct, retention        sql.NullInt64
+		)
+		if err := rows.Scan(r.dest(&column, &secure, &inRow, &pct, &retention)...); err != nil {
+			return fmt.Errorf("oracle: scanning %s lob storage: %w", r, err)
+		}
+		t, ok := r.table()
+		if !ok {
+			continue
+		}
+		c, ok := t.Column(column)
+		if !ok {
+			continue
+		}
+		s := &LOBStorage{SecureFile: secure == "YES", OutOfRow: inRow == "NO"}
+		// PCTVERSION is NULL for BASICFILE LOBs that use RETENTION.
+		if !s.SecureFile {
+			s.PctVersion = int(pct.Int64)
+			s.Retention = !pct.Valid && retention.Valid
+		}
+		c.Attrs = append(c.Attrs, s)
+	}
+	return rows.Err()
+}
+
+// objectTypes queries the object types that are used by the table
+// columns, and replaces the unsupported types of these columns.
+func (i *inspect) objectTypes(ctx context.Context, r *tableRows) error {
+	rows, err := r.query(ctx, i, objectTypesQuery, schemaObjectTypesQuery)
+	if err != nil {
+		return fmt.Errorf("oracle: querying %s object types: %w", r, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var column, owner, name string
+		if err := rows.Scan(r.dest(&column, &owner, &name)...); err != nil {
+			return fmt.Errorf("oracle: scanning %s object types: %w", r, err)
+		}
+		t, ok := r.table()
+		if !ok {
+			continue
+		}
+		if c, ok := t.Column(column); ok {
+			c.Type.Type = &UserDefinedType{T: name, Schema: owner}
+		}
+	}
+	return rows.Err()
+}
+
+// constraintsDDL queries the DDL of the table constraints and appends it to
+// the table attributes. Only constraints that were modeled on inspection are
+// captured. For example, NOT NULL constraints are skipped, as they are already
+// represented by the column nullability.
+func (i *inspect) constraintsDDL(ctx context.Context, r *tableRows) error {
+	rows, err := r.query(ctx, i, constraintsDDLQuery, schemaConstraintsDDLQuery)
+	if err != nil {
+		return fmt.Errorf("oracle: querying %s constraints ddl: %w", r, err)
+	}
+	defer rows.Close()
+	names := make(map[*schema.Table]map[string]bool)
+	for rows.Next() {
+		var name, typ, ddl string
+		if err := rows.Scan(r.dest(&name, &typ, &ddl)...); err != nil {
+			return fmt.Errorf("oracle: scanning constraint ddl: %w", err)
+		}
+		t, ok := r.table()
+		if !ok {
+			continue
+		}
+		if names[t] == nil {
+			names[t] = constraintNames(t)
+		}
+		if names[t][name] {
+			t.Attrs = append(t.Attrs, &RawConstraint{Name: name, T: typ, DDL: strings.TrimSpace(ddl)})
+		}
+	}
+	return rows.Err()
+}
+
+// constraintNames returns the names of the constraints that were modeled on inspection.
+func constraintNames(t *schema.Table) map[string]bool {
+	names := make(map[string]bool)
+	if t.PrimaryKey != nil {
+		names[t.PrimaryKey.Name] = true
+	}
+	for _, idx := range t.Indexes {
+		names[idx.Name] = true
+	}
+	for _, fk := range t.ForeignKeys {
+		names[fk.Symbol] = true
+	}
+	for _, a := range t.Attrs {
+		if c, ok := a.(*schema.Check); ok {
+			names[c.Name] = true
+		}
+	}
+	return names
+}
+
+// schemas returns the list of the schemas in the database.
+func (i *inspect) schemas(ctx context.Context, opts *schema.InspectRealmOption) ([]*schema.Schema, error) {
+	var (
+		args  []interface{}
+		query = schemasQuery
+	)
+	if opts != nil && len(opts.Schemas) > 0 {
+		query, args = inStrings(opts.Schemas, schemasQueryArgs, args)
+	}
+	rows, err := i.QueryContext(ctx, i.dictQuery(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("oracle: querying schemas: %w", err)
+	}
+	defer rows.Close()
+	var schemas []*schema.Schema
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, &schema.Schema{
+			Name: name,
+		})
+	}
+	return schemas, nil
+}
+
+// tableNames returns a list of all tables exist in the schema.
+func (i *inspect) tableNames(ctx context.Context, schema string, opts *schema.InspectOptions) ([]string, error) {
+	query, args := tablesQuery, []interface{}{schema}
+	if opts != nil && len(opts.Tables) > 0 {
+		query, args = inStrings(opts.Tables, tablesQueryArgs, args)
+	}
+	rows, err := i.QueryContext(ctx, i.dictQuery(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("oracle: querying schema tables: %w", err)
+	}
+	names, err := sqlx.ScanStrings(rows)
+	if err != nil {
+		return nil, fmt.Errorf("oracle: scanning table names: %w", err)
+	}
+	if len(i.excludePrefixes) == 0 {
+		return names, nil
+	}
+	filtered := names[:0]
+	for _, n := range names {
+		if !i.excluded(n) {
+			filtered = append(filtered, n)
+		}
+	}
+	return filtered, nil
+}
+
+// inStrings formats the query with an "= :N" or "IN (:N, ...)" predicate
+// for the given values, using positional binds that continue the numbering
+// of the already existing arguments.
+func inStrings(s []string, query string, args []interface{}) (string, []interface{}) {
+	var b strings.Builder
+	switch len(s) {
+	case 1:
+		args = append(args, s[0])
+		b.WriteString("= :")
+		b.WriteString(strconv.Itoa(len(args)))
+	default:
+		b.WriteString("IN (")
+		for i := range s {
+			args = append(args, s[i])
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteByte(':')
+			b.WriteString(strconv.Itoa(len(args)))
+		}
+		b.WriteByte(')')
+	}
+	return fmt.Sprintf(query, b.String()), args
+}
+
+// defaultExpr returns the schema.Expr for the given DATA_DEFAULT value.
+// Note that Oracle stores the DEFAULT clause as it was written, including
+// its trailing whitespaces.
+//
+// Numeric literals (e.g. 0, -1.5, 2E10 or 1.5f) and string literals (e.g. 'a'
+// or N'a') are returned as literals. Other values, such as the SYSDATE, USER
+// and SYSTIMESTAMP functions, or calls like SYS_GUID(), are raw expressions.
+func defaultExpr(x string) schema.Expr {
+	switch x = strings.TrimSpace(x); {
+	case reNumberLiteral.MatchString(x), isStringLiteral(x):
+		return &schema.Literal{V: x}
+	default:
+		return &schema.RawExpr{X: x}
+	}
+}
+
+// reNumberLiteral matches the numeric literals of Oracle, including
+// the BINARY_FLOAT and BINARY_DOUBLE suffixes (f and d).
+var reNumberLiteral = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?[fFdD]?$`)
+
+// isStringLiteral reports if the given value is a single string literal, or
+// a national character literal (e.g. N'a'). Quotes inside the literal must be
+// escaped by doubling them, to not match expressions like 'a' || 'b'.
+func isStringLiteral(x string) bool {
+	if len(x) > 1 && (x[0] == 'N' || x[0] == 'n') {
+		x = x[1:]
+	}
+	if len(x) < 2 || x[0] != '\'' || x[len(x)-1] != '\'' {
+		return false
+	}
+	return !strings.Contains(strings.ReplaceAll(x[1:len(x)-1], "''", ""), "'")
+}
+
+type (
+	// Sequence defines (the supported) sequence options. It is used by identity
+	// columns, and as a schema attribute for sequences created using CREATE SEQUENCE.
+	// A zero Min or Max value means the sequence uses the default bound
+	// (i.e. NOMINVALUE or NOMAXVALUE). Oracle does not keep the START WITH value of
+	// standalone sequences, and therefore, their Start is not set on inspection.
+	// Instead, Last holds the value they continue from (LAST_NUMBER).
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/CREATE-SEQUENCE.html
+	Sequence struct {
+		schema.Attr
+		Name             string // Empty for identity sequences.
+		Start, Increment int64
+		Last             int64 // Set on inspection only.
+		Min, Max         int64
+		Cache            int64 // Zero means NOCACHE.
+		Cycle            bool
+		Order            bool // ORDER or NOORDER (the default).
+		Keep             bool // KEEP or NOKEEP (the default). Available since 18c.
+		// Scalable and sharded sequences (18c), and whether their values are
+		// extended with the instance and session offsets (i.e. SCALE EXTEND).
+		Scale, Shard, Extend bool
+		Session              bool // SESSION or GLOBAL (the default).
+	}
+
+	// Parallel describes the PARALLEL clause of a table. Degree and Instances
+	// are set to ParallelDefault if the database computes them (i.e. DEFAULT).
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/CREATE-TABLE.html
+	Parallel struct {
+		schema.Attr
+		Degree    int
+		Instances int
+	}
+
+	// Tablespace describes the tablespace a table or an index resides in.
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/refrn/ALL_TABLES.html
+	Tablespace struct {
+		schema.Attr
+		Name string
+	}
+
+	// RowDependencies describes a table that was created with ROWDEPENDENCIES,
+	// which tracks the SCN of the last change per row instead of per block.
+	// The option is set on creation, and cannot be altered afterwards.
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/CREATE-TABLE.html
+	RowDependencies struct {
+		schema.Attr
+	}
+
+	// Temporary describes a global temporary table, and whether its
+	// rows are deleted or preserved when the transaction is committed.
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/CREATE-TABLE.html
+	Temporary struct {
+		schema.Attr
+		OnCommit string // DELETE (the default) or PRESERVE.
+	}
+
+	// Organization describes the organization of a table. Tables without this
+	// attribute are heap-organized. The PctThreshold, Overflow and Mapping fields
+	// are used only by index-organized tables (IOT).
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/CREATE-TABLE.html
+	Organization struct {
+		schema.Attr
+		T            string // HEAP or INDEX.
+		PctThreshold int    // 0 stands for the default (50).
+		Overflow     bool
+		Mapping      bool // A mapping table, required for bitmap indexes.
+	}
+
+	// External describes the ORGANIZATION EXTERNAL clause of an external table.
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/CREATE-TABLE.html
+	External struct {
+		schema.Attr
+		Type      string // Access driver type. e.g. ORACLE_LOADER, ORACLE_DATAPUMP.
+		Directory string // Default directory.
+		Params    string // Access parameters (the text within the parentheses).
+		Locations []ExternalLocation
+	}
+
+	// ExternalLocation describes a location (data file) of an external table.
+	// The Directory is empty for locations that reside in the default directory.
+	ExternalLocation struct {
+		Directory string
+		Name      string
+	}
+
+	// View describes a view that is represented as a schema.Table. Def holds
+	// the defining query (the text following the AS keyword).
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/refrn/ALL_VIEWS.html
+	View struct {
+		schema.Attr
+		Def string
+	}
+
+	// MaterializedView describes a materialized view that is represented as
+	// a schema.Table. Def holds the defining query, and the rest of the fields
+	// hold the refresh options as reported by the database.
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/refrn/ALL_MVIEWS.html
+	MaterializedView struct {
+		schema.Attr
+		Def           string
+		RefreshMethod string // COMPLETE, FAST, FORCE, NEVER.
+		RefreshMode   string // DEMAND, COMMIT, STATEMENT, NEVER.
+		BuildMode     string // IMMEDIATE, DEFERRED, PREBUILT.
+	}
+
+	// Synonym describes an alias for a database object. Private synonyms are
+	// attached to the schema that owns them, and public synonyms to the realm.
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/CREATE-SYNONYM.html
+	Synonym struct {
+		schema.Attr
+		Name        string
+		Public      bool
+		TableSchema string // The owner of the referenced object.
+		Table       string // The referenced object. Not necessarily a table.
+		DBLink      string // Set for objects in remote databases.
+	}
+
+	// ConstraintState describes the non-default state of a constraint. It is
+	// attached to the schema.Check it describes, to the columns for their NOT
+	// NULL constraints, and to the table attributes for foreign keys, as
+	// schema.ForeignKey does not hold attributes.
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/constraint.html
+	ConstraintState struct {
+		schema.Attr
+		Name              string
+		Deferrable        bool // DEFERRABLE or NOT DEFERRABLE (the default).
+		InitiallyDeferred bool // INITIALLY DEFERRED or IMMEDIATE (the default).
+		Disabled          bool // DISABLE or ENABLE (the default).
+		NoValidate        bool // NOVALIDATE or VALIDATE (the default).
+	}
+
+	// RawConstraint holds the DDL of a table constraint as generated by the
+	// DBMS_METADATA package. It is captured on inspection when the driver is
+	// opened with the WithRawConstraints option, next to the modeled constraint.
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/arpls/DBMS_METADATA.html
+	RawConstraint struct {
+		schema.Attr
+		Name string
+		T    string // P, U, R, C.
+		DDL  string
+	}
+
+	// Virtual describes a virtual column (i.e. a generated column). Oracle does
+	// not support stored generated columns, and the column values are always
+	// computed from the expression when they are read.
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/CREATE-TABLE.html
+	Virtual struct {
+		schema.Attr
+		Expr string
+	}
+
+	// DefaultOnNull describes a column that was declared with DEFAULT ON NULL,
+	// and therefore, its default value is used also when NULL is inserted
+	// explicitly. Columns that are declared this way are implicitly NOT NULL.
+	// The attribute is supported by versions >= 12c.
+	DefaultOnNull struct {
+		schema.Attr
+	}
+
+	// Invisible describes an invisible column. Invisible columns are
+	// not returned by SELECT * and are not positioned in the table.
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/CREATE-TABLE.html
+	Invisible struct {
+		schema.Attr
+	}
+
+	// LengthSemantics describes the length semantics (BYTE or CHAR) of
+	// a CHAR or VARCHAR2 column. It is set only when it differs from the
+	// default semantics of the session (NLS_LENGTH_SEMANTICS), which are
+	// applied to columns that are declared without it.
+	LengthSemantics struct {
+		schema.Attr
+		T string
+	}
+
+	// LOBStorage describes the storage of a LOB column. PctVersion and Retention
+	// apply to BASICFILE LOBs only, and are mutually exclusive, as old versions
+	// of the LOB are kept either by a percentage of its storage (PCTVERSION), or
+	// by the undo retention period of the database (RETENTION). OutOfRow is set
+	// for LOBs that were declared with DISABLE STORAGE IN ROW.
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/refrn/ALL_LOBS.html
+	LOBStorage struct {
+		schema.Attr
+		SecureFile bool
+		OutOfRow   bool
+		PctVersion int
+		Retention  bool
+	}
+
+	// ILMPolicy describes an Automatic Data Optimization policy of a table.
+	// It is captured on inspection when the driver is opened with WithILM.
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/refrn/ALL_ILMDATAMOVEMENTPOLICIES.html
+	ILMPolicy struct {
+		schema.Attr
+		Name        string
+		Action      string // COMPRESSION, STORAGE.
+		Scope       string // ROW, SEGMENT, GROUP.
+		Compression string // e.g. ADVANCED, QUERY HIGH. Set for COMPRESSION policies.
+		Tier        string // The target tablespace of STORAGE policies.
+		Condition   string // e.g. LAST MODIFICATION TIME, CREATION TIME.
+		Days        int
+		Enabled     bool
+	}
+
+	// Audit describes an auditing option of a table, e.g. AUDIT SELECT ON t BY ACCESS.
+	// It is captured on inspection when the driver is opened with WithAudit. An empty
+	// When stands for auditing both successful and unsuccessful statements.
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/AUDIT-Traditional-Auditing.html
+	Audit struct {
+		schema.Attr
+		Option string // e.g. SELECT, INSERT, ALTER.
+		By     string // ACCESS, SESSION.
+		When   string // SUCCESSFUL, NOT SUCCESSFUL.
+	}
+
+	// LastDDL holds the time of the last DDL statement that modified a table,
+	// including grants and revokes. It is captured on inspection when the driver
+	// is opened with WithLastDDL. Note that DATE values do not hold a time zone,
+	// and T is reported in UTC, although it is in the time zone of the server.
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/refrn/ALL_OBJECTS.html
+	LastDDL struct {
+		schema.Attr
+		T time.Time
+	}
+
+	// IntervalType defines an interval type. Precision holds the leading field
+	// precision (e.g. YEAR(4)), and Scale holds the fractional seconds precision
+	// of the INTERVAL DAY TO SECOND type. Nil values stand for the defaults.
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/Data-Types.html
+	IntervalType struct {
+		schema.Type
+		T         string
+		Precision *int
+		Scale     *int
+	}
+
+	// RowIDType defines the ROWID and the UROWID (universal rowid) types.
+	// The Size is set only for UROWID columns that were declared with it.
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/Data-Types.html
+	RowIDType struct {
+		schema.Type
+		T    string
+		Size int
+	}
+
+	// CollectionType defines a user-defined collection type, a VARRAY or a nested
+	// table, that is used as a column type. The Schema is set only for types that
+	// are owned by another schema than the table. Elements that were declared as
+	// NOT NULL (e.g. VARRAY(10) OF NUMBER NOT NULL) are marked by NotNull.
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/CREATE-TYPE.html
+	CollectionType struct {
+		schema.Type
+		T       string
+		Schema  string
+		Kind    string // VARRAY, TABLE.
+		Size    int64  // Maximum number of VARRAY elements.
+		Elem    schema.Type
+		NotNull bool
+	}
+
+	// UserDefinedType defines a user-defined object type that is used as a column
+	// type. Unlike CollectionType, the Schema always holds the owner of the type.
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/CREATE-TYPE.html
+	UserDefinedType struct {
+		schema.Type
+		T      string
+		Schema string
+	}
+
+	// Collection describes a collection type that is created in a schema.
+	// Like standalone sequences, it is planned as a schema attribute.
+	Collection struct {
+		schema.Attr
+		T *CollectionType
+	}
+
+	// XMLType defines the XMLTYPE type for storing XML data.
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/arpls/XMLTYPE.html
+	XMLType struct {
+		schema.Type
+		T string
+	}
+
+	// Identity defines an identity column.
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/CREATE-TABLE.html
+	Identity struct {
+		schema.Attr
+		Generation string // ALWAYS, BY DEFAULT.
+		Sequence   *Sequence
+		// The name of the sequence that backs the column (e.g. ISEQ$$_73001).
+		// It is generated by the database, and therefore, it is captured on
+		// inspection, but it is not used when planning changes.
+		SequenceName string
+	}
+
+	// ConType describes the type of the constraint an index is backing.
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/refrn/ALL_CONSTRAINTS.html
+	ConType struct {
+		schema.Attr
+		T string // P, U.
+	}
+
+	// IndexType represents an index type.
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/refrn/ALL_INDEXES.html
+	IndexType struct {
+		schema.Attr
+		T string // NORMAL, BITMAP, FUNCTION-BASED NORMAL, FUNCTION-BASED BITMAP, DOMAIN, etc.
+	}
+
+	// LocalIndex describes a local partitioned index, which is partitioned the
+	// same way its table is. It is commonly used by the indexes that back the
+	// unique and primary key constraints of partitioned tables. Indexes without
+	// this attribute are global (non-partitioned) indexes.
+	// https://docs.oracle.com/en/database/oracle/oracle-database/19/refrn/ALL_PART_INDEXES.html
+	LocalIndex struct {
+		schema.Attr
+	}
+
+	// IndexColumnProperty describes an index column property.
+	IndexColumnProperty struct {
+		schema.Attr
+		Desc bool
+	}
+
+	// CheckColumns attribute hold the column named used by the CHECK constraints.
+	// This attribute is added on inspection for internal usage and has no meaning
+	// on migration.
+	CheckColumns struct {
+		schema.Attr
+		Columns []string
+	}
+)
+
+// Default sequence attributes.
+const (
+	defaultSeqStart     = 1
+	defaultSeqIncrement = 1
+	defaultSeqCache     = 20
+	// The bounds that are used by ascending sequences with NOMAXVALUE,
+	// and by descending sequences with NOMINVALUE.
+	seqMaxValue = "9999999999999999999999999999"
+	seqMinValue = "-999999999999999999999999999"
+)
+
+// The organizations of tables.
+const (
+	OrganizationHeap  = "HEAP"
+	OrganizationIndex = "INDEX"
+)
+
+// The ON COMMIT modes of global temporary tables.
+const (
+	OnCommitDelete   = "DELETE"
+	OnCommitPreserve = "PRESERVE"
+)
+
+// The auditing options of tables, as they are selected by auditOptsQuery.
+var auditOptions = []string{"ALTER", "AUDIT", "COMMENT", "DELETE", "GRANT", "INDEX", "INSERT", "LOCK", "RENAME", "SELECT", "UPDATE", "FLASHBACK"}
+
+// Auditing modes and conditions of the Audit attribute.
+const (
+	AuditByAccess      = "ACCESS"
+	AuditBySession     = "SESSION"
+	AuditSuccessful    = "SUCCESSFUL"
+	AuditNotSuccessful = "NOT SUCCESSFUL"
+)
+
+// The layout of the LAST_DDL_TIME values returned by lastDDLQuery.
+const lastDDLLayout = "2006-01-02 15:04:05"
+
+// ParallelDefault represents the DEFAULT value of the
+// DEGREE and INSTANCES options of the PARALLEL clause.
+const ParallelDefault = -1
+
+// Bounds of the precision and the scale of the NUMBER type. A negative
+// scale rounds the value to the left of the decimal point.
+const (
+	maxNumberPrecision = 38
+	minNumberScale     = -84
+	maxNumberScale     = 127
+)
+
+const (
+	// Query to get the current schema (i.e. the default schema used for name resolution),
+	// and the session user that is used as a fallback in case the former is empty.
+	currentSchemaQuery = "SELECT SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA'), SYS_CONTEXT('USERENV', 'SESSION_USER') FROM DUAL"
+
+	// Query to list database schemas. Oracle-maintained
+	// accounts that exist in every installation are skipped.
+	schemasQuery = "SELECT USERNAME FROM ALL_USERS WHERE USERNAME NOT IN ('ANONYMOUS', 'APPQOSSYS', 'AUDSYS', 'CTXSYS', 'DBSFWUSER', 'DBSNMP', 'DIP', 'DVSYS', 'GGSYS', 'GSMADMIN_INTERNAL', 'LBACSYS', 'MDSYS', 'OJVMSYS', 'OLAPSYS', 'ORDDATA', 'ORDPLUGINS', 'ORDSYS', 'OUTLN', 'REMOTE_SCHEDULER_AGENT', 'SI_INFORMTN_SCHEMA', 'SYS', 'SYSTEM', 'WMSYS', 'XDB', 'XS$NULL') ORDER BY USERNAME"
+
+	// Query to list specific database schemas.
+	schemasQueryArgs = "SELECT USERNAME FROM ALL_USERS WHERE USERNAME %s ORDER BY USERNAME"
+
+	// Query to list schema tables. Tables in the recycle bin, nested tables,
+	// secondary objects (e.g. domain indexes storage) and the container tables
+	// of materialized views are skipped.
+	tablesQuery = "SELECT t.TABLE_NAME FROM ALL_TABLES t WHERE t.OWNER = :1 AND t.DROPPED = 'NO' AND t.NESTED = 'NO' AND t.SECONDARY = 'N' AND (t.IOT_TYPE IS NULL OR t.IOT_TYPE = 'IOT') AND NOT EXISTS (SELECT 1 FROM ALL_MVIEWS m WHERE m.OWNER = t.OWNER AND m.MVIEW_NAME = t.TABLE_NAME) ORDER BY t.TABLE_NAME"
+
+	// Query to list specific schema tables.
+	tablesQueryArgs = "SELECT t.TABLE_NAME FROM ALL_TABLES t WHERE t.OWNER = :1 AND t.DROPPED = 'NO' AND t.NESTED = 'NO' AND t.SECONDARY = 'N' AND (t.IOT_TYPE IS NULL OR t.IOT_TYPE = 'IOT') AND NOT EXISTS (SELECT 1 FROM ALL_MVIEWS m WHERE m.OWNER = t.OWNER AND m.MVIEW_NAME = t.TABLE_NAME) AND t.TABLE_NAME %s ORDER BY t.TABLE_NAME"
+
+	// Query to list schema views and their defining query.
+	viewsQuery = "SELECT VIEW_NAME, TEXT FROM ALL_VIEWS WHERE OWNER = :1 ORDER BY VIEW_NAME"
+
+	// Query to list specific schema views.
+	viewsQueryArgs = "SELECT VIEW_NAME, TEXT FROM ALL_VIEWS WHERE OWNER = :1 AND VIEW_NAME %s ORDER BY VIEW_NAME"
+
+	// Query to list schema materialized views and their refresh options.
+	mviewsQuery = "SELECT MVIEW_NAME, QUERY, REFRESH_METHOD, REFRESH_MODE, BUILD_MODE FROM ALL_MVIEWS WHERE OWNER = :1 ORDER BY MVIEW_NAME"
+
+	// Query to list specific schema materialized views.
+	mviewsQueryArgs = "SELECT MVIEW_NAME, QUERY, REFRESH_METHOD, REFRESH_MODE, BUILD_MODE FROM ALL_MVIEWS WHERE OWNER = :1 AND MVIEW_NAME %s ORDER BY MVIEW_NAME"
+
+	// Query to list the triggers that emulate identity columns in versions < 12c,
+	// together with the state of the sequences they take their values from.
+	identityTriggersQuery = `
+SELECT
+	t.TRIGGER_NAME,
+	t.WHEN_CLAUSE,
+	s.INCREMENT_BY,
+	s.LAST_NUMBER
+FROM
+	ALL_TRIGGERS t
+	JOIN ALL_SEQUENCES s ON s.SEQUENCE_OWNER = t.TABLE_OWNER AND s.SEQUENCE_NAME = SUBSTR(t.TRIGGER_NAME, 1, LENGTH(t.TRIGGER_NAME) - 4) || '_SEQ'
+WHERE
+	t.TABLE_OWNER = :1
+	AND t.TABLE_NAME = :2
+	AND t.TRIGGER_TYPE = 'BEFORE EACH ROW'
+	AND t.TRIGGERING_EVENT = 'INSERT'
+	AND t.TRIGGER_NAME LIKE '%\_TRG' ESCAPE '\'
+ORDER BY
+	t.TRIGGER_NAME
+`
+
+	// Query to list the identity triggers of all tables in a schema. The
+	// column order matches the query above, followed by the table name.
+	schemaIdentityTriggersQuery = `
+SELECT
+	t.TRIGGER_NAME,
+	t.WHEN_CLAUSE,
+	s.INCREMENT_BY,
+	s.LAST_NUMBER,
+	t.TABLE_NAME
+FROM
+	ALL_TRIGGERS t
+	JOIN ALL_SEQUENCES s ON s.SEQUENCE_OWNER = t.TABLE_OWNER AND s.SEQUENCE_NAME = SUBSTR(t.TRIGGER_NAME, 1, LENGTH(t.TRIGGER_NAME) - 4) || '_SEQ'
+WHERE
+	t.TABLE_OWNER = :1
+	AND t.TRIGGER_TYPE = 'BEFORE EACH ROW'
+	AND t.TRIGGERING_EVENT = 'INSERT'
+	AND t.TRIGGER_NAME LIKE '%\_TRG' ESCAPE '\'
+ORDER BY
+	t.TABLE_NAME, t.TRIGGER_NAME
+`
+
+	// Query to get the state of the sequence that backs an identity column.
+	identitySequenceQuery = "SELECT TO_CHAR(s.LAST_NUMBER), TO_CHAR(s.MIN_VALUE), TO_CHAR(s.MAX_VALUE), s.INCREMENT_BY FROM ALL_TAB_IDENTITY_COLS t JOIN ALL_SEQUENCES s ON t.OWNER = s.SEQUENCE_OWNER AND t.SEQUENCE_NAME = s.SEQUENCE_NAME WHERE t.OWNER = :1 AND t.TABLE_NAME = :2 AND t.COLUMN_NAME = :3"
+
+	// Query to list schema sequences. Sequences that are generated by the
+	// database for identity columns (named ISEQ$$_<object_id>) are skipped.
+	// Note that Oracle does not keep the START WITH value of a sequence, and
+	// the LAST_NUMBER is the value it continues from when it is re-created.
+	sequencesQuery = `
+SELECT
+	SEQUENCE_NAME,
+	TO_CHAR(MIN_VALUE),
+	TO_CHAR(MAX_VALUE),
+	INCREMENT_BY,
+	CYCLE_FLAG,
+	CACHE_SIZE,
+	LAST_NUMBER,
+	ORDER_FLAG,
+	KEEP_VALUE,
+	SCALE_FLAG,
+	EXTEND_FLAG,
+	SHARDED_FLAG,
+	SESSION_FLAG
+FROM
+	ALL_SEQUENCES
+WHERE
+	SEQUENCE_OWNER = :1
+	AND SEQUENCE_NAME NOT LIKE 'ISEQ$$\_%' ESCAPE '\'
+ORDER BY
+	SEQUENCE_NAME
+`
+
+	// Query to list the sequences of a schema in versions that do not
+	// support the KEEP attribute and scalable sequences (< 18c). Session
+	// sequences (12c) are reported only on the versions above.
+	sequencesQueryNoScale = `
+SELECT
+	SEQUENCE_NAME,
+	TO_CHAR(MIN_VALUE),
+	TO_CHAR(MAX_VALUE),
+	INCREMENT_BY,
+	CYCLE_FLAG,
+	CACHE_SIZE,
+	LAST_NUMBER,
+	ORDER_FLAG,
+	'N' AS KEEP_VALUE,
+	'N' AS SCALE_FLAG,
+	'N' AS EXTEND_FLAG,
+	'N' AS SHARDED_FLAG,
+	'N' AS SESSION_FLAG
+FROM
+	ALL_SEQUENCES
+WHERE
+	SEQUENCE_OWNER = :1
+	AND SEQUENCE_NAME NOT LIKE 'ISEQ$$\_%' ESCAPE '\'
+ORDER BY
+	SEQUENCE_NAME
+`
+
+	// Query to list the private synonyms of a schema.
+	synonymsQuery = "SELECT OWNER, SYNONYM_NAME, TABLE_OWNER, TABLE_NAME, DB_LINK FROM ALL_SYNONYMS WHERE OWNER = :1 ORDER BY SYNONYM_NAME"
+
+	// Query to list the public synonyms that point to objects of specific schemas.
+	publicSynonymsQuery = "SELECT OWNER, SYNONYM_NAME, TABLE_OWNER, TABLE_NAME, DB_LINK FROM ALL_SYNONYMS WHERE OWNER = 'PUBLIC' AND TABLE_OWNER %s ORDER BY SYNONYM_NAME"
+
+	// Query to list table information.
+	tableQuery = `
+SELECT
+	t1.OWNER,
+	t2.COMMENTS,
+	t1.DEGREE,
+	t1.INSTANCES,
+	t1.DEPENDENCIES,
+	t1.TEMPORARY,
+	t1.DURATION,
+	t3.TYPE_NAME,
+	t3.DEFAULT_DIRECTORY_NAME,
+	t3.ACCESS_PARAMETERS,
+	t1.TABLESPACE_NAME,
+	t1.IOT_TYPE,
+	t4.PCT_THRESHOLD,
+	t5.TABLE_NAME AS OVERFLOW_NAME,
+	t6.TABLE_NAME AS MAPPING_NAME
+FROM
+	ALL_TABLES t1
+	LEFT JOIN ALL_TAB_COMMENTS t2
+	ON t1.OWNER = t2.OWNER
+	AND t1.TABLE_NAME = t2.TABLE_NAME
+	LEFT JOIN ALL_EXTERNAL_TABLES t3
+	ON t1.OWNER = t3.OWNER
+	AND t1.TABLE_NAME = t3.TABLE_NAME
+	LEFT JOIN ALL_INDEXES t4
+	ON t1.OWNER = t4.TABLE_OWNER
+	AND t1.TABLE_NAME = t4.TABLE_NAME
+	AND t4.INDEX_TYPE = 'IOT - TOP'
+	LEFT JOIN ALL_TABLES t5
+	ON t1.OWNER = t5.OWNER
+	AND t1.TABLE_NAME = t5.IOT_NAME
+	AND t5.IOT_TYPE = 'IOT_OVERFLOW'
+	LEFT JOIN ALL_TABLES t6
+	ON t1.OWNER = t6.OWNER
+	AND t1.TABLE_NAME = t6.IOT_NAME
+	AND t6.IOT_TYPE = 'IOT_MAPPING'
+WHERE
+	t1.TABLE_NAME = :1
+	AND t1.OWNER = SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA')
+`
+	tableSchemaQuery = `
+SELECT
+	t1.OWNER,
+	t2.COMMENTS,
+	t1.DEGREE,
+	t1.INSTANCES,
+	t1.DEPENDENCIES,
+	t1.TEMPORARY,
+	t1.DURATION,
+	t3.TYPE_NAME,
+	t3.DEFAULT_DIRECTORY_NAME,
+	t3.ACCESS_PARAMETERS,
+	t1.TABLESPACE_NAME,
+	t1.IOT_TYPE,
+	t4.PCT_THRESHOLD,
+	t5.TABLE_NAME AS OVERFLOW_NAME,
+	t6.TABLE_NAME AS MAPPING_NAME
+FROM
+	ALL_TABLES t1
+	LEFT JOIN ALL_TAB_COMMENTS t2
+	ON t1.OWNER = t2.OWNER
+	AND t1.TABLE_NAME = t2.TABLE_NAME
+	LEFT JOIN ALL_EXTERNAL_TABLES t3
+	ON t1.OWNER = t3.OWNER
+	AND t1.TABLE_NAME = t3.TABLE_NAME
+	LEFT JOIN ALL_INDEXES t4
+	ON t1.OWNER = t4.TABLE_OWNER
+	AND t1.TABLE_NAME = t4.TABLE_NAME
+	AND t4.INDEX_TYPE = 'IOT - TOP'
+	LEFT JOIN ALL_TABLES t5
+	ON t1.OWNER = t5.OWNER
+	AND t1.TABLE_NAME = t5.IOT_NAME
+	AND t5.IOT_TYPE = 'IOT_OVERFLOW'
+	LEFT JOIN ALL_TABLES t6
+	ON t1.OWNER = t6.OWNER
+	AND t1.TABLE_NAME = t6.IOT_NAME
+	AND t6.IOT_TYPE = 'IOT_MAPPING'
+WHERE
+	t1.TABLE_NAME = :1
+	AND t1.OWNER = :2
+`
+	// Query to list the tables of a schema. The column order matches
+	// the query above, followed by the table name.
+	schemaTablesQuery = `
+SELECT
+	t1.OWNER,
+	t2.COMMENTS,
+	t1.DEGREE,
+	t1.INSTANCES,
+	t1.DEPENDENCIES,
+	t1.TEMPORARY,
+	t1.DURATION,
+	t3.TYPE_NAME,
+	t3.DEFAULT_DIRECTORY_NAME,
+	t3.ACCESS_PARAMETERS,
+	t1.TABLESPACE_NAME,
+	t1.IOT_TYPE,
+	t4.PCT_THRESHOLD,
+	t5.TABLE_NAME AS OVERFLOW_NAME,
+	t6.TABLE_NAME AS MAPPING_NAME,
+	t1.TABLE_NAME
+FROM
+	ALL_TABLES t1
+	LEFT JOIN ALL_TAB_COMMENTS t2
+	ON t1.OWNER = t2.OWNER
+	AND t1.TABLE_NAME = t2.TABLE_NAME
+	LEFT JOIN ALL_EXTERNAL_TABLES t3
+	ON t1.OWNER = t3.OWNER
+	AND t1.TABLE_NAME = t3.TABLE_NAME
+	LEFT JOIN ALL_INDEXES t4
+	ON t1.OWNER = t4.TABLE_OWNER
+	AND t1.TABLE_NAME = t4.TABLE_NAME
+	AND t4.INDEX_TYPE = 'IOT - TOP'
+	LEFT JOIN ALL_TABLES t5
+	ON t1.OWNER = t5.OWNER
+	AND t1.TABLE_NAME = t5.IOT_NAME
+	AND t5.IOT_TYPE = 'IOT_OVERFLOW'
+	LEFT JOIN ALL_TABLES t6
+	ON t1.OWNER = t6.OWNER
+	AND t1.TABLE_NAME = t6.IOT_NAME
+	AND t6.IOT_TYPE = 'IOT_MAPPING'
+WHERE
+	t1.OWNER = :1
+ORDER BY
+	t1.TABLE_NAME
+`
+	// Query to list the locations of an external table. The view does not
+	// expose the position of the locations, and they are returned in the
+	// order they are stored in the data dictionary.
+	externalLocationsQuery = "SELECT DIRECTORY_NAME, LOCATION FROM ALL_EXTERNAL_LOCATIONS WHERE OWNER = :1 AND TABLE_NAME = :2"
+
+	// Query to list the locations of the external tables of a schema.
+	schemaExternalLocationsQuery = "SELECT DIRECTORY_NAME, LOCATION, TABLE_NAME FROM ALL_EXTERNAL_LOCATIONS WHERE OWNER = :1"
+
+	// Query to list table columns. ALL_TAB_COLS is used instead of ALL_TAB_COLUMNS,
+	// as the latter does not expose the VIRTUAL_COLUMN information and invisible
+	// columns. System-generated columns are filtered out on scan (see addColumn).
+	// Invisible columns do not have a COLUMN_ID, and therefore, are listed last.
+	columnsQuery = `
+SELECT
+	t1.COLUMN_NAME,
+	t1.DATA_TYPE,
+	t1.NULLABLE,
+	t1.DATA_DEFAULT,
+	t1.DATA_LENGTH,
+	t1.CHAR_LENGTH,
+	t1.DATA_PRECISION,
+	t1.DATA_SCALE,
+	t1.CHARACTER_SET_NAME,
+	t1.IDENTITY_COLUMN,
+	t3.GENERATION_TYPE,
+	t3.IDENTITY_OPTIONS,
+	t2.COMMENTS,
+	t1.VIRTUAL_COLUMN,
+	t1.HIDDEN_COLUMN,
+	t1.USER_GENERATED,
+	t1.CHAR_USED,
+	t1.COLLATION,
+	t1.DEFAULT_ON_NULL,
+	t3.SEQUENCE_NAME
+FROM
+	ALL_TAB_COLS t1
+	LEFT JOIN ALL_COL_COMMENTS t2
+	ON t1.OWNER = t2.OWNER
+	AND t1.TABLE_NAME = t2.TABLE_NAME
+	AND t1.COLUMN_NAME = t2.COLUMN_NAME
+	LEFT JOIN ALL_TAB_IDENTITY_COLS t3
+	ON t1.OWNER = t3.OWNER
+	AND t1.TABLE_NAME = t3.TABLE_NAME
+	AND t1.COLUMN_NAME = t3.COLUMN_NAME
+WHERE
+	t1.OWNER = :1
+	AND t1.TABLE_NAME = :2
+ORDER BY
+	t1.COLUMN_ID, t1.INTERNAL_COLUMN_ID
+`
+	// Query to list table columns in versions that do not support column
+	// collations (< 18c). The column order is kept the same.
+	columnsQueryNoCollation = `
+SELECT
+	t1.COLUMN_NAME,
+	t1.DATA_TYPE,
+	t1.NULLABLE,
+	t1.DATA_DEFAULT,
+	t1.DATA_LENGTH,
+	t1.CHAR_LENGTH,
+	t1.DATA_PRECISION,
+	t1.DATA_SCALE,
+	t1.CHARACTER_SET_NAME,
+	t1.IDENTITY_COLUMN,
+	t3.GENERATION_TYPE,
+	t3.IDENTITY_OPTIONS,
+	t2.COMMENTS,
+	t1.VIRTUAL_COLUMN,
+	t1.HIDDEN_COLUMN,
+	t1.USER_GENERATED,
+	t1.CHAR_USED,
+	NULL AS COLLATION,
+	t1.DEFAULT_ON_NULL,
+	t3.SEQUENCE_NAME
+FROM
+	ALL_TAB_COLS t1
+	LEFT JOIN ALL_COL_COMMENTS t2
+	ON t1.OWNER = t2.OWNER
+	AND t1.TABLE_NAME = t2.TABLE_NAME
+	AND t1.COLUMN_NAME = t2.COLUMN_NAME
+	LEFT JOIN ALL_TAB_IDENTITY_COLS t3
+	ON t1.OWNER = t3.OWNER
+	AND t1.TABLE_NAME = t3.TABLE_NAME
+	AND t1.COLUMN_NAME = t3.COLUMN_NAME
+WHERE
+	t1.OWNER = :1
+	AND t1.TABLE_NAME = :2
+ORDER BY
+	t1.COLUMN_ID, t1.INTERNAL_COLUMN_ID
+`
+	// Query to list table columns in versions that do not support identity
+	// and invisible columns (< 12c). The column order is kept the same, but
+	// as all hidden columns are system-generated, they are filtered out here.
+	columnsQueryNoIdentity = `
+SELECT
+	t1.COLUMN_NAME,
+	t1.DATA_TYPE,
+	t1.NULLABLE,
+	t1.DATA_DEFAULT,
+	t1.DATA_LENGTH,
+	t1.CHAR_LENGTH,
+	t1.DATA_PRECISION,
+	t1.DATA_SCALE,
+	t1.CHARACTER_SET_NAME,
+	'NO' AS IDENTITY_COLUMN,
+	NULL AS GENERATION_TYPE,
+	NULL AS IDENTITY_OPTIONS,
+	t2.COMMENTS,
+	t1.VIRTUAL_COLUMN,
+	'NO' AS HIDDEN_COLUMN,
+	'YES' AS USER_GENERATED,
+	t1.CHAR_USED,
+	NULL AS COLLATION,
+	NULL AS DEFAULT_ON_NULL,
+	NULL AS SEQUENCE_NAME
+FROM
+	ALL_TAB_COLS t1
+	LEFT JOIN ALL_COL_COMMENTS t2
+	ON t1.OWNER = t2.OWNER
+	AND t1.TABLE_NAME = t2.TABLE_NAME
+	AND t1.COLUMN_NAME = t2.COLUMN_NAME
+WHERE
+	t1.OWNER = :1
+	AND t1.TABLE_NAME = :2
+	AND t1.HIDDEN_COLUMN = 'NO'
+ORDER BY
+	t1.COLUMN_ID
+`
+	// Queries to list the columns of all tables (and views) in a schema using
+	// a single query. The column order matches the queries above, followed by
+	// the name of the table the column belongs to.
+	schemaColumnsQuery = `
+SELECT
+	t1.COLUMN_NAME,
+	t1.DATA_TYPE,
+	t1.NULLABLE,
+	t1.DATA_DEFAULT,
+	t1.DATA_LENGTH,
+	t1.CHAR_LENGTH,
+	t1.DATA_PRECISION,
+	t1.DATA_SCALE,
+	t1.CHARACTER_SET_NAME,
+	t1.IDENTITY_COLUMN,
+	t3.GENERATION_TYPE,
+	t3.IDENTITY_OPTIONS,
+	t2.COMMENTS,
+	t1.VIRTUAL_COLUMN,
+	t1.HIDDEN_COLUMN,
+	t1.USER_GENERATED,
+	t1.CHAR_USED,
+	t1.COLLATION,
+	t1.DEFAULT_ON_NULL,
+	t3.SEQUENCE_NAME,
+	t1.TABLE_NAME
+FROM
+	ALL_TAB_COLS t1
+	LEFT JOIN ALL_COL_COMMENTS t2
+	ON t1.OWNER = t2.OWNER
+	AND t1.TABLE_NAME = t2.TABLE_NAME
+	AND t1.COLUMN_NAME = t2.COLUMN_NAME
+	LEFT JOIN ALL_TAB_IDENTITY_COLS t3
+	ON t1.OWNER = t3.OWNER
+	AND t1.TABLE_NAME = t3.TABLE_NAME
+	AND t1.COLUMN_NAME = t3.COLUMN_NAME
+WHERE
+	t1.OWNER = :1
+ORDER BY
+	t1.TABLE_NAME, t1.COLUMN_ID, t1.INTERNAL_COLUMN_ID
+`
+	schemaColumnsQueryNoCollation = `
+SELECT
+	t1.COLUMN_NAME,
+	t1.DATA_TYPE,
+	t1.NULLABLE,
+	t1.DATA_DEFAULT,
+	t1.DATA_LENGTH,
+	t1.CHAR_LENGTH,
+	t1.DATA_PRECISION,
+	t1.DATA_SCALE,
+	t1.CHARACTER_SET_NAME,
+	t1.IDENTITY_COLUMN,
+	t3.GENERATION_TYPE,
+	t3.IDENTITY_OPTIONS,
+	t2.COMMENTS,
+	t1.VIRTUAL_COLUMN,
+	t1.HIDDEN_COLUMN,
+	t1.USER_GENERATED,
+	t1.CHAR_USED,
+	NULL AS COLLATION,
+	t1.DEFAULT_ON_NULL,
+	t3.SEQUENCE_NAME,
+	t1.TABLE_NAME
+FROM
+	ALL_TAB_COLS t1
+	LEFT JOIN ALL_COL_COMMENTS t2
+	ON t1.OWNER = t2.OWNER
+	AND t1.TABLE_NAME = t2.TABLE_NAME
+	AND t1.COLUMN_NAME = t2.COLUMN_NAME
+	LEFT JOIN ALL_TAB_IDENTITY_COLS t3
+	ON t1.OWNER = t3.OWNER
+	AND t1.TABLE_NAME = t3.TABLE_NAME
+	AND t1.COLUMN_NAME = t3.COLUMN_NAME
+WHERE
+	t1.OWNER = :1
+ORDER BY
+	t1.TABLE_NAME, t1.COLUMN_ID, t1.INTERNAL_COLUMN_ID
+`
+	schemaColumnsQueryNoIdentity = `
+SELECT
+	t1.COLUMN_NAME,
+	t1.DATA_TYPE,
+	t1.NULLABLE,
+	t1.DATA_DEFAULT,
+	t1.DATA_LENGTH,
+	t1.CHAR_LENGTH,
+	t1.DATA_PRECISION,
+	t1.DATA_SCALE,
+	t1.CHARACTER_SET_NAME,
+	'NO' AS IDENTITY_COLUMN,
+	NULL AS GENERATION_TYPE,
+	NULL AS IDENTITY_OPTIONS,
+	t2.COMMENTS,
+	t1.VIRTUAL_COLUMN,
+	'NO' AS HIDDEN_COLUMN,
+	'YES' AS USER_GENERATED,
+	t1.CHAR_USED,
+	NULL AS COLLATION,
+	NULL AS DEFAULT_ON_NULL,
+	NULL AS SEQUENCE_NAME,
+	t1.TABLE_NAME
+FROM
+	ALL_TAB_COLS t1
+	LEFT JOIN ALL_COL_COMMENTS t2
+	ON t1.OWNER = t2.OWNER
+	AND t1.TABLE_NAME = t2.TABLE_NAME
+	AND t1.COLUMN_NAME = t2.COLUMN_NAME
+WHERE
+	t1.OWNER = :1
+	AND t1.HIDDEN_COLUMN = 'NO'
+ORDER BY
+	t1.TABLE_NAME, t1.COLUMN_ID
+`
+	// Query to list table indexes. LOB indexes are managed
+	// by the database and therefore, are skipped.
+	indexesQuery = `
+SELECT
+	t1.INDEX_NAME,
+	t1.INDEX_TYPE,
+	t1.UNIQUENESS,
+	t3.CONSTRAINT_TYPE,
+	t2.COLUMN_NAME,
+	t2.DESCEND,
+	t4.COLUMN_EXPRESSION,
+	t1.TABLESPACE_NAME,
+	t5.LOCALITY
+FROM
+	ALL_INDEXES t1
+	JOIN ALL_IND_COLUMNS t2
+	ON t1.OWNER = t2.INDEX_OWNER
+	AND t1.INDEX_NAME = t2.INDEX_NAME
+	LEFT JOIN ALL_CONSTRAINTS t3
+	ON t1.TABLE_OWNER = t3.OWNER
+	AND t1.TABLE_NAME = t3.TABLE_NAME
+	AND t1.INDEX_NAME = t3.INDEX_NAME
+	AND t3.CONSTRAINT_TYPE IN ('P', 'U')
+	LEFT JOIN ALL_IND_EXPRESSIONS t4
+	ON t2.INDEX_OWNER = t4.INDEX_OWNER
+	AND t2.INDEX_NAME = t4.INDEX_NAME
+	AND t2.COLUMN_POSITION = t4.COLUMN_POSITION
+	LEFT JOIN ALL_PART_INDEXES t5
+	ON t1.OWNER = t5.OWNER
+	AND t1.INDEX_NAME = t5.INDEX_NAME
+WHERE
+	t1.TABLE_OWNER = :1
+	AND t1.TABLE_NAME = :2
+	AND t1.INDEX_TYPE <> 'LOB'
+ORDER BY
+	t1.INDEX_NAME, t2.COLUMN_POSITION
+`
+	// Query to list the indexes of all tables in a schema. The column
+	// order matches the query above, followed by the table name.
+	schemaIndexesQuery = `
+SELECT
+	t1.INDEX_NAME,
+	t1.INDEX_TYPE,
+	t1.UNIQUENESS,
+	t3.CONSTRAINT_TYPE,
+	t2.COLUMN_NAME,
+	t2.DESCEND,
+	t4.COLUMN_EXPRESSION,
+	t1.TABLESPACE_NAME,
+	t5.LOCALITY,
+	t1.TABLE_NAME
+FROM
+	ALL_INDEXES t1
+	JOIN ALL_IND_COLUMNS t2
+	ON t1.OWNER = t2.INDEX_OWNER
+	AND t1.INDEX_NAME = t2.INDEX_NAME
+	LEFT JOIN ALL_CONSTRAINTS t3
+	ON t1.TABLE_OWNER = t3.OWNER
+	AND t1.TABLE_NAME = t3.TABLE_NAME
+	AND t1.INDEX_NAME = t3.INDEX_NAME
+	AND t3.CONSTRAINT_TYPE IN ('P', 'U')
+	LEFT JOIN ALL_IND_EXPRESSIONS t4
+	ON t2.INDEX_OWNER = t4.INDEX_OWNER
+	AND t2.INDEX_NAME = t4.INDEX_NAME
+	AND t2.COLUMN_POSITION = t4.COLUMN_POSITION
+	LEFT JOIN ALL_PART_INDEXES t5
+	ON t1.OWNER = t5.OWNER
+	AND t1.INDEX_NAME = t5.INDEX_NAME
+WHERE
+	t1.TABLE_OWNER = :1
+	AND t1.INDEX_TYPE <> 'LOB'
+ORDER BY
+	t1.TABLE_NAME, t1.INDEX_NAME, t2.COLUMN_POSITION
+`
+	// Query to list table foreign keys. The column order matches the one
+	// expected by sqlx.ScanFKs. Oracle does not support referential actions
+	// for updates, and therefore, the rule is always "NO ACTION".
+	fksQuery = `
+SELECT
+	t1.CONSTRAINT_NAME,
+	t1.TABLE_NAME,
+	t2.COLUMN_NAME,
+	t1.OWNER,
+	t3.TABLE_NAME AS REFERENCED_TABLE_NAME,
+	t3.COLUMN_NAME AS REFERENCED_COLUMN_NAME,
+	t3.OWNER AS REFERENCED_SCHEMA_NAME,
+	'NO ACTION' AS UPDATE_RULE,
+	t1.DELETE_RULE
+FROM
+	ALL_CONSTRAINTS t1
+	JOIN ALL_CONS_COLUMNS t2
+	ON t1.OWNER = t2.OWNER
+	AND t1.CONSTRAINT_NAME = t2.CONSTRAINT_NAME
+	JOIN ALL_CONS_COLUMNS t3
+	ON t1.R_OWNER = t3.OWNER
+	AND t1.R_CONSTRAINT_NAME = t3.CONSTRAINT_NAME
+	AND t2.POSITION = t3.POSITION
+WHERE
+	t1.CONSTRAINT_TYPE = 'R'
+	AND t1.OWNER = :1
+	AND t1.TABLE_NAME = :2
+ORDER BY
+	t1.CONSTRAINT_NAME, t2.POSITION
+`
+	// Query to list the foreign keys of all tables in a schema.
+	schemaFKsQuery = `
+SELECT
+	t1.CONSTRAINT_NAME,
+	t1.TABLE_NAME,
+	t2.COLUMN_NAME,
+	t1.OWNER,
+	t3.TABLE_NAME AS REFERENCED_TABLE_NAME,
+	t3.COLUMN_NAME AS REFERENCED_COLUMN_NAME,
+	t3.OWNER AS REFERENCED_SCHEMA_NAME,
+	'NO ACTION' AS UPDATE_RULE,
+	t1.DELETE_RULE
+FROM
+	ALL_CONSTRAINTS t1
+	JOIN ALL_CONS_COLUMNS t2
+	ON t1.OWNER = t2.OWNER
+	AND t1.CONSTRAINT_NAME = t2.CONSTRAINT_NAME
+	JOIN ALL_CONS_COLUMNS t3
+	ON t1.R_OWNER = t3.OWNER
+	AND t1.R_CONSTRAINT_NAME = t3.CONSTRAINT_NAME
+	AND t2.POSITION = t3.POSITION
+WHERE
+	t1.CONSTRAINT_TYPE = 'R'
+	AND t1.OWNER = :1
+ORDER BY
+	t1.TABLE_NAME, t1.CONSTRAINT_NAME, t2.POSITION
+`
+
+	// Query to list the states of the foreign keys of a table.
+	fkStatesQuery = "SELECT CONSTRAINT_NAME, DEFERRABLE, DEFERRED, STATUS, VALIDATED FROM ALL_CONSTRAINTS WHERE CONSTRAINT_TYPE = 'R' AND OWNER = :1 AND TABLE_NAME = :2 ORDER BY CONSTRAINT_NAME"
+
+	// Query to list the states of the foreign keys of all tables in a schema.
+	schemaFKStatesQuery = "SELECT CONSTRAINT_NAME, DEFERRABLE, DEFERRED, STATUS, VALIDATED, TABLE_NAME FROM ALL_CONSTRAINTS WHERE CONSTRAINT_TYPE = 'R' AND OWNER = :1 ORDER BY TABLE_NAME, CONSTRAINT_NAME"
+
+	// Query to get the auditing options of a table. The columns are selected in the order of auditOptions.
+	auditOptsQuery = "SELECT ALT, AUD, COM, DEL, GRA, IND, INS, LOC, REN, SEL, UPD, FBK FROM DBA_OBJ_AUDIT_OPTS WHERE OWNER = :1 AND OBJECT_NAME = :2 AND OBJECT_TYPE = 'TABLE'"
+
+	// Query to get the auditing options of all tables in a schema.
+	schemaAuditOptsQuery = "SELECT ALT, AUD, COM, DEL, GRA, IND, INS, LOC, REN, SEL, UPD, FBK, OBJECT_NAME FROM DBA_OBJ_AUDIT_OPTS WHERE OWNER = :1 AND OBJECT_TYPE = 'TABLE'"
+
+	// Query to get the definitions of the collection types that are used by the table columns.
+	collTypesQuery = "SELECT c.COLUMN_NAME, t.OWNER, t.TYPE_NAME, t.COLL_TYPE, t.UPPER_BOUND, t.ELEM_TYPE_NAME, t.LENGTH, t.PRECISION, t.SCALE, t.NULLS_STORED FROM ALL_TAB_COLUMNS c JOIN ALL_COLL_TYPES t ON t.OWNER = c.DATA_TYPE_OWNER AND t.TYPE_NAME = c.DATA_TYPE WHERE c.OWNER = :1 AND c.TABLE_NAME = :2 ORDER BY c.COLUMN_ID"
+
+	// Query to get the collection types that are used by the columns of all tables in a schema.
+	schemaCollTypesQuery = "SELECT c.COLUMN_NAME, t.OWNER, t.TYPE_NAME, t.COLL_TYPE, t.UPPER_BOUND, t.ELEM_TYPE_NAME, t.LENGTH, t.PRECISION, t.SCALE, t.NULLS_STORED, c.TABLE_NAME FROM ALL_TAB_COLUMNS c JOIN ALL_COLL_TYPES t ON t.OWNER = c.DATA_TYPE_OWNER AND t.TYPE_NAME = c.DATA_TYPE WHERE c.OWNER = :1 ORDER BY c.TABLE_NAME, c.COLUMN_ID"
+
+	// Query to get the object types that are used by the table columns.
+	objectTypesQuery = "SELECT c.COLUMN_NAME, t.OWNER, t.TYPE_NAME FROM ALL_TAB_COLUMNS c JOIN ALL_TYPES t ON t.OWNER = c.DATA_TYPE_OWNER AND t.TYPE_NAME = c.DATA_TYPE WHERE c.OWNER = :1 AND c.TABLE_NAME = :2 AND t.TYPECODE = 'OBJECT' ORDER BY c.COLUMN_ID"
+
+	// Query to get the object types that are used by the columns of all tables in a schema.
+	schemaObjectTypesQuery = "SELECT c.COLUMN_NAME, t.OWNER, t.TYPE_NAME, c.TABLE_NAME FROM ALL_TAB_COLUMNS c JOIN ALL_TYPES t ON t.OWNER = c.DATA_TYPE_OWNER AND t.TYPE_NAME = c.DATA_TYPE WHERE c.OWNER = :1 AND t.TYPECODE = 'OBJECT' ORDER BY c.TABLE_NAME, c.COLUMN_ID"
+
+	// Query to look up an object type by its name. Unqualified names are resolved
+	// against the current schema of the session, as empty owners are NULL in Oracle.
+	objectTypeQuery = "SELECT OWNER, TYPE_NAME FROM ALL_TYPES WHERE OWNER = NVL(:1, SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA')) AND TYPE_NAME = :2 AND TYPECODE = 'OBJECT'"
+
+	// Query to get the storage options of the LOB columns of a table.
+	lobsQuery = "SELECT COLUMN_NAME, SECUREFILE, IN_ROW, PCTVERSION, RETENTION FROM ALL_LOBS WHERE OWNER = :1 AND TABLE_NAME = :2"
+
+	// Query to get the storage options of the LOB columns of all tables in a schema.
+	schemaLobsQuery = "SELECT COLUMN_NAME, SECUREFILE, IN_ROW, PCTVERSION, RETENTION, TABLE_NAME FROM ALL_LOBS WHERE OWNER = :1"
+
+	// Query to get the time of the last DDL statement on a table. The DATE
+	// value is formatted on the server side, as drivers scan it differently.
+	lastDDLQuery = "SELECT TO_CHAR(LAST_DDL_TIME, 'YYYY-MM-DD HH24:MI:SS') FROM ALL_OBJECTS WHERE OWNER = :1 AND OBJECT_NAME = :2 AND OBJECT_TYPE = 'TABLE'"
+
+	// Query to get the time of the last DDL statement on all tables in a schema.
+	schemaLastDDLQuery = "SELECT TO_CHAR(LAST_DDL_TIME, 'YYYY-MM-DD HH24:MI:SS'), OBJECT_NAME FROM ALL_OBJECTS WHERE OWNER = :1 AND OBJECT_TYPE = 'TABLE'"
+
+	// Query to list the ILM policies of a table, including the
+	// ones that are inherited from its tablespace or partitions.
+	ilmQuery = `
+SELECT
+	t1.POLICY_NAME,
+	t2.ACTION_TYPE,
+	t2.SCOPE,
+	t2.COMPRESSION_LEVEL,
+	t2.TIER_TABLESPACE,
+	t2.CONDITION_TYPE,
+	t2.CONDITION_DAYS,
+	t1.ENABLED
+FROM
+	ALL_ILMOBJECTS t1
+	JOIN ALL_ILMDATAMOVEMENTPOLICIES t2
+	ON t1.POLICY_NAME = t2.POLICY_NAME
+WHERE
+	t1.OBJECT_OWNER = :1
+	AND t1.OBJECT_NAME = :2
+	AND t1.OBJECT_TYPE = 'TABLE'
+ORDER BY
+	t1.POLICY_NAME
+`
+
+	// Query to list the ILM policies of all tables in a schema.
+	schemaILMQuery = `
+SELECT
+	t1.POLICY_NAME,
+	t2.ACTION_TYPE,
+	t2.SCOPE,
+	t2.COMPRESSION_LEVEL,
+	t2.TIER_TABLESPACE,
+	t2.CONDITION_TYPE,
+	t2.CONDITION_DAYS,
+	t1.ENABLED,
+	t1.OBJECT_NAME
+FROM
+	ALL_ILMOBJECTS t1
+	JOIN ALL_ILMDATAMOVEMENTPOLICIES t2
+	ON t1.POLICY_NAME = t2.POLICY_NAME
+WHERE
+	t1.OBJECT_OWNER = :1
+	AND t1.OBJECT_TYPE = 'TABLE'
+ORDER BY
+	t1.OBJECT_NAME, t1.POLICY_NAME
+`
+
+	// Query to get the DDL of the table constraints. Foreign keys are
+	// exported by DBMS_METADATA using the REF_CONSTRAINT object type.
+	constraintsDDLQuery = `
+SELECT
+	CONSTRAINT_NAME,
+	CONSTRAINT_TYPE,
+	DBMS_METADATA.GET_DDL(CASE CONSTRAINT_TYPE WHEN 'R' THEN 'REF_CONSTRAINT' ELSE 'CONSTRAINT' END, CONSTRAINT_NAME, OWNER)
+FROM
+	ALL_CONSTRAINTS
+WHERE
+	OWNER = :1
+	AND TABLE_NAME = :2
+	AND CONSTRAINT_TYPE IN ('P', 'U', 'R', 'C')
+ORDER BY
+	CONSTRAINT_NAME
+`
+
+	// Query to get the DDL of the constraints of all tables in a schema.
+	schemaConstraintsDDLQuery = `
+SELECT
+	CONSTRAINT_NAME,
+	CONSTRAINT_TYPE,
+	DBMS_METADATA.GET_DDL(CASE CONSTRAINT_TYPE WHEN 'R' THEN 'REF_CONSTRAINT' ELSE 'CONSTRAINT' END, CONSTRAINT_NAME, OWNER),
+	TABLE_NAME
+FROM
+	ALL_CONSTRAINTS
+WHERE
+	OWNER = :1
+	AND CONSTRAINT_TYPE IN ('P', 'U', 'R', 'C')
+ORDER BY
+	TABLE_NAME, CONSTRAINT_NAME
+`
+
+	// Query to list table check constraints.
+	checksQuery = `
+SELECT
+	t1.CONSTRAINT_NAME,
+	t1.SEARCH_CONDITION,
+	t2.COLUMN_NAME,
+	t1.DEFERRABLE,
+	t1.DEFERRED,
+	t1.STATUS,
+	t1.VALIDATED
+FROM
+	ALL_CONSTRAINTS t1
+	JOIN ALL_CONS_COLUMNS t2
+	ON t1.OWNER = t2.OWNER
+	AND t1.CONSTRAINT_NAME = t2.CONSTRAINT_NAME
+WHERE
+	t1.CONSTRAINT_TYPE = 'C'
+	AND t1.OWNER = :1
+	AND t1.TABLE_NAME = :2
+ORDER BY
+	t1.CONSTRAINT_NAME, t2.COLUMN_NAME
+`
+	// Query to list the check constraints of all tables in a schema. The
+	// column order matches the query above, followed by the table name.
+	schemaChecksQuery = `
+SELECT
+	t1.CONSTRAINT_NAME,
+	t1.SEARCH_CONDITION,
+	t2.COLUMN_NAME,
+	t1.DEFERRABLE,
+	t1.DEFERRED,
+	t1.STATUS,
+	t1.VALIDATED,
+	t1.TABLE_NAME
+FROM
+	ALL_CONSTRAINTS t1
+	JOIN ALL_CONS_COLUMNS t2
+	ON t1.OWNER = t2.OWNER
+	AND t1.CONSTRAINT_NAME = t2.CONSTRAINT_NAME
+WHERE
+	t1.CONSTRAINT_TYPE = 'C'
+	AND t1.OWNER = :1
+ORDER BY
+	t1.TABLE_NAME, t1.CONSTRAINT_NAME, t2.COLUMN_NAME
+`
+)
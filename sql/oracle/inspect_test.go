@@ -0,0 +1,2264 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"ariga.io/atlas/sql/internal/sqltest"
+	"ariga.io/atlas/sql/schema"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/semver"
+)
+
+func TestDriver_InspectTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		opts    *schema.InspectTableOptions
+		before  func(mock)
+		expect  func(*require.Assertions, *schema.Table, error)
+	}{
+		{
+			name: "table does not exist",
+			before: func(m mock) {
+				m.tableExists("ATLAS", "USERS", false)
+			},
+			expect: func(require *require.Assertions, t *schema.Table, err error) {
+				require.Nil(t)
+				require.Error(err)
+				require.True(schema.IsNotExistError(err), "expect not exists error")
+			},
+		},
+		{
+			name: "table does not exist in schema",
+			opts: &schema.InspectTableOptions{
+				Schema: "ATLAS",
+			},
+			before: func(m mock) {
+				m.tableExistsInSchema("ATLAS", "USERS", false)
+			},
+			expect: func(require *require.Assertions, t *schema.Table, err error) {
+				require.Nil(t)
+				require.Error(err)
+				require.True(schema.IsNotExistError(err), "expect not exists error")
+			},
+		},
+		{
+			name: "table parallel",
+			opts: &schema.InspectTableOptions{
+				Schema: "ATLAS",
+			},
+			before: func(m mock) {
+				m.ExpectQuery(sqltest.Escape(tableSchemaQuery)).
+					WithArgs("USERS", "ATLAS").
+					WillReturnRows(sqlmock.NewRows([]string{"OWNER", "COMMENTS", "DEGREE", "INSTANCES", "DEPENDENCIES", "TEMPORARY", "DURATION", "TYPE_NAME", "DEFAULT_DIRECTORY_NAME", "ACCESS_PARAMETERS", "TABLESPACE_NAME", "IOT_TYPE", "PCT_THRESHOLD", "OVERFLOW_NAME", "MAPPING_NAME"}).AddRow("ATLAS", "users table", "         4", "   DEFAULT", "ENABLED", "N", nil, nil, nil, nil, "USERS", nil, nil, nil, nil))
+				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+					WithArgs("ATLAS", "USERS").
+					WillReturnRows(sqlmock.NewRows(nil))
+				m.noIndexes()
+				m.noFKs()
+				m.noChecks()
+			},
+			expect: func(require *require.Assertions, t *schema.Table, err error) {
+				require.NoError(err)
+				require.Equal([]schema.Attr{
+					&schema.Comment{Text: "users table"},
+					&Parallel{Degree: 4, Instances: ParallelDefault},
+					&RowDependencies{},
+					&Tablespace{Name: "USERS"},
+				}, t.Attrs)
+			},
+		},
+		{
+			name: "global temporary table (SYS$TRANSACTION)",
+			opts: &schema.InspectTableOptions{
+				Schema: "ATLAS",
+			},
+			before: func(m mock) {
+				m.ExpectQuery(sqltest.Escape(tableSchemaQuery)).
+					WithArgs("USERS", "ATLAS").
+					WillReturnRows(sqlmock.NewRows([]string{"OWNER", "COMMENTS", "DEGREE", "INSTANCES", "DEPENDENCIES", "TEMPORARY", "DURATION", "TYPE_NAME", "DEFAULT_DIRECTORY_NAME", "ACCESS_PARAMETERS", "TABLESPACE_NAME", "IOT_TYPE", "PCT_THRESHOLD", "OVERFLOW_NAME", "MAPPING_NAME"}).AddRow("ATLAS", nil, "         1", "         1", "DISABLED", "Y", "SYS$TRANSACTION", nil, nil, nil, nil, nil, nil, nil, nil))
+				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+					WithArgs("ATLAS", "USERS").
+					WillReturnRows(sqlmock.NewRows(nil))
+				m.noIndexes()
+				m.noFKs()
+				m.noChecks()
+			},
+			expect: func(require *require.Assertions, t *schema.Table, err error) {
+				require.NoError(err)
+				require.Equal([]schema.Attr{&Temporary{OnCommit: OnCommitDelete}}, t.Attrs)
+			},
+		},
+		{
+			name: "global temporary table (SYS$SESSION)",
+			opts: &schema.InspectTableOptions{
+				Schema: "ATLAS",
+			},
+			before: func(m mock) {
+				m.ExpectQuery(sqltest.Escape(tableSchemaQuery)).
+					WithArgs("USERS", "ATLAS").
+					WillReturnRows(sqlmock.NewRows([]string{"OWNER", "COMMENTS", "DEGREE", "INSTANCES", "DEPENDENCIES", "TEMPORARY", "DURATION", "TYPE_NAME", "DEFAULT_DIRECTORY_NAME", "ACCESS_PARAMETERS", "TABLESPACE_NAME", "IOT_TYPE", "PCT_THRESHOLD", "OVERFLOW_NAME", "MAPPING_NAME"}).AddRow("ATLAS", nil, "         1", "         1", "DISABLED", "Y", "SYS$SESSION", nil, nil, nil, nil, nil, nil, nil, nil))
+				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+					WithArgs("ATLAS", "USERS").
+					WillReturnRows(sqlmock.NewRows(nil))
+				m.noIndexes()
+				m.noFKs()
+				m.noChecks()
+			},
+			expect: func(require *require.Assertions, t *schema.Table, err error) {
+				require.NoError(err)
+				require.Equal([]schema.Attr{&Temporary{OnCommit: OnCommitPreserve}}, t.Attrs)
+			},
+		},
+		{
+			name: "index-organized table",
+			opts: &schema.InspectTableOptions{
+				Schema: "ATLAS",
+			},
+			before: func(m mock) {
+				m.ExpectQuery(sqltest.Escape(tableSchemaQuery)).
+					WithArgs("USERS", "ATLAS").
+					WillReturnRows(sqlmock.NewRows([]string{"OWNER", "COMMENTS", "DEGREE", "INSTANCES", "DEPENDENCIES", "TEMPORARY", "DURATION", "TYPE_NAME", "DEFAULT_DIRECTORY_NAME", "ACCESS_PARAMETERS", "TABLESPACE_NAME", "IOT_TYPE", "PCT_THRESHOLD", "OVERFLOW_NAME", "MAPPING_NAME"}).AddRow("ATLAS", nil, "         1", "         1", "DISABLED", "N", nil, nil, nil, nil, nil, "IOT", "20", "SYS_IOT_OVER_73418", nil))
+				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+					WithArgs("ATLAS", "USERS").
+					WillReturnRows(sqltest.Rows(`
+ COLUMN_NAME | DATA_TYPE | NULLABLE | DATA_DEFAULT | DATA_LENGTH | CHAR_LENGTH | DATA_PRECISION | DATA_SCALE | CHARACTER_SET_NAME | IDENTITY_COLUMN | GENERATION_TYPE | IDENTITY_OPTIONS | COMMENTS | VIRTUAL_COLUMN | HIDDEN_COLUMN | USER_GENERATED | CHAR_USED | COLLATION | DEFAULT_ON_NULL | SEQUENCE_NAME
+-------------+-----------+----------+--------------+-------------+-------------+----------------+------------+--------------------+-----------------+-----------------+------------------+----------+----------------
+ ID          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |
+`))
+				m.ExpectQuery(sqltest.Escape(indexesQuery)).
+					WithArgs("ATLAS", "USERS").
+					WillReturnRows(sqltest.Rows(`
+ INDEX_NAME | INDEX_TYPE | UNIQUENESS | CONSTRAINT_TYPE | COLUMN_NAME | DESCEND | COLUMN_EXPRESSION | TABLESPACE_NAME | LOCALITY
+------------+------------+------------+-----------------+-------------+---------+-------------------+-----------------+----------
+ USERS_PK   | IOT - TOP  | UNIQUE     | P               | ID          | ASC     |                   | USERS
+`))
+				m.noFKs()
+				m.noChecks()
+			},
+			expect: func(require *require.Assertions, t *schema.Table, err error) {
+				require.NoError(err)
+				require.Equal([]schema.Attr{&Organization{T: OrganizationIndex, PctThreshold: 20, Overflow: true}}, t.Attrs)
+				require.NotNil(t.PrimaryKey)
+				require.Equal("USERS_PK", t.PrimaryKey.Name)
+				require.Equal([]schema.Attr{&IndexType{T: "IOT - TOP"}, &ConType{T: "P"}, &Tablespace{Name: "USERS"}}, t.PrimaryKey.Attrs)
+			},
+		},
+		{
+			name: "column types",
+			before: func(m mock) {
+				m.tableExists("ATLAS", "USERS", true)
+				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+					WithArgs("ATLAS", "USERS").
+					WillReturnRows(sqltest.Rows(`
+ COLUMN_NAME | DATA_TYPE                      | NULLABLE | DATA_DEFAULT                 | DATA_LENGTH | CHAR_LENGTH | DATA_PRECISION | DATA_SCALE | CHARACTER_SET_NAME | IDENTITY_COLUMN | GENERATION_TYPE | IDENTITY_OPTIONS                                  | COMMENTS | VIRTUAL_COLUMN | HIDDEN_COLUMN | USER_GENERATED | CHAR_USED | COLLATION | DEFAULT_ON_NULL | SEQUENCE_NAME
+-------------+--------------------------------+----------+------------------------------+-------------+-------------+----------------+------------+--------------------+-----------------+-----------------+---------------------------------------------------+----------+----------------
+ ID          | NUMBER                         | N        | "ATLAS"."ISEQ$$_73001".nextval |          22 |           0 |                |            |                    | YES             | ALWAYS          | START WITH: 100, INCREMENT BY: 2, MAX_VALUE: 9999 |
+ NAME        | VARCHAR2                       | Y        | 'unknown'                    |          40 |          10 |                |            | CHAR_CS            | NO              |                 |                                                   | user name
+ C1          | NVARCHAR2                      | N        |                              |          40 |          20 |                |            | NCHAR_CS           | NO              |                 |                                                   |
+ C2          | CHAR                           | N        |                              |           1 |           1 |                |            | CHAR_CS            | NO              |                 |                                                   |
+ C3          | NUMBER                         | N        | 0                            |          22 |           0 |             10 |          2 |                    | NO              |                 |                                                   |
+ C4          | NUMBER                         | N        |                              |          22 |           0 |                |          2 |                    | NO              |                 |                                                   |
+ C5          | FLOAT                          | N        |                              |          22 |           0 |            126 |            |                    | NO              |                 |                                                   |
+ C6          | BINARY_DOUBLE                  | N        |                              |           8 |           0 |                |            |                    | NO              |                 |                                                   |
+ C7          | DATE                           | N        | SYSDATE                      |           7 |           0 |                |            |                    | NO              |                 |                                                   |
+ C8          | TIMESTAMP(6) WITH TIME ZONE    | N        |                              |          13 |           0 |                |          6 |                    | NO              |                 |                                                   |
+ C9          | RAW                            | N        |                              |          16 |           0 |                |            |                    | NO              |                 |                                                   |
+ C10         | CLOB                           | Y        |                              |        4000 |           0 |                |            | CHAR_CS            | NO              |                 |                                                   |
+ C11         | NCLOB                          | Y        |                              |        4000 |           0 |                |            | NCHAR_CS           | NO              |                 |                                                   |
+ C12         | UROWID                         | Y        |                              |        4000 |           0 |                |            |                    | NO              |                 |                                                   |
+`))
+				m.noIndexes()
+				m.noFKs()
+				m.noChecks()
+				m.ExpectQuery(sqltest.Escape(lobsQuery)).
+					WithArgs("ATLAS", "USERS").
+					WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME", "SECUREFILE", "IN_ROW", "PCTVERSION", "RETENTION"}))
+			},
+			expect: func(require *require.Assertions, t *schema.Table, err error) {
+				require.NoError(err)
+				require.Equal("USERS", t.Name)
+				require.Equal("ATLAS", t.Schema.Name)
+				require.EqualValues([]*schema.Column{
+					{Name: "ID", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 0, Scale: 0}}, Attrs: []schema.Attr{&Identity{Generation: "ALWAYS", Sequence: &Sequence{Start: 100, Increment: 2, Max: 9999}}}},
+					{Name: "NAME", Type: &schema.ColumnType{Raw: "VARCHAR2", Null: true, Type: &schema.StringType{T: "varchar2", Size: 10}}, Default: &schema.Literal{V: "'unknown'"}, Attrs: []schema.Attr{&schema.Comment{Text: "user name"}, &schema.Charset{V: "CHAR_CS"}}},
+					{Name: "C1", Type: &schema.ColumnType{Raw: "NVARCHAR2", Type: &schema.StringType{T: "nvarchar2", Size: 20}}, Attrs: []schema.Attr{&schema.Charset{V: "NCHAR_CS"}}},
+					{Name: "C2", Type: &schema.ColumnType{Raw: "CHAR", Type: &schema.StringType{T: "char", Size: 1}}, Attrs: []schema.Attr{&schema.Charset{V: "CHAR_CS"}}},
+					{Name: "C3", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 10, Scale: 2}}, Default: &schema.Literal{V: "0"}},
+					{Name: "C4", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 38, Scale: 2}}},
+					{Name: "C5", Type: &schema.ColumnType{Raw: "FLOAT", Type: &schema.FloatType{T: "float", Precision: 126}}},
+					{Name: "C6", Type: &schema.ColumnType{Raw: "BINARY_DOUBLE", Type: &schema.FloatType{T: "binary_double"}}},
+					{Name: "C7", Type: &schema.ColumnType{Raw: "DATE", Type: &schema.TimeType{T: "date"}}, Default: &schema.RawExpr{X: "SYSDATE"}},
+					{Name: "C8", Type: &schema.ColumnType{Raw: "TIMESTAMP(6) WITH TIME ZONE", Type: &schema.TimeType{T: "timestamp with time zone", Precision: intp(6)}}},
+					{Name: "C9", Type: &schema.ColumnType{Raw: "RAW", Type: &schema.BinaryType{T: "raw", Size: 16}}},
+					{Name: "C10", Type: &schema.ColumnType{Raw: "CLOB", Null: true, Type: &schema.StringType{T: "clob"}}, Attrs: []schema.Attr{&schema.Charset{V: "CHAR_CS"}}},
+					{Name: "C11", Type: &schema.ColumnType{Raw: "NCLOB", Null: true, Type: &schema.StringType{T: "nclob"}}, Attrs: []schema.Attr{&schema.Charset{V: "NCHAR_CS"}}},
+					{Name: "C12", Type: &schema.ColumnType{Raw: "UROWID", Null: true, Type: &RowIDType{T: "urowid", Size: 4000}}},
+				}, t.Columns)
+			},
+		},
+		{
+			name:    "columns without identity",
+			version: "11.2.0.4.0",
+			opts: &schema.InspectTableOptions{
+				Schema: "ATLAS",
+			},
+			before: func(m mock) {
+				m.tableExistsInSchema("ATLAS", "USERS", true)
+				m.ExpectQuery(sqltest.Escape(columnsQueryNoIdentity)).
+					WithArgs("ATLAS", "USERS").
+					WillReturnRows(sqltest.Rows(`
+ COLUMN_NAME | DATA_TYPE | NULLABLE | DATA_DEFAULT | DATA_LENGTH | CHAR_LENGTH | DATA_PRECISION | DATA_SCALE | CHARACTER_SET_NAME | IDENTITY_COLUMN | GENERATION_TYPE | IDENTITY_OPTIONS | COMMENTS | VIRTUAL_COLUMN | HIDDEN_COLUMN | USER_GENERATED | CHAR_USED | COLLATION | DEFAULT_ON_NULL | SEQUENCE_NAME
+-------------+-----------+----------+--------------+-------------+-------------+----------------+------------+--------------------+-----------------+-----------------+------------------+----------+----------------
+ ID          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |
+ C1          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |
+`))
+				m.noIndexes()
+				m.noFKs()
+				m.noChecks()
+				// Identity columns are emulated by triggers.
+				m.ExpectQuery(sqltest.Escape(identityTriggersQuery)).
+					WithArgs("ATLAS", "USERS").
+					WillReturnRows(sqltest.Rows(`
+ TRIGGER_NAME | WHEN_CLAUSE         | INCREMENT_BY | LAST_NUMBER
+--------------+---------------------+--------------+-------------
+ USERS_C1_TRG | new.C1 IS NULL      | 1            | 21
+ USERS_ID_TRG |                     | 2            | 41
+ USERS_X_TRG  |                     | 1            | 1
+`))
+			},
+			expect: func(require *require.Assertions, t *schema.Table, err error) {
+				require.NoError(err)
+				require.EqualValues([]*schema.Column{
+					{Name: "ID", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 10}}, Attrs: []schema.Attr{&Identity{Generation: "ALWAYS", Sequence: &Sequence{Increment: 2, Last: 41}, SequenceName: "USERS_ID_SEQ"}}},
+					{Name: "C1", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 10}}, Attrs: []schema.Attr{&Identity{Generation: "BY DEFAULT", Sequence: &Sequence{Increment: 1, Last: 21}, SequenceName: "USERS_C1_SEQ"}}},
+				}, t.Columns)
+			},
+		},
+		{
+			name: "table indexes",
+			before: func(m mock) {
+				m.tableExists("ATLAS", "USERS", true)
+				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+					WithArgs("ATLAS", "USERS").
+					WillReturnRows(sqltest.Rows(`
+ COLUMN_NAME | DATA_TYPE | NULLABLE | DATA_DEFAULT | DATA_LENGTH | CHAR_LENGTH | DATA_PRECISION | DATA_SCALE | CHARACTER_SET_NAME | IDENTITY_COLUMN | GENERATION_TYPE | IDENTITY_OPTIONS | COMMENTS | VIRTUAL_COLUMN | HIDDEN_COLUMN | USER_GENERATED | CHAR_USED | COLLATION | DEFAULT_ON_NULL | SEQUENCE_NAME
+-------------+-----------+----------+--------------+-------------+-------------+----------------+------------+--------------------+-----------------+-----------------+------------------+----------+----------------
+ ID          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |
+ C1          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |
+ C2          | VARCHAR2  | N        |              |          10 |          10 |                |            | CHAR_CS            | NO              |                 |                  |
+`))
+				m.ExpectQuery(sqltest.Escape(indexesQuery)).
+					WithArgs("ATLAS", "USERS").
+					WillReturnRows(sqltest.Rows(`
+ INDEX_NAME   | INDEX_TYPE            | UNIQUENESS | CONSTRAINT_TYPE | COLUMN_NAME  | DESCEND | COLUMN_EXPRESSION | TABLESPACE_NAME | LOCALITY
+--------------+-----------------------+------------+-----------------+--------------+---------+-------------------+-----------------+----------
+ IDX_C1_C2    | FUNCTION-BASED NORMAL | NONUNIQUE  |                 | C1           | ASC     |                   |
+ IDX_C1_C2    | FUNCTION-BASED NORMAL | NONUNIQUE  |                 | SYS_NC00004$ | DESC    | "C2"              |
+ IDX_UPPER    | FUNCTION-BASED NORMAL | NONUNIQUE  |                 | SYS_NC00005$ | ASC     | UPPER("C2")       |
+ USERS_C2_UK  | NORMAL                | UNIQUE     | U               | C2           | ASC     |                   | INDX
+ USERS_PK     | NORMAL                | UNIQUE     | P               | ID           | ASC     |                   |
+`))
+				m.noFKs()
+				m.noChecks()
+			},
+			expect: func(require *require.Assertions, t *schema.Table, err error) {
+				require.NoError(err)
+				columns := []*schema.Column{
+					{Name: "ID", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 10}}},
+					{Name: "C1", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 10}}},
+					{Name: "C2", Type: &schema.ColumnType{Raw: "VARCHAR2", Type: &schema.StringType{T: "varchar2", Size: 10}}, Attrs: []schema.Attr{&schema.Charset{V: "CHAR_CS"}}},
+				}
+				indexes := []*schema.Index{
+					{Name: "IDX_C1_C2", Table: t, Attrs: []schema.Attr{&IndexType{T: "FUNCTION-BASED NORMAL"}}},
+					{Name: "IDX_UPPER", Table: t, Attrs: []schema.Attr{&IndexType{T: "FUNCTION-BASED NORMAL"}}},
+					{Name: "USERS_C2_UK", Unique: true, Table: t, Attrs: []schema.Attr{&IndexType{T: "NORMAL"}, &ConType{T: "U"}, &Tablespace{Name: "INDX"}}},
+				}
+				pk := &schema.Index{Name: "USERS_PK", Unique: true, Table: t, Attrs: []schema.Attr{&IndexType{T: "NORMAL"}, &ConType{T: "P"}}}
+				columns[0].Indexes = []*schema.Index{pk}
+				columns[1].Indexes = []*schema.Index{indexes[0]}
+				columns[2].Indexes = []*schema.Index{indexes[0], indexes[2]}
+				indexes[0].Parts = []*schema.IndexPart{
+					{SeqNo: 1, C: columns[1], Attrs: []schema.Attr{&IndexColumnProperty{}}},
+					{SeqNo: 2, C: columns[2], Attrs: []schema.Attr{&IndexColumnProperty{Desc: true}}},
+				}
+				indexes[1].Parts = []*schema.IndexPart{
+					{SeqNo: 1, X: &schema.RawExpr{X: `UPPER("C2")`}, Attrs: []schema.Attr{&IndexColumnProperty{}}},
+				}
+				indexes[2].Parts = []*schema.IndexPart{
+					{SeqNo: 1, C: columns[2], Attrs: []schema.Attr{&IndexColumnProperty{}}},
+				}
+				pk.Parts = []*schema.IndexPart{
+					{SeqNo: 1, C: columns[0], Attrs: []schema.Attr{&IndexColumnProperty{}}},
+				}
+				require.EqualValues(columns, t.Columns)
+				require.EqualValues(indexes, t.Indexes)
+				require.EqualValues(pk, t.PrimaryKey)
+			},
+		},
+		{
+			name: "fks",
+			before: func(m mock) {
+				m.tableExists("ATLAS", "USERS", true)
+				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+					WithArgs("ATLAS", "USERS").
+					WillReturnRows(sqltest.Rows(`
+ COLUMN_NAME | DATA_TYPE | NULLABLE | DATA_DEFAULT | DATA_LENGTH | CHAR_LENGTH | DATA_PRECISION | DATA_SCALE | CHARACTER_SET_NAME | IDENTITY_COLUMN | GENERATION_TYPE | IDENTITY_OPTIONS | COMMENTS | VIRTUAL_COLUMN | HIDDEN_COLUMN | USER_GENERATED | CHAR_USED | COLLATION | DEFAULT_ON_NULL | SEQUENCE_NAME
+-------------+-----------+----------+--------------+-------------+-------------+----------------+------------+--------------------+-----------------+-----------------+------------------+----------+----------------
+ ID          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |
+ OID         | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |
+ UID         | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |
+`))
+				m.noIndexes()
+				m.ExpectQuery(sqltest.Escape(fksQuery)).
+					WithArgs("ATLAS", "USERS").
+					WillReturnRows(sqltest.Rows(`
+ CONSTRAINT_NAME | TABLE_NAME | COLUMN_NAME | OWNER | REFERENCED_TABLE_NAME | REFERENCED_COLUMN_NAME | REFERENCED_SCHEMA_NAME | UPDATE_RULE | DELETE_RULE
+-----------------+------------+-------------+-------+-----------------------+------------------------+------------------------+-------------+-------------
+ MULTI_COLUMN    | USERS      | ID          | ATLAS | T1                    | GID                    | ATLAS                  | NO ACTION   | CASCADE
+ MULTI_COLUMN    | USERS      | OID         | ATLAS | T1                    | XOID                   | ATLAS                  | NO ACTION   | CASCADE
+ SELF_REFERENCE  | USERS      | UID         | ATLAS | USERS                 | ID                     | ATLAS                  | NO ACTION   | SET NULL
+`))
+				m.ExpectQuery(sqltest.Escape(fkStatesQuery)).
+					WithArgs("ATLAS", "USERS").
+					WillReturnRows(sqltest.Rows(`
+ CONSTRAINT_NAME | DEFERRABLE     | DEFERRED  | STATUS  | VALIDATED
+-----------------+----------------+-----------+---------+-----------
+ MULTI_COLUMN    | DEFERRABLE     | DEFERRED  | ENABLED | VALIDATED
+ SELF_REFERENCE  | NOT DEFERRABLE | IMMEDIATE | ENABLED | VALIDATED
+`))
+				m.noChecks()
+			},
+			expect: func(require *require.Assertions, t *schema.Table, err error) {
+				require.NoError(err)
+				require.Equal("ATLAS", t.Schema.Name)
+				fks := []*schema.ForeignKey{
+					{Symbol: "MULTI_COLUMN", Table: t, OnUpdate: schema.NoAction, OnDelete: schema.Cascade, RefTable: &schema.Table{Name: "T1", Schema: t.Schema}, RefColumns: []*schema.Column{{Name: "GID"}, {Name: "XOID"}}},
+					{Symbol: "SELF_REFERENCE", Table: t, OnUpdate: schema.NoAction, OnDelete: schema.SetNull, RefTable: t},
+				}
+				columns := []*schema.Column{
+					{Name: "ID", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 10}}, ForeignKeys: fks[0:1]},
+					{Name: "OID", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 10}}, ForeignKeys: fks[0:1]},
+					{Name: "UID", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 10}}, ForeignKeys: fks[1:2]},
+				}
+				fks[0].Columns = columns[:2]
+				fks[1].Columns = columns[2:]
+				fks[1].RefColumns = columns[:1]
+				require.EqualValues(columns, t.Columns)
+				require.EqualValues(fks, t.ForeignKeys)
+				require.EqualValues([]schema.Attr{&ConstraintState{Name: "MULTI_COLUMN", Deferrable: true, InitiallyDeferred: true}}, t.Attrs)
+			},
+		},
+		{
+			name: "checks",
+			before: func(m mock) {
+				m.tableExists("ATLAS", "USERS", true)
+				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+					WithArgs("ATLAS", "USERS").
+					WillReturnRows(sqltest.Rows(`
+ COLUMN_NAME | DATA_TYPE | NULLABLE | DATA_DEFAULT | DATA_LENGTH | CHAR_LENGTH | DATA_PRECISION | DATA_SCALE | CHARACTER_SET_NAME | IDENTITY_COLUMN | GENERATION_TYPE | IDENTITY_OPTIONS | COMMENTS | VIRTUAL_COLUMN | HIDDEN_COLUMN | USER_GENERATED | CHAR_USED | COLLATION | DEFAULT_ON_NULL | SEQUENCE_NAME
+-------------+-----------+----------+--------------+-------------+-------------+----------------+------------+--------------------+-----------------+-----------------+------------------+----------+----------------
+ C1          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |
+ C2          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |
+`))
+				m.noIndexes()
+				m.noFKs()
+				m.ExpectQuery(sqltest.Escape(checksQuery)).
+					WithArgs("ATLAS", "USERS").
+					WillReturnRows(sqltest.Rows(`
+ CONSTRAINT_NAME | SEARCH_CONDITION      | COLUMN_NAME | DEFERRABLE     | DEFERRED  | STATUS   | VALIDATED
+-----------------+-----------------------+-------------+----------------+-----------+----------+---------------
+ BOTH_POSITIVE   | "C1" > 0 AND "C2" > 0 | C1          | NOT DEFERRABLE | IMMEDIATE | ENABLED  | VALIDATED
+ BOTH_POSITIVE   | "C1" > 0 AND "C2" > 0 | C2          | NOT DEFERRABLE | IMMEDIATE | ENABLED  | VALIDATED
+ SYS_C008012     | "C1" IS NOT NULL      | C1          | NOT DEFERRABLE | IMMEDIATE | ENABLED  | NOT VALIDATED
+ SYS_C008013     | "C2" IS NOT NULL      | C2          | NOT DEFERRABLE | IMMEDIATE | ENABLED  | VALIDATED
+ C2_LIMIT        | "C2" < 1000           | C2          | NOT DEFERRABLE | IMMEDIATE | DISABLED | NOT VALIDATED
+`))
+			},
+			expect: func(require *require.Assertions, t *schema.Table, err error) {
+				require.NoError(err)
+				// The state of NOVALIDATE NOT NULL constraints is kept on their columns.
+				require.Equal(t.Columns[0].Attrs, []schema.Attr{&ConstraintState{Name: "SYS_C008012", NoValidate: true}})
+				require.Empty(t.Columns[1].Attrs)
+				require.EqualValues([]schema.Attr{
+					&schema.Check{Name: "BOTH_POSITIVE", Expr: `"C1" > 0 AND "C2" > 0`, Attrs: []schema.Attr{&CheckColumns{Columns: []string{"C1", "C2"}}}},
+					&schema.Check{Name: "C2_LIMIT", Expr: `"C2" < 1000`, Attrs: []schema.Attr{&CheckColumns{Columns: []string{"C2"}}, &ConstraintState{Name: "C2_LIMIT", Disabled: true, NoValidate: true}}},
+				}, t.Attrs)
+			},
+		},
+		{
+			name: "partitioned table local primary key",
+			before: func(m mock) {
+				m.tableExists("ATLAS", "USERS", true)
+				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+					WithArgs("ATLAS", "USERS").
+					WillReturnRows(sqltest.Rows(`
+ COLUMN_NAME | DATA_TYPE | NULLABLE | DATA_DEFAULT | DATA_LENGTH | CHAR_LENGTH | DATA_PRECISION | DATA_SCALE | CHARACTER_SET_NAME | IDENTITY_COLUMN | GENERATION_TYPE | IDENTITY_OPTIONS | COMMENTS | VIRTUAL_COLUMN | HIDDEN_COLUMN | USER_GENERATED | CHAR_USED | COLLATION | DEFAULT_ON_NULL | SEQUENCE_NAME
+-------------+-----------+----------+--------------+-------------+-------------+----------------+------------+--------------------+-----------------+-----------------+------------------+----------+----------------+---------------+----------------+-----------+------------+----------------+--------------
+ ID          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |          | NO             | NO            | YES            |           |
+ REGION      | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |          | NO             | NO            | YES            |           |
+`))
+				m.ExpectQuery(sqltest.Escape(indexesQuery)).
+					WithArgs("ATLAS", "USERS").
+					WillReturnRows(sqltest.Rows(`
+ INDEX_NAME | INDEX_TYPE | UNIQUENESS | CONSTRAINT_TYPE | COLUMN_NAME | DESCEND | COLUMN_EXPRESSION | TABLESPACE_NAME | LOCALITY
+------------+------------+------------+-----------------+-------------+---------+-------------------+-----------------+----------
+ USERS_PK   | NORMAL     | UNIQUE     | P               | ID          | ASC     |                   |                 | LOCAL
+ USERS_PK   | NORMAL     | UNIQUE     | P               | REGION      | ASC     |                   |                 | LOCAL
+ ID_IDX     | NORMAL     | NONUNIQUE  |                 | ID          | ASC     |                   |                 | GLOBAL
+`))
+				m.noFKs()
+				m.noChecks()
+			},
+			expect: func(require *require.Assertions, t *schema.Table, err error) {
+				require.NoError(err)
+				require.Equal([]schema.Attr{&IndexType{T: "NORMAL"}, &ConType{T: "P"}, &LocalIndex{}}, t.PrimaryKey.Attrs)
+				require.Len(t.PrimaryKey.Parts, 2)
+				// Global partitioned indexes are not marked as local.
+				require.Len(t.Indexes, 1)
+				require.Equal([]schema.Attr{&IndexType{T: "NORMAL"}}, t.Indexes[0].Attrs)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, m, err := sqlmock.New()
+			require.NoError(t, err)
+			version := tt.version
+			if version == "" {
+				version = "19.0.0.0.0"
+			}
+			mk := mock{m}
+			mk.version(version)
+			drv, err := Open(db)
+			require.NoError(t, err)
+			tt.before(mk)
+			table, err := drv.InspectTable(context.Background(), "USERS", tt.opts)
+			tt.expect(require.New(t), table, err)
+			require.NoError(t, m.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDriver_InspectVirtualColumns(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.tableExistsInSchema("ATLAS", "ORDERS", true)
+	m.ExpectQuery(sqltest.Escape(columnsQuery)).
+		WithArgs("ATLAS", "ORDERS").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME", "DATA_TYPE", "NULLABLE", "DATA_DEFAULT", "DATA_LENGTH", "CHAR_LENGTH", "DATA_PRECISION", "DATA_SCALE", "CHARACTER_SET_NAME", "IDENTITY_COLUMN", "GENERATION_TYPE", "IDENTITY_OPTIONS", "COMMENTS", "VIRTUAL_COLUMN", "HIDDEN_COLUMN", "USER_GENERATED", "CHAR_USED", "COLLATION", "DEFAULT_ON_NULL", "SEQUENCE_NAME"}).
+			AddRow("QTY", "NUMBER", "N", "1 ", 22, 0, 10, 0, nil, "NO", nil, nil, nil, "NO", "NO", "YES", nil, nil, nil, nil).
+			AddRow("PRICE", "NUMBER", "N", nil, 22, 0, 10, 2, nil, "NO", nil, nil, nil, "NO", "NO", "YES", nil, nil, nil, nil).
+			AddRow("TOTAL", "NUMBER", "Y", `"QTY"*"PRICE"`, 22, 0, nil, nil, nil, "NO", nil, nil, nil, "YES", "NO", "YES", nil, nil, nil, nil))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	tt, err := drv.InspectTable(context.Background(), "ORDERS", &schema.InspectTableOptions{Schema: "ATLAS"})
+	require.NoError(t, err)
+	require.EqualValues(t, []*schema.Column{
+		{Name: "QTY", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 10}}, Default: &schema.Literal{V: "1"}},
+		{Name: "PRICE", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 10, Scale: 2}}},
+		{Name: "TOTAL", Type: &schema.ColumnType{Raw: "NUMBER", Null: true, Type: &schema.DecimalType{T: "number"}}, Attrs: []schema.Attr{&Virtual{Expr: `"QTY"*"PRICE"`}}},
+	}, tt.Columns)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestDriver_InspectHiddenColumns(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.tableExistsInSchema("ATLAS", "USERS", true)
+	m.ExpectQuery(sqltest.Escape(columnsQuery)).
+		WithArgs("ATLAS", "USERS").
+		WillReturnRows(sqltest.Rows(`
+ COLUMN_NAME | DATA_TYPE | NULLABLE | DATA_DEFAULT | DATA_LENGTH | CHAR_LENGTH | DATA_PRECISION | DATA_SCALE | CHARACTER_SET_NAME | IDENTITY_COLUMN | GENERATION_TYPE | IDENTITY_OPTIONS | COMMENTS | VIRTUAL_COLUMN | HIDDEN_COLUMN | USER_GENERATED | CHAR_USED | COLLATION | DEFAULT_ON_NULL | SEQUENCE_NAME
+-------------+-----------+----------+--------------+-------------+-------------+----------------+------------+--------------------+-----------------+-----------------+------------------+----------+----------------+---------------+----------------+----------------
+ ID          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |          | NO             | NO            | YES
+ SECRET      | NUMBER    | Y        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |          | NO             | YES           | YES
+ SYS_NC00003$| NUMBER    | Y        | "ID"*2       |          22 |           0 |                |            |                    | NO              |                 |                  |          | YES            | YES           | NO
+`))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	tt, err := drv.InspectTable(context.Background(), "USERS", &schema.InspectTableOptions{Schema: "ATLAS"})
+	require.NoError(t, err)
+	require.Len(t, tt.Columns, 2)
+	require.Empty(t, tt.Columns[0].Attrs)
+	require.Equal(t, []schema.Attr{&Invisible{}}, tt.Columns[1].Attrs)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestDriver_InspectLengthSemantics(t *testing.T) {
+	tests := []struct {
+		semantics string
+		expect    [][]schema.Attr
+	}{
+		{
+			semantics: SemanticsByte,
+			expect:    [][]schema.Attr{nil, {&LengthSemantics{T: SemanticsChar}}, nil},
+		},
+		{
+			semantics: SemanticsChar,
+			expect:    [][]schema.Attr{{&LengthSemantics{T: SemanticsByte}}, nil, nil},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.semantics, func(t *testing.T) {
+			db, m, err := sqlmock.New()
+			require.NoError(t, err)
+			mk := mock{m}
+			mk.params("19.0.0.0.0", tt.semantics)
+			drv, err := Open(db)
+			require.NoError(t, err)
+			mk.tableExistsInSchema("ATLAS", "USERS", true)
+			m.ExpectQuery(sqltest.Escape(columnsQuery)).
+				WithArgs("ATLAS", "USERS").
+				WillReturnRows(sqltest.Rows(`
+ COLUMN_NAME | DATA_TYPE | NULLABLE | DATA_DEFAULT | DATA_LENGTH | CHAR_LENGTH | DATA_PRECISION | DATA_SCALE | CHARACTER_SET_NAME | IDENTITY_COLUMN | GENERATION_TYPE | IDENTITY_OPTIONS | COMMENTS | VIRTUAL_COLUMN | HIDDEN_COLUMN | USER_GENERATED | CHAR_USED | COLLATION | DEFAULT_ON_NULL | SEQUENCE_NAME
+-------------+-----------+----------+--------------+-------------+-------------+----------------+------------+--------------------+-----------------+-----------------+------------------+----------+----------------+---------------+----------------+-----------+----------------
+ C1          | VARCHAR2  | Y        |              |          10 |          10 |                |            | CHAR_CS            | NO              |                 |                  |          | NO             | NO            | YES            | B
+ C2          | VARCHAR2  | Y        |              |          40 |          10 |                |            | CHAR_CS            | NO              |                 |                  |          | NO             | NO            | YES            | C
+ C3          | NVARCHAR2 | Y        |              |          20 |          10 |                |            | NCHAR_CS           | NO              |                 |                  |          | NO             | NO            | YES            | C
+`))
+			mk.noIndexes()
+			mk.noFKs()
+			mk.noChecks()
+			table, err := drv.InspectTable(context.Background(), "USERS", &schema.InspectTableOptions{Schema: "ATLAS"})
+			require.NoError(t, err)
+			require.Len(t, table.Columns, 3)
+			for i, c := range table.Columns {
+				require.Equal(t, &schema.StringType{T: strings.ToLower(c.Type.Raw), Size: 10}, c.Type.Type)
+				var attrs []schema.Attr
+				for _, a := range c.Attrs {
+					if _, ok := a.(*schema.Charset); !ok {
+						attrs = append(attrs, a)
+					}
+				}
+				require.Equal(t, tt.expect[i], attrs)
+			}
+			require.NoError(t, m.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDriver_InspectCollation(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.tableExistsInSchema("ATLAS", "USERS", true)
+	m.ExpectQuery(sqltest.Escape(columnsQuery)).
+		WithArgs("ATLAS", "USERS").
+		WillReturnRows(sqltest.Rows(`
+ COLUMN_NAME | DATA_TYPE | NULLABLE | DATA_DEFAULT | DATA_LENGTH | CHAR_LENGTH | DATA_PRECISION | DATA_SCALE | CHARACTER_SET_NAME | IDENTITY_COLUMN | GENERATION_TYPE | IDENTITY_OPTIONS | COMMENTS | VIRTUAL_COLUMN | HIDDEN_COLUMN | USER_GENERATED | CHAR_USED | COLLATION | DEFAULT_ON_NULL | SEQUENCE_NAME
+-------------+-----------+----------+--------------+-------------+-------------+----------------+------------+--------------------+-----------------+-----------------+------------------+----------+----------------+---------------+----------------+-----------+-------------------+----------------+--------------
+ ID          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |          | NO             | NO            | YES            |           |
+ NAME        | VARCHAR2  | Y        |              |          10 |          10 |                |            | CHAR_CS            | NO              |                 |                  |          | NO             | NO            | YES            | B         | USING_NLS_COMP
+ EMAIL       | VARCHAR2  | Y        |              |          10 |          10 |                |            | CHAR_CS            | NO              |                 |                  |          | NO             | NO            | YES            | B         | BINARY_CI
+ NICK        | VARCHAR2  | Y        |              |          10 |          10 |                |            | CHAR_CS            | NO              |                 |                  |          | NO             | NO            | YES            | B         | USING_NLS_SORT_CI
+`))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	table, err := drv.InspectTable(context.Background(), "USERS", &schema.InspectTableOptions{Schema: "ATLAS"})
+	require.NoError(t, err)
+	require.Len(t, table.Columns, 4)
+	// Columns that use the default pseudo-collation are inspected without one.
+	require.Empty(t, table.Columns[0].Attrs)
+	require.Equal(t, []schema.Attr{&schema.Charset{V: "CHAR_CS"}}, table.Columns[1].Attrs)
+	require.Equal(t, []schema.Attr{&schema.Charset{V: "CHAR_CS"}, &schema.Collation{V: "BINARY_CI"}}, table.Columns[2].Attrs)
+	require.Equal(t, []schema.Attr{&schema.Charset{V: "CHAR_CS"}, &schema.Collation{V: "USING_NLS_SORT_CI"}}, table.Columns[3].Attrs)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestDriver_InspectDefaultOnNull(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.tableExistsInSchema("ATLAS", "USERS", true)
+	m.ExpectQuery(sqltest.Escape(columnsQuery)).
+		WithArgs("ATLAS", "USERS").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME", "DATA_TYPE", "NULLABLE", "DATA_DEFAULT", "DATA_LENGTH", "CHAR_LENGTH", "DATA_PRECISION", "DATA_SCALE", "CHARACTER_SET_NAME", "IDENTITY_COLUMN", "GENERATION_TYPE", "IDENTITY_OPTIONS", "COMMENTS", "VIRTUAL_COLUMN", "HIDDEN_COLUMN", "USER_GENERATED", "CHAR_USED", "COLLATION", "DEFAULT_ON_NULL", "SEQUENCE_NAME"}).
+			AddRow("ACTIVE", "NUMBER", "N", "1", 22, 0, 1, 0, nil, "NO", nil, nil, nil, "NO", "NO", "YES", nil, nil, "YES", nil).
+			AddRow("RANK", "NUMBER", "Y", "0", 22, 0, 10, 0, nil, "NO", nil, nil, nil, "NO", "NO", "YES", nil, nil, "NO", nil))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	table, err := drv.InspectTable(context.Background(), "USERS", &schema.InspectTableOptions{Schema: "ATLAS"})
+	require.NoError(t, err)
+	require.Len(t, table.Columns, 2)
+	require.Equal(t, &schema.Literal{V: "1"}, table.Columns[0].Default)
+	require.Equal(t, []schema.Attr{&DefaultOnNull{}}, table.Columns[0].Attrs)
+	require.Equal(t, &schema.Literal{V: "0"}, table.Columns[1].Default)
+	require.Empty(t, table.Columns[1].Attrs)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestDriver_InspectMappingTable(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	m.ExpectQuery(sqltest.Escape(tableSchemaQuery)).
+		WithArgs("USERS", "ATLAS").
+		WillReturnRows(sqlmock.NewRows([]string{"OWNER", "COMMENTS", "DEGREE", "INSTANCES", "DEPENDENCIES", "TEMPORARY", "DURATION", "TYPE_NAME", "DEFAULT_DIRECTORY_NAME", "ACCESS_PARAMETERS", "TABLESPACE_NAME", "IOT_TYPE", "PCT_THRESHOLD", "OVERFLOW_NAME", "MAPPING_NAME"}).
+			AddRow("ATLAS", nil, "         1", "         1", "DISABLED", "N", nil, nil, nil, nil, nil, "IOT", nil, nil, "SYS_IOT_MAP_73418"))
+	m.ExpectQuery(sqltest.Escape(columnsQuery)).
+		WithArgs("ATLAS", "USERS").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME", "DATA_TYPE", "NULLABLE", "DATA_DEFAULT", "DATA_LENGTH", "CHAR_LENGTH", "DATA_PRECISION", "DATA_SCALE", "CHARACTER_SET_NAME", "IDENTITY_COLUMN", "GENERATION_TYPE", "IDENTITY_OPTIONS", "COMMENTS", "VIRTUAL_COLUMN", "HIDDEN_COLUMN", "USER_GENERATED", "CHAR_USED", "COLLATION", "DEFAULT_ON_NULL", "SEQUENCE_NAME"}).
+			AddRow("ID", "NUMBER", "N", nil, 22, 0, 10, 0, nil, "NO", nil, nil, nil, "NO", "NO", "YES", nil, nil, "NO", nil))
+	m.ExpectQuery(sqltest.Escape(indexesQuery)).
+		WithArgs("ATLAS", "USERS").
+		WillReturnRows(sqlmock.NewRows([]string{"INDEX_NAME", "INDEX_TYPE", "UNIQUENESS", "CONSTRAINT_TYPE", "COLUMN_NAME", "DESCEND", "COLUMN_EXPRESSION", "TABLESPACE_NAME", "LOCALITY"}).
+			AddRow("USERS_PK", "IOT - TOP", "UNIQUE", "P", "ID", "ASC", nil, nil, nil))
+	mk.noFKs()
+	mk.noChecks()
+	table, err := drv.InspectTable(context.Background(), "USERS", &schema.InspectTableOptions{Schema: "ATLAS"})
+	require.NoError(t, err)
+	require.Equal(t, []schema.Attr{&Organization{T: OrganizationIndex, Mapping: true}}, table.Attrs)
+	require.NoError(t, m.ExpectationsWereMet())
+
+	plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.AddTable{T: table}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `CREATE TABLE ATLAS.USERS (ID number(10) NOT NULL, CONSTRAINT USERS_PK PRIMARY KEY (ID)) ORGANIZATION INDEX MAPPING TABLE`, plan.Changes[0].Cmd)
+}
+
+func TestDriver_InspectParallel(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	m.ExpectQuery(sqltest.Escape(tableSchemaQuery)).
+		WithArgs("USERS", "ATLAS").
+		WillReturnRows(sqlmock.NewRows([]string{"OWNER", "COMMENTS", "DEGREE", "INSTANCES", "DEPENDENCIES", "TEMPORARY", "DURATION", "TYPE_NAME", "DEFAULT_DIRECTORY_NAME", "ACCESS_PARAMETERS", "TABLESPACE_NAME", "IOT_TYPE", "PCT_THRESHOLD", "OVERFLOW_NAME", "MAPPING_NAME"}).
+			AddRow("ATLAS", nil, "         8", "         2", "DISABLED", "N", nil, nil, nil, nil, nil, nil, nil, nil, nil))
+	m.ExpectQuery(sqltest.Escape(columnsQuery)).
+		WithArgs("ATLAS", "USERS").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME", "DATA_TYPE", "NULLABLE", "DATA_DEFAULT", "DATA_LENGTH", "CHAR_LENGTH", "DATA_PRECISION", "DATA_SCALE", "CHARACTER_SET_NAME", "IDENTITY_COLUMN", "GENERATION_TYPE", "IDENTITY_OPTIONS", "COMMENTS", "VIRTUAL_COLUMN", "HIDDEN_COLUMN", "USER_GENERATED", "CHAR_USED", "COLLATION", "DEFAULT_ON_NULL", "SEQUENCE_NAME"}).
+			AddRow("ID", "NUMBER", "N", nil, 22, 0, 10, 0, nil, "NO", nil, nil, nil, "NO", "NO", "YES", nil, nil, "NO", nil))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	table, err := drv.InspectTable(context.Background(), "USERS", &schema.InspectTableOptions{Schema: "ATLAS"})
+	require.NoError(t, err)
+	require.Equal(t, []schema.Attr{&Parallel{Degree: 8, Instances: 2}}, table.Attrs)
+	require.NoError(t, m.ExpectationsWereMet())
+
+	plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.AddTable{T: table}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `CREATE TABLE ATLAS.USERS (ID number(10) NOT NULL) PARALLEL (DEGREE 8 INSTANCES 2)`, plan.Changes[0].Cmd)
+
+	// The planned table is in the inspected state.
+	desired := &schema.Table{Name: "USERS", Schema: table.Schema, Columns: table.Columns, Attrs: []schema.Attr{&Parallel{Degree: 8, Instances: 2}}}
+	changes, err := drv.TableDiff(table, desired)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	// DEFAULT values are not equal to explicit ones.
+	desired.Attrs = []schema.Attr{&Parallel{Degree: ParallelDefault, Instances: 2}}
+	changes, err = drv.TableDiff(table, desired)
+	require.NoError(t, err)
+	require.Equal(t, []schema.Change{&schema.ModifyAttr{From: &Parallel{Degree: 8, Instances: 2}, To: &Parallel{Degree: ParallelDefault, Instances: 2}}}, changes)
+	plan, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.ModifyTable{T: desired, Changes: changes}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `ALTER TABLE ATLAS.USERS PARALLEL (DEGREE DEFAULT INSTANCES 2)`, plan.Changes[0].Cmd)
+	require.Equal(t, `ALTER TABLE ATLAS.USERS PARALLEL (DEGREE 8 INSTANCES 2)`, plan.Changes[0].Reverse)
+
+	// Degree 1 is planned as NOPARALLEL.
+	desired.Attrs = []schema.Attr{&Parallel{Degree: 1}}
+	changes, err = drv.TableDiff(table, desired)
+	require.NoError(t, err)
+	plan, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.ModifyTable{T: desired, Changes: changes}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `ALTER TABLE ATLAS.USERS NOPARALLEL`, plan.Changes[0].Cmd)
+}
+
+func TestDriver_InspectIdentitySequenceName(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.tableExistsInSchema("ATLAS", "USERS", true)
+	m.ExpectQuery(sqltest.Escape(columnsQuery)).
+		WithArgs("ATLAS", "USERS").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME", "DATA_TYPE", "NULLABLE", "DATA_DEFAULT", "DATA_LENGTH", "CHAR_LENGTH", "DATA_PRECISION", "DATA_SCALE", "CHARACTER_SET_NAME", "IDENTITY_COLUMN", "GENERATION_TYPE", "IDENTITY_OPTIONS", "COMMENTS", "VIRTUAL_COLUMN", "HIDDEN_COLUMN", "USER_GENERATED", "CHAR_USED", "COLLATION", "DEFAULT_ON_NULL", "SEQUENCE_NAME"}).
+			AddRow("ID", "NUMBER", "N", `"ATLAS"."ISEQ$$_73001".nextval`, 22, 0, 10, 0, nil, "YES", "ALWAYS", "START WITH: 1, INCREMENT BY: 1", nil, "NO", "NO", "YES", nil, nil, "NO", "ISEQ$$_73001"))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	table, err := drv.InspectTable(context.Background(), "USERS", &schema.InspectTableOptions{Schema: "ATLAS"})
+	require.NoError(t, err)
+	require.Len(t, table.Columns[0].Attrs, 1)
+	id, ok := table.Columns[0].Attrs[0].(*Identity)
+	require.True(t, ok)
+	require.Equal(t, "ISEQ$$_73001", id.SequenceName)
+	require.Equal(t, "ALWAYS", id.Generation)
+	require.NoError(t, m.ExpectationsWereMet())
+
+	// The system-generated name is not used in DDL.
+	plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.AddTable{T: table}})
+	require.NoError(t, err)
+	require.Equal(t, `CREATE TABLE ATLAS.USERS (ID number(10) GENERATED ALWAYS AS IDENTITY NOT NULL)`, plan.Changes[0].Cmd)
+}
+
+func TestDriver_InspectXMLType(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.tableExistsInSchema("ATLAS", "DOCS", true)
+	m.ExpectQuery(sqltest.Escape(columnsQuery)).
+		WithArgs("ATLAS", "DOCS").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME", "DATA_TYPE", "NULLABLE", "DATA_DEFAULT", "DATA_LENGTH", "CHAR_LENGTH", "DATA_PRECISION", "DATA_SCALE", "CHARACTER_SET_NAME", "IDENTITY_COLUMN", "GENERATION_TYPE", "IDENTITY_OPTIONS", "COMMENTS", "VIRTUAL_COLUMN", "HIDDEN_COLUMN", "USER_GENERATED", "CHAR_USED", "COLLATION", "DEFAULT_ON_NULL", "SEQUENCE_NAME"}).
+			AddRow("BODY", "XMLTYPE", "Y", nil, 2000, 0, nil, nil, nil, "NO", nil, nil, nil, "NO", "NO", "YES", nil, nil, "NO", nil))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	table, err := drv.InspectTable(context.Background(), "DOCS", &schema.InspectTableOptions{Schema: "ATLAS"})
+	require.NoError(t, err)
+	require.Equal(t, []*schema.Column{
+		{Name: "BODY", Type: &schema.ColumnType{Raw: "XMLTYPE", Type: &XMLType{T: TypeXML}, Null: true}},
+	}, table.Columns)
+	require.NoError(t, m.ExpectationsWereMet())
+	f, err := FormatType(table.Columns[0].Type.Type)
+	require.NoError(t, err)
+	require.Equal(t, "xmltype", f)
+}
+
+func TestDriver_InspectCollectionTypes(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.tableExistsInSchema("ATLAS", "USERS", true)
+	m.ExpectQuery(sqltest.Escape(columnsQuery)).
+		WithArgs("ATLAS", "USERS").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME", "DATA_TYPE", "NULLABLE", "DATA_DEFAULT", "DATA_LENGTH", "CHAR_LENGTH", "DATA_PRECISION", "DATA_SCALE", "CHARACTER_SET_NAME", "IDENTITY_COLUMN", "GENERATION_TYPE", "IDENTITY_OPTIONS", "COMMENTS", "VIRTUAL_COLUMN", "HIDDEN_COLUMN", "USER_GENERATED", "CHAR_USED", "COLLATION", "DEFAULT_ON_NULL", "SEQUENCE_NAME"}).
+			AddRow("ID", "NUMBER", "N", nil, 22, 0, 10, 0, nil, "NO", nil, nil, nil, "NO", "NO", "YES", nil, nil, "NO", nil).
+			AddRow("PHONES", "PHONE_LIST", "Y", nil, 65, 0, nil, nil, nil, "NO", nil, nil, nil, "NO", "NO", "YES", nil, nil, "NO", nil).
+			AddRow("TAGS", "TAG_LIST", "Y", nil, 16, 0, nil, nil, nil, "NO", nil, nil, nil, "NO", "NO", "YES", nil, nil, "NO", nil))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	m.ExpectQuery(sqltest.Escape(collTypesQuery)).
+		WithArgs("ATLAS", "USERS").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME", "OWNER", "TYPE_NAME", "COLL_TYPE", "UPPER_BOUND", "ELEM_TYPE_NAME", "LENGTH", "PRECISION", "SCALE", "NULLS_STORED"}).
+			AddRow("PHONES", "ATLAS", "PHONE_LIST", "VARYING ARRAY", 5, "VARCHAR2", 20, nil, nil, "NO").
+			AddRow("TAGS", "SHARED", "TAG_LIST", "TABLE", nil, "NUMBER", 22, 10, 0, "YES"))
+	table, err := drv.InspectTable(context.Background(), "USERS", &schema.InspectTableOptions{Schema: "ATLAS"})
+	require.NoError(t, err)
+	require.Len(t, table.Columns, 3)
+	phones := &CollectionType{T: "PHONE_LIST", Kind: CollectionVarray, Size: 5, Elem: &schema.StringType{T: "varchar2", Size: 20}, NotNull: true}
+	require.Equal(t, phones, table.Columns[1].Type.Type)
+	require.Equal(t, &CollectionType{T: "TAG_LIST", Schema: "SHARED", Kind: CollectionTable, Elem: &schema.DecimalType{T: "number", Precision: 10}}, table.Columns[2].Type.Type)
+	require.NoError(t, m.ExpectationsWereMet())
+
+	// Round-trip the inspected types.
+	plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{
+		&schema.ModifySchema{S: table.Schema, Changes: []schema.Change{&schema.AddAttr{A: &Collection{T: phones}}}},
+		&schema.AddTable{T: table},
+	})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 2)
+	require.Equal(t, `CREATE TYPE ATLAS.PHONE_LIST AS VARRAY(5) OF varchar2(20) NOT NULL`, plan.Changes[0].Cmd)
+	require.Equal(t, `DROP TYPE ATLAS.PHONE_LIST`, plan.Changes[0].Reverse)
+	require.Equal(t, `CREATE TABLE ATLAS.USERS (ID number(10) NOT NULL, PHONES PHONE_LIST NULL, TAGS SHARED.TAG_LIST NULL)`, plan.Changes[1].Cmd)
+}
+
+func TestDriver_InspectLOBStorage(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.tableExistsInSchema("ATLAS", "DOCS", true)
+	m.ExpectQuery(sqltest.Escape(columnsQuery)).
+		WithArgs("ATLAS", "DOCS").
+		WillReturnRows(sqltest.Rows(`
+ COLUMN_NAME | DATA_TYPE | NULLABLE | DATA_DEFAULT | DATA_LENGTH | CHAR_LENGTH | DATA_PRECISION | DATA_SCALE | CHARACTER_SET_NAME | IDENTITY_COLUMN | GENERATION_TYPE | IDENTITY_OPTIONS | COMMENTS | VIRTUAL_COLUMN | HIDDEN_COLUMN | USER_GENERATED | CHAR_USED | COLLATION | DEFAULT_ON_NULL | SEQUENCE_NAME
+-------------+-----------+----------+--------------+-------------+-------------+----------------+------------+--------------------+-----------------+-----------------+------------------+----------+----------------
+ ID          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |
+ BODY        | CLOB      | Y        |              |        4000 |           0 |                |            | CHAR_CS            | NO              |                 |                  |
+ DATA        | BLOB      | Y        |              |        4000 |           0 |                |            |                    | NO              |                 |                  |
+ NOTES       | CLOB      | Y        |              |        4000 |           0 |                |            | CHAR_CS            | NO              |                 |                  |
+`))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	m.ExpectQuery(sqltest.Escape(lobsQuery)).
+		WithArgs("ATLAS", "DOCS").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME", "SECUREFILE", "IN_ROW", "PCTVERSION", "RETENTION"}).
+			AddRow("BODY", "NO", "YES", nil, 900).
+			AddRow("DATA", "NO", "YES", 20, nil).
+			AddRow("NOTES", "YES", "YES", nil, nil))
+	table, err := drv.InspectTable(context.Background(), "DOCS", &schema.InspectTableOptions{Schema: "ATLAS"})
+	require.NoError(t, err)
+	require.NoError(t, m.ExpectationsWereMet())
+	require.Equal(t, []schema.Attr{&schema.Charset{V: "CHAR_CS"}, &LOBStorage{Retention: true}}, table.Columns[1].Attrs)
+	require.Equal(t, []schema.Attr{&LOBStorage{PctVersion: 20}}, table.Columns[2].Attrs)
+	require.Equal(t, []schema.Attr{&schema.Charset{V: "CHAR_CS"}, &LOBStorage{SecureFile: true}}, table.Columns[3].Attrs)
+
+	// Round-trip the inspected storage.
+	plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.AddTable{T: table}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `CREATE TABLE ATLAS.DOCS (ID number(10) NOT NULL, BODY clob NULL, DATA blob NULL, NOTES clob NULL) LOB (BODY) STORE AS BASICFILE (RETENTION) LOB (DATA) STORE AS BASICFILE (PCTVERSION 20) LOB (NOTES) STORE AS SECUREFILE`, plan.Changes[0].Cmd)
+
+	// Switch the BASICFILE LOBs to the other versioning mode.
+	desired := schema.NewTable("DOCS").SetSchema(schema.New("ATLAS")).AddColumns(
+		table.Columns[0],
+		&schema.Column{Name: "BODY", Type: table.Columns[1].Type, Attrs: []schema.Attr{&schema.Charset{V: "CHAR_CS"}, &LOBStorage{PctVersion: 10}}},
+		&schema.Column{Name: "DATA", Type: table.Columns[2].Type, Attrs: []schema.Attr{&LOBStorage{Retention: true}}},
+		&schema.Column{Name: "NOTES", Type: table.Columns[3].Type, Attrs: []schema.Attr{&schema.Charset{V: "CHAR_CS"}}},
+	)
+	changes, err := drv.TableDiff(table, desired)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	plan, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.ModifyTable{T: desired, Changes: changes}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 2)
+	require.Equal(t, `ALTER TABLE ATLAS.DOCS MODIFY LOB (BODY) (PCTVERSION 10)`, plan.Changes[0].Cmd)
+	require.Equal(t, `ALTER TABLE ATLAS.DOCS MODIFY LOB (BODY) (RETENTION)`, plan.Changes[0].Reverse)
+	require.Equal(t, `ALTER TABLE ATLAS.DOCS MODIFY LOB (DATA) (RETENTION)`, plan.Changes[1].Cmd)
+
+	// The LOB segment must be moved for changing its kind.
+	desired.Columns[3].Attrs = []schema.Attr{&schema.Charset{V: "CHAR_CS"}, &LOBStorage{}}
+	changes, err = drv.TableDiff(table, desired)
+	require.NoError(t, err)
+	_, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.ModifyTable{T: desired, Changes: changes}})
+	require.EqualError(t, err, `oracle: changing the LOB storage of column "NOTES" between BASICFILE and SECUREFILE is not supported`)
+}
+
+func TestDriver_InspectLOBOutOfRow(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.tableExistsInSchema("ATLAS", "DOCS", true)
+	m.ExpectQuery(sqltest.Escape(columnsQuery)).
+		WithArgs("ATLAS", "DOCS").
+		WillReturnRows(sqltest.Rows(`
+ COLUMN_NAME | DATA_TYPE | NULLABLE | DATA_DEFAULT | DATA_LENGTH | CHAR_LENGTH | DATA_PRECISION | DATA_SCALE | CHARACTER_SET_NAME | IDENTITY_COLUMN | GENERATION_TYPE | IDENTITY_OPTIONS | COMMENTS | VIRTUAL_COLUMN | HIDDEN_COLUMN | USER_GENERATED | CHAR_USED | COLLATION | DEFAULT_ON_NULL | SEQUENCE_NAME
+-------------+-----------+----------+--------------+-------------+-------------+----------------+------------+--------------------+-----------------+-----------------+------------------+----------+----------------
+ ID          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |
+ BODY        | BLOB      | Y        |              |        4000 |           0 |                |            |                    | NO              |                 |                  |
+ DATA        | BLOB      | Y        |              |        4000 |           0 |                |            |                    | NO              |                 |                  |
+`))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	m.ExpectQuery(sqltest.Escape(lobsQuery)).
+		WithArgs("ATLAS", "DOCS").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME", "SECUREFILE", "IN_ROW", "PCTVERSION", "RETENTION"}).
+			AddRow("BODY", "YES", "NO", nil, nil).
+			AddRow("DATA", "NO", "NO", 10, nil))
+	table, err := drv.InspectTable(context.Background(), "DOCS", &schema.InspectTableOptions{Schema: "ATLAS"})
+	require.NoError(t, err)
+	require.NoError(t, m.ExpectationsWereMet())
+	require.Equal(t, []schema.Attr{&LOBStorage{SecureFile: true, OutOfRow: true}}, table.Columns[1].Attrs)
+	require.Equal(t, []schema.Attr{&LOBStorage{OutOfRow: true, PctVersion: 10}}, table.Columns[2].Attrs)
+
+	// Round-trip the inspected storage.
+	plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.AddTable{T: table}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `CREATE TABLE ATLAS.DOCS (ID number(10) NOT NULL, BODY blob NULL, DATA blob NULL) LOB (BODY) STORE AS SECUREFILE (DISABLE STORAGE IN ROW) LOB (DATA) STORE AS BASICFILE (DISABLE STORAGE IN ROW PCTVERSION 10)`, plan.Changes[0].Cmd)
+	changes, err := drv.TableDiff(table, table)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	// The LOB segment must be moved for storing it in-row.
+	desired := schema.NewTable("DOCS").SetSchema(schema.New("ATLAS")).AddColumns(
+		table.Columns[0],
+		&schema.Column{Name: "BODY", Type: table.Columns[1].Type, Attrs: []schema.Attr{&LOBStorage{SecureFile: true}}},
+		table.Columns[2],
+	)
+	changes, err = drv.TableDiff(table, desired)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	_, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.ModifyTable{T: desired, Changes: changes}})
+	require.EqualError(t, err, `oracle: changing the in-row storage of LOB column "BODY" is not supported`)
+}
+
+func TestDriver_InspectObjectTypes(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.tableExistsInSchema("ATLAS", "USERS", true)
+	m.ExpectQuery(sqltest.Escape(columnsQuery)).
+		WithArgs("ATLAS", "USERS").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME", "DATA_TYPE", "NULLABLE", "DATA_DEFAULT", "DATA_LENGTH", "CHAR_LENGTH", "DATA_PRECISION", "DATA_SCALE", "CHARACTER_SET_NAME", "IDENTITY_COLUMN", "GENERATION_TYPE", "IDENTITY_OPTIONS", "COMMENTS", "VIRTUAL_COLUMN", "HIDDEN_COLUMN", "USER_GENERATED", "CHAR_USED", "COLLATION", "DEFAULT_ON_NULL", "SEQUENCE_NAME"}).
+			AddRow("ID", "NUMBER", "N", nil, 22, 0, 10, 0, nil, "NO", nil, nil, nil, "NO", "NO", "YES", nil, nil, "NO", nil).
+			AddRow("HOME", "ADDRESS", "Y", nil, 1, 0, nil, nil, nil, "NO", nil, nil, nil, "NO", "NO", "YES", nil, nil, "NO", nil))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	m.ExpectQuery(sqltest.Escape(collTypesQuery)).
+		WithArgs("ATLAS", "USERS").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME", "OWNER", "TYPE_NAME", "COLL_TYPE", "UPPER_BOUND", "ELEM_TYPE_NAME", "LENGTH", "PRECISION", "SCALE", "NULLS_STORED"}))
+	m.ExpectQuery(sqltest.Escape(objectTypesQuery)).
+		WithArgs("ATLAS", "USERS").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME", "OWNER", "TYPE_NAME"}).
+			AddRow("HOME", "ATLAS", "ADDRESS"))
+	table, err := drv.InspectTable(context.Background(), "USERS", &schema.InspectTableOptions{Schema: "ATLAS"})
+	require.NoError(t, err)
+	require.NoError(t, m.ExpectationsWereMet())
+	require.Equal(t, &UserDefinedType{T: "ADDRESS", Schema: "ATLAS"}, table.Columns[1].Type.Type)
+
+	// Object types are resolved against the database.
+	m.ExpectQuery(sqltest.Escape(objectTypeQuery)).
+		WithArgs("", "ADDRESS").
+		WillReturnRows(sqlmock.NewRows([]string{"OWNER", "TYPE_NAME"}).AddRow("ATLAS", "ADDRESS"))
+	typ, err := drv.ParseTypeContext(context.Background(), "address")
+	require.NoError(t, err)
+	require.Equal(t, table.Columns[1].Type.Type, typ)
+	changed, err := drv.TableDiff(table, schema.NewTable("USERS").SetSchema(schema.New("ATLAS")).AddColumns(
+		table.Columns[0],
+		&schema.Column{Name: "HOME", Type: &schema.ColumnType{Type: typ, Null: true}},
+	))
+	require.NoError(t, err)
+	require.Empty(t, changed)
+	plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.AddTable{T: table}})
+	require.NoError(t, err)
+	require.Equal(t, `CREATE TABLE ATLAS.USERS (ID number(10) NOT NULL, HOME ATLAS.ADDRESS NULL)`, plan.Changes[0].Cmd)
+
+	m.ExpectQuery(sqltest.Escape(objectTypeQuery)).
+		WithArgs("SHARED", "Point").
+		WillReturnRows(sqlmock.NewRows([]string{"OWNER", "TYPE_NAME"}).AddRow("SHARED", "Point"))
+	typ, err = drv.ParseTypeContext(context.Background(), `shared."Point"`)
+	require.NoError(t, err)
+	require.Equal(t, &UserDefinedType{T: "Point", Schema: "SHARED"}, typ)
+
+	m.ExpectQuery(sqltest.Escape(objectTypeQuery)).
+		WithArgs("", "UNKNOWN").
+		WillReturnRows(sqlmock.NewRows([]string{"OWNER", "TYPE_NAME"}))
+	_, err = drv.ParseTypeContext(context.Background(), "unknown")
+	require.EqualError(t, err, `oracle: unknown type "unknown"`)
+
+	// Built-in types are not looked up.
+	typ, err = drv.ParseTypeContext(context.Background(), "number(10)")
+	require.NoError(t, err)
+	require.Equal(t, &schema.DecimalType{T: TypeNumber, Precision: 10}, typ)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestDriver_InspectDBAViews(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		expect func(string) string
+	}{
+		{
+			name:   "dba",
+			expect: func(q string) string { return strings.ReplaceAll(q, "ALL_", "DBA_") },
+		},
+		{
+			name:   "fallback",
+			err:    errors.New("ORA-00942: table or view does not exist"),
+			expect: func(q string) string { return q },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, m, err := sqlmock.New()
+			require.NoError(t, err)
+			mk := mock{m}
+			mk.version("19.0.0.0.0")
+			probe := m.ExpectQuery(sqltest.Escape(dbaViewsQuery))
+			if tt.err != nil {
+				probe.WillReturnError(tt.err)
+			} else {
+				probe.WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+			}
+			drv, err := Open(db, WithDBAViews())
+			require.NoError(t, err)
+			m.ExpectQuery(sqltest.Escape(tt.expect(tableSchemaQuery))).
+				WithArgs("USERS", "ATLAS").
+				WillReturnRows(sqlmock.NewRows([]string{"OWNER", "COMMENTS", "DEGREE", "INSTANCES", "DEPENDENCIES", "TEMPORARY", "DURATION", "TYPE_NAME", "DEFAULT_DIRECTORY_NAME", "ACCESS_PARAMETERS", "TABLESPACE_NAME", "IOT_TYPE", "PCT_THRESHOLD", "OVERFLOW_NAME", "MAPPING_NAME"}).AddRow("ATLAS", nil, "1", "1", "DISABLED", "N", nil, nil, nil, nil, nil, nil, nil, nil, nil))
+			m.ExpectQuery(sqltest.Escape(tt.expect(columnsQuery))).
+				WithArgs("ATLAS", "USERS").
+				WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME"}))
+			m.ExpectQuery(sqltest.Escape(tt.expect(indexesQuery))).
+				WillReturnRows(sqlmock.NewRows([]string{"INDEX_NAME"}))
+			m.ExpectQuery(sqltest.Escape(tt.expect(fksQuery))).
+				WillReturnRows(sqlmock.NewRows([]string{"CONSTRAINT_NAME"}))
+			m.ExpectQuery(sqltest.Escape(tt.expect(checksQuery))).
+				WillReturnRows(sqlmock.NewRows([]string{"CONSTRAINT_NAME"}))
+			_, err = drv.InspectTable(context.Background(), "USERS", &schema.InspectTableOptions{Schema: "ATLAS"})
+			require.NoError(t, err)
+			require.NoError(t, m.ExpectationsWereMet())
+		})
+	}
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("19.0.0.0.0")
+	m.ExpectQuery(sqltest.Escape(dbaViewsQuery)).WillReturnError(errors.New("connection reset"))
+	_, err = Open(db, WithDBAViews())
+	require.Error(t, err)
+}
+
+func TestDriver_InspectILM(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db, WithILM())
+	require.NoError(t, err)
+	mk.tableExistsInSchema("ATLAS", "ORDERS", true)
+	m.ExpectQuery(sqltest.Escape(columnsQuery)).
+		WithArgs("ATLAS", "ORDERS").
+		WillReturnRows(sqlmock.NewRows(nil))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	m.ExpectQuery(sqltest.Escape(ilmQuery)).
+		WithArgs("ATLAS", "ORDERS").
+		WillReturnRows(sqltest.Rows(`
+ POLICY_NAME | ACTION_TYPE | SCOPE | COMPRESSION_LEVEL | TIER_TABLESPACE | CONDITION_TYPE         | CONDITION_DAYS | ENABLED
+-------------+-------------+-------+-------------------+-----------------+------------------------+----------------+---------
+ P1          | COMPRESSION | ROW   | ADVANCED          |                 | LAST MODIFICATION TIME | 30             | YES
+`))
+	tt, err := drv.InspectTable(context.Background(), "ORDERS", &schema.InspectTableOptions{Schema: "ATLAS"})
+	require.NoError(t, err)
+	require.Equal(t, []schema.Attr{
+		&ILMPolicy{Name: "P1", Action: "COMPRESSION", Scope: "ROW", Compression: "ADVANCED", Condition: "LAST MODIFICATION TIME", Days: 30, Enabled: true},
+	}, tt.Attrs)
+	require.NoError(t, m.ExpectationsWereMet())
+
+	// ILM is not supported by 11g, and the option is ignored.
+	mk.version("11.2.0.4.0")
+	drv, err = Open(db, WithILM())
+	require.NoError(t, err)
+	mk.tableExistsInSchema("ATLAS", "ORDERS", true)
+	m.ExpectQuery(sqltest.Escape(columnsQueryNoIdentity)).
+		WithArgs("ATLAS", "ORDERS").
+		WillReturnRows(sqlmock.NewRows(nil))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	tt, err = drv.InspectTable(context.Background(), "ORDERS", &schema.InspectTableOptions{Schema: "ATLAS"})
+	require.NoError(t, err)
+	require.Empty(t, tt.Attrs)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestDriver_InspectLastDDL(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db, WithLastDDL())
+	require.NoError(t, err)
+	mk.tableExistsInSchema("ATLAS", "ORDERS", true)
+	m.ExpectQuery(sqltest.Escape(columnsQuery)).
+		WithArgs("ATLAS", "ORDERS").
+		WillReturnRows(sqlmock.NewRows(nil))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	m.ExpectQuery(sqltest.Escape(lastDDLQuery)).
+		WithArgs("ATLAS", "ORDERS").
+		WillReturnRows(sqlmock.NewRows([]string{"LAST_DDL_TIME"}).AddRow("2026-10-14 09:30:15"))
+	tt, err := drv.InspectTable(context.Background(), "ORDERS", &schema.InspectTableOptions{Schema: "ATLAS"})
+	require.NoError(t, err)
+	require.Equal(t, []schema.Attr{
+		&LastDDL{T: time.Date(2026, 10, 14, 9, 30, 15, 0, time.UTC)},
+	}, tt.Attrs)
+	require.NoError(t, m.ExpectationsWereMet())
+
+	// The last DDL time is not captured by default.
+	mk.version("19.0.0.0.0")
+	drv, err = Open(db)
+	require.NoError(t, err)
+	mk.tableExistsInSchema("ATLAS", "ORDERS", true)
+	m.ExpectQuery(sqltest.Escape(columnsQuery)).
+		WithArgs("ATLAS", "ORDERS").
+		WillReturnRows(sqlmock.NewRows(nil))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	tt, err = drv.InspectTable(context.Background(), "ORDERS", &schema.InspectTableOptions{Schema: "ATLAS"})
+	require.NoError(t, err)
+	require.Empty(t, tt.Attrs)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestDriver_InspectAudit(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db, WithAudit())
+	require.NoError(t, err)
+	mk.tableExistsInSchema("ATLAS", "ORDERS", true)
+	m.ExpectQuery(sqltest.Escape(columnsQuery)).
+		WithArgs("ATLAS", "ORDERS").
+		WillReturnRows(sqlmock.NewRows(nil))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	m.ExpectQuery(sqltest.Escape(auditOptsQuery)).
+		WithArgs("ATLAS", "ORDERS").
+		WillReturnRows(sqlmock.NewRows([]string{"ALT", "AUD", "COM", "DEL", "GRA", "IND", "INS", "LOC", "REN", "SEL", "UPD", "FBK"}).
+			AddRow("-/-", "-/-", "-/-", "S/S", "-/-", "-/-", "A/-", "-/-", "-/-", "A/A", "S/A", "-/-"))
+	tt, err := drv.InspectTable(context.Background(), "ORDERS", &schema.InspectTableOptions{Schema: "ATLAS"})
+	require.NoError(t, err)
+	require.Equal(t, []schema.Attr{
+		&Audit{Option: "DELETE", By: AuditBySession},
+		&Audit{Option: "INSERT", By: AuditByAccess, When: AuditSuccessful},
+		&Audit{Option: "SELECT", By: AuditByAccess},
+		&Audit{Option: "UPDATE", By: AuditBySession, When: AuditSuccessful},
+		&Audit{Option: "UPDATE", By: AuditByAccess, When: AuditNotSuccessful},
+	}, tt.Attrs)
+	require.NoError(t, m.ExpectationsWereMet())
+
+	// Tables without auditing options are not listed.
+	mk.tableExistsInSchema("ATLAS", "ORDERS", true)
+	m.ExpectQuery(sqltest.Escape(columnsQuery)).
+		WithArgs("ATLAS", "ORDERS").
+		WillReturnRows(sqlmock.NewRows(nil))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	m.ExpectQuery(sqltest.Escape(auditOptsQuery)).
+		WithArgs("ATLAS", "ORDERS").
+		WillReturnRows(sqlmock.NewRows([]string{"ALT", "AUD", "COM", "DEL", "GRA", "IND", "INS", "LOC", "REN", "SEL", "UPD", "FBK"}))
+	tt, err = drv.InspectTable(context.Background(), "ORDERS", &schema.InspectTableOptions{Schema: "ATLAS"})
+	require.NoError(t, err)
+	require.Empty(t, tt.Attrs)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestDriver_InspectRawConstraints(t *testing.T) {
+	const ddl = `
+  ALTER TABLE "ATLAS"."USERS" ADD CONSTRAINT "USERS_PK" PRIMARY KEY ("ID")
+  USING INDEX PCTFREE 10 INITRANS 2 TABLESPACE "USERS"  ENABLE`
+	for _, raw := range []bool{false, true} {
+		db, m, err := sqlmock.New()
+		require.NoError(t, err)
+		mk := mock{m}
+		mk.version("19.0.0.0.0")
+		var opts []Option
+		if raw {
+			opts = append(opts, WithRawConstraints())
+		}
+		drv, err := Open(db, opts...)
+		require.NoError(t, err)
+		mk.tableExistsInSchema("ATLAS", "USERS", true)
+		m.ExpectQuery(sqltest.Escape(columnsQuery)).
+			WithArgs("ATLAS", "USERS").
+			WillReturnRows(sqltest.Rows(`
+ COLUMN_NAME | DATA_TYPE | NULLABLE | DATA_DEFAULT | DATA_LENGTH | CHAR_LENGTH | DATA_PRECISION | DATA_SCALE | CHARACTER_SET_NAME | IDENTITY_COLUMN | GENERATION_TYPE | IDENTITY_OPTIONS | COMMENTS | VIRTUAL_COLUMN | HIDDEN_COLUMN | USER_GENERATED | CHAR_USED | COLLATION | DEFAULT_ON_NULL | SEQUENCE_NAME
+-------------+-----------+----------+--------------+-------------+-------------+----------------+------------+--------------------+-----------------+-----------------+------------------+----------+----------------
+ ID          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |
+`))
+		m.ExpectQuery(sqltest.Escape(indexesQuery)).
+			WithArgs("ATLAS", "USERS").
+			WillReturnRows(sqltest.Rows(`
+ INDEX_NAME | INDEX_TYPE | UNIQUENESS | CONSTRAINT_TYPE | COLUMN_NAME | DESCEND | COLUMN_EXPRESSION | TABLESPACE_NAME | LOCALITY
+------------+------------+------------+-----------------+-------------+---------+-------------------
+ USERS_PK   | NORMAL     | UNIQUE     | P               | ID          | ASC     |
+`))
+		mk.noFKs()
+		mk.noChecks()
+		if raw {
+			m.ExpectQuery(sqltest.Escape(constraintsDDLQuery)).
+				WithArgs("ATLAS", "USERS").
+				WillReturnRows(sqlmock.NewRows([]string{"CONSTRAINT_NAME", "CONSTRAINT_TYPE", "DDL"}).
+					AddRow("SYS_C008012", "C", `ALTER TABLE "ATLAS"."USERS" MODIFY ("ID" NOT NULL ENABLE)`).
+					AddRow("USERS_PK", "P", ddl))
+		}
+		tt, err := drv.InspectTable(context.Background(), "USERS", &schema.InspectTableOptions{Schema: "ATLAS"})
+		require.NoError(t, err)
+		// The constraint is modeled in both modes.
+		require.Equal(t, "USERS_PK", tt.PrimaryKey.Name)
+		require.Equal(t, []schema.Attr{&IndexType{T: "NORMAL"}, &ConType{T: "P"}}, tt.PrimaryKey.Attrs)
+		if raw {
+			require.Equal(t, []schema.Attr{&RawConstraint{Name: "USERS_PK", T: "P", DDL: strings.TrimSpace(ddl)}}, tt.Attrs)
+		} else {
+			require.Empty(t, tt.Attrs)
+		}
+		require.NoError(t, m.ExpectationsWereMet())
+	}
+}
+
+func TestDriver_InspectSchema(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	m.ExpectQuery(sqltest.Escape(currentSchemaQuery)).
+		WillReturnRows(sqltest.Rows(`
+ CURRENT_SCHEMA | SESSION_USER
+----------------+--------------
+ ATLAS          | ADMIN
+`))
+	mk.tables("ATLAS", "USERS")
+	mk.tableExistsInSchema("ATLAS", "USERS", true)
+	m.ExpectQuery(sqltest.Escape(columnsQuery)).
+		WithArgs("ATLAS", "USERS").
+		WillReturnRows(sqltest.Rows(`
+ COLUMN_NAME | DATA_TYPE | NULLABLE | DATA_DEFAULT | DATA_LENGTH | CHAR_LENGTH | DATA_PRECISION | DATA_SCALE | CHARACTER_SET_NAME | IDENTITY_COLUMN | GENERATION_TYPE | IDENTITY_OPTIONS | COMMENTS | VIRTUAL_COLUMN | HIDDEN_COLUMN | USER_GENERATED | CHAR_USED | COLLATION | DEFAULT_ON_NULL | SEQUENCE_NAME
+-------------+-----------+----------+--------------+-------------+-------------+----------------+------------+--------------------+-----------------+-----------------+------------------+----------+----------------
+ ID          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |
+`))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	mk.noViews("ATLAS")
+	mk.noSequences("ATLAS")
+	mk.noSynonyms("ATLAS")
+	s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "ATLAS", s.Name)
+	require.Len(t, s.Tables, 1)
+	require.Equal(t, "USERS", s.Tables[0].Name)
+	require.True(t, s.Tables[0].Schema == s)
+	require.Equal(t, []*schema.Schema{s}, s.Realm.Schemas)
+	require.Equal(t, []schema.Attr{&schema.Charset{V: "AL32UTF8"}, &schema.Collation{V: "BINARY"}}, s.Realm.Attrs)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+// countQuerier counts the queries that were executed on the underlying connection.
+type countQuerier struct {
+	schema.ExecQuerier
+	queries int
+}
+
+func (c *countQuerier) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	c.queries++
+	return c.ExecQuerier.QueryContext(ctx, query, args...)
+}
+
+func TestDriver_InspectTableMode(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	c := &countQuerier{ExecQuerier: db}
+	drv, err := Open(c)
+	require.NoError(t, err)
+	columns := func() {
+		m.ExpectQuery(sqltest.Escape(columnsQuery)).
+			WithArgs("ATLAS", "USERS").
+			WillReturnRows(sqltest.Rows(`
+ COLUMN_NAME | DATA_TYPE | NULLABLE | DATA_DEFAULT | DATA_LENGTH | CHAR_LENGTH | DATA_PRECISION | DATA_SCALE | CHARACTER_SET_NAME | IDENTITY_COLUMN | GENERATION_TYPE | IDENTITY_OPTIONS | COMMENTS | VIRTUAL_COLUMN | HIDDEN_COLUMN | USER_GENERATED | CHAR_USED | COLLATION | DEFAULT_ON_NULL | SEQUENCE_NAME
+-------------+-----------+----------+--------------+-------------+-------------+----------------+------------+--------------------+-----------------+-----------------+------------------+----------+----------------
+ ID          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |
+`))
+	}
+
+	// Columns only.
+	c.queries = 0
+	mk.tableExistsInSchema("ATLAS", "USERS", true)
+	columns()
+	tt, err := drv.InspectTable(context.Background(), "USERS", &schema.InspectTableOptions{Schema: "ATLAS", Mode: schema.InspectColumns})
+	require.NoError(t, err)
+	require.Len(t, tt.Columns, 1)
+	require.Equal(t, 2, c.queries)
+	require.NoError(t, m.ExpectationsWereMet())
+
+	// Columns and checks.
+	c.queries = 0
+	mk.tableExistsInSchema("ATLAS", "USERS", true)
+	columns()
+	mk.noChecks()
+	_, err = drv.InspectTable(context.Background(), "USERS", &schema.InspectTableOptions{Schema: "ATLAS", Mode: schema.InspectColumns | schema.InspectChecks})
+	require.NoError(t, err)
+	require.Equal(t, 3, c.queries)
+	require.NoError(t, m.ExpectationsWereMet())
+
+	// The mode is passed to the tables of the inspected schema.
+	c.queries = 0
+	m.ExpectQuery(sqltest.Escape(currentSchemaQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"CURRENT_SCHEMA", "SESSION_USER"}).AddRow("ATLAS", "ADMIN"))
+	mk.tables("ATLAS", "USERS")
+	mk.tableExistsInSchema("ATLAS", "USERS", true)
+	columns()
+	mk.noIndexes()
+	mk.noViews("ATLAS")
+	mk.noSequences("ATLAS")
+	mk.noSynonyms("ATLAS")
+	_, err = drv.InspectSchema(context.Background(), "", &schema.InspectOptions{Mode: schema.InspectIndexes})
+	require.NoError(t, err)
+	require.Equal(t, 9, c.queries)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+// cancelAfter is a context that is canceled after
+// its Err method was called n times.
+type cancelAfter struct {
+	context.Context
+	n int
+}
+
+func (c *cancelAfter) Err() error {
+	if c.n == 0 {
+		return context.Canceled
+	}
+	c.n--
+	return nil
+}
+
+func TestInspect_CancelRows(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	i := drv.Inspector.(*inspect)
+	tbl := &schema.Table{Name: "USERS", Schema: &schema.Schema{Name: "ATLAS"}}
+
+	// Columns are scanned until the context is canceled.
+	m.ExpectQuery(sqltest.Escape(columnsQuery)).
+		WillReturnRows(sqltest.Rows(`
+ COLUMN_NAME | DATA_TYPE | NULLABLE | DATA_DEFAULT | DATA_LENGTH | CHAR_LENGTH | DATA_PRECISION | DATA_SCALE | CHARACTER_SET_NAME | IDENTITY_COLUMN | GENERATION_TYPE | IDENTITY_OPTIONS | COMMENTS | VIRTUAL_COLUMN | HIDDEN_COLUMN | USER_GENERATED | CHAR_USED | COLLATION | DEFAULT_ON_NULL | SEQUENCE_NAME
+-------------+-----------+----------+--------------+-------------+-------------+----------------+------------+--------------------+-----------------+-----------------+------------------+----------+----------------+---------------+----------------+-----------+----------------
+ ID          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |          | NO             | NO            | YES            |
+ NAME        | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |          | NO             | NO            | YES            |
+ AGE         | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |          | NO             | NO            | YES            |
+`))
+	err = i.columns(&cancelAfter{Context: context.Background(), n: 2}, tbl)
+	require.ErrorIs(t, err, context.Canceled)
+	require.EqualError(t, err, `oracle: scanning "USERS" columns: context canceled`)
+	require.Len(t, tbl.Columns, 2)
+
+	m.ExpectQuery(sqltest.Escape(indexesQuery)).
+		WillReturnRows(sqltest.Rows(`
+ INDEX_NAME | INDEX_TYPE | UNIQUENESS | CONSTRAINT_TYPE | COLUMN_NAME | DESCEND | COLUMN_EXPRESSION | TABLESPACE_NAME | LOCALITY
+------------+------------+------------+-----------------+-------------+---------+-------------------+-----------------+----------
+ ID_IDX     | NORMAL     | UNIQUE     |                 | ID          | ASC     |                   |
+ NAME_IDX   | NORMAL     | NONUNIQUE  |                 | NAME        | ASC     |                   |
+`))
+	err = i.indexes(&cancelAfter{Context: context.Background(), n: 1}, tbl)
+	require.EqualError(t, err, `oracle: scanning "USERS" indexes: context canceled`)
+	require.Len(t, tbl.Indexes, 1)
+
+	m.ExpectQuery(sqltest.Escape(checksQuery)).
+		WillReturnRows(sqltest.Rows(`
+ CONSTRAINT_NAME | SEARCH_CONDITION | COLUMN_NAME | DEFERRABLE     | DEFERRED  | STATUS  | VALIDATED
+-----------------+------------------+-------------+----------------+-----------+---------+-----------
+ ID_CK           | ID > 0           | ID          | NOT DEFERRABLE | IMMEDIATE | ENABLED | VALIDATED
+`))
+	err = i.checks(&cancelAfter{Context: context.Background()}, tbl)
+	require.EqualError(t, err, `oracle: scanning "USERS" check constraints: context canceled`)
+	require.Empty(t, tbl.Attrs)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestDriver_InspectSchemaBatch(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	m.ExpectQuery(sqltest.Escape(currentSchemaQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"CURRENT_SCHEMA", "SESSION_USER"}).AddRow("ATLAS", "ADMIN"))
+	mk.tables("ATLAS", "POSTS", "TAGS", "USERS")
+	// The tables and their columns are queried once, and
+	// tables that were not listed (e.g. nested tables) are skipped.
+	mk.schemaTables("ATLAS", "POSTS", "TAGS", "USERS", "USERS_NT")
+	m.ExpectQuery(sqltest.Escape(schemaColumnsQuery)).
+		WithArgs("ATLAS").
+		WillReturnRows(sqltest.Rows(`
+ COLUMN_NAME | DATA_TYPE | NULLABLE | DATA_DEFAULT | DATA_LENGTH | CHAR_LENGTH | DATA_PRECISION | DATA_SCALE | CHARACTER_SET_NAME | IDENTITY_COLUMN | GENERATION_TYPE | IDENTITY_OPTIONS | COMMENTS | VIRTUAL_COLUMN | HIDDEN_COLUMN | USER_GENERATED | CHAR_USED | COLLATION | DEFAULT_ON_NULL | SEQUENCE_NAME | TABLE_NAME
+-------------+-----------+----------+--------------+-------------+-------------+----------------+------------+--------------------+-----------------+-----------------+------------------+----------+----------------+---------------+----------------+-----------+-----------+-----------------+---------------+------------
+ ID          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |          | NO             | NO            | YES            |           |           |                 |               | POSTS
+ AUTHOR_ID   | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |          | NO             | NO            | YES            |           |           |                 |               | POSTS
+ ID          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |          | NO             | NO            | YES            |           |           |                 |               | TAGS
+ ID          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |          | NO             | NO            | YES            |           |           |                 |               | USERS
+ ID          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |          | NO             | NO            | YES            |           |           |                 |               | USERS_V
+`))
+	// The indexes, foreign keys and checks of all tables are queried once,
+	// and rows of tables that are not inspected (e.g. views) are skipped.
+	m.ExpectQuery(sqltest.Escape(schemaIndexesQuery)).
+		WithArgs("ATLAS").
+		WillReturnRows(sqltest.Rows(`
+ INDEX_NAME | INDEX_TYPE | UNIQUENESS | CONSTRAINT_TYPE | COLUMN_NAME | DESCEND | COLUMN_EXPRESSION | TABLESPACE_NAME | LOCALITY | TABLE_NAME
+------------+------------+------------+-----------------+-------------+---------+-------------------+-----------------+----------+------------
+ POSTS_PK   | NORMAL     | UNIQUE     | P               | ID          | ASC     |                   |                 |          | POSTS
+ AUTHOR_IDX | NORMAL     | NONUNIQUE  |                 | AUTHOR_ID   | ASC     |                   |                 |          | POSTS
+ USERS_PK   | NORMAL     | UNIQUE     | P               | ID          | ASC     |                   |                 |          | USERS
+ MV_IDX     | NORMAL     | NONUNIQUE  |                 | ID          | ASC     |                   |                 |          | USERS_MV
+`))
+	m.ExpectQuery(sqltest.Escape(schemaFKsQuery)).
+		WithArgs("ATLAS").
+		WillReturnRows(sqltest.Rows(`
+ CONSTRAINT_NAME | TABLE_NAME | COLUMN_NAME | OWNER | REFERENCED_TABLE_NAME | REFERENCED_COLUMN_NAME | REFERENCED_SCHEMA_NAME | UPDATE_RULE | DELETE_RULE
+-----------------+------------+-------------+-------+-----------------------+------------------------+------------------------+-------------+-------------
+ AUTHOR_FK       | POSTS      | AUTHOR_ID   | ATLAS | USERS                 | ID                     | ATLAS                  | NO ACTION   | CASCADE
+`))
+	m.ExpectQuery(sqltest.Escape(schemaFKStatesQuery)).
+		WithArgs("ATLAS").
+		WillReturnRows(sqltest.Rows(`
+ CONSTRAINT_NAME | DEFERRABLE     | DEFERRED  | STATUS  | VALIDATED | TABLE_NAME
+-----------------+----------------+-----------+---------+-----------+------------
+ AUTHOR_FK       | DEFERRABLE     | DEFERRED  | ENABLED | VALIDATED | POSTS
+`))
+	m.ExpectQuery(sqltest.Escape(schemaChecksQuery)).
+		WithArgs("ATLAS").
+		WillReturnRows(sqltest.Rows(`
+ CONSTRAINT_NAME | SEARCH_CONDITION | COLUMN_NAME | DEFERRABLE     | DEFERRED  | STATUS  | VALIDATED | TABLE_NAME
+-----------------+------------------+-------------+----------------+-----------+---------+-----------+------------
+ SYS_C001        | "ID" IS NOT NULL | ID          | NOT DEFERRABLE | IMMEDIATE | ENABLED | VALIDATED | TAGS
+ TAGS_ID_CK      | ID > 0           | ID          | NOT DEFERRABLE | IMMEDIATE | ENABLED | VALIDATED | TAGS
+`))
+	mk.noViews("ATLAS")
+	mk.noSequences("ATLAS")
+	mk.noSynonyms("ATLAS")
+	s, err := drv.InspectSchema(context.Background(), "", nil)
+	require.NoError(t, err)
+	require.Len(t, s.Tables, 3)
+	for i, n := range []int{2, 1, 1} {
+		require.Len(t, s.Tables[i].Columns, n)
+	}
+	posts, tags, users := s.Tables[0], s.Tables[1], s.Tables[2]
+	require.Equal(t, "AUTHOR_ID", posts.Columns[1].Name)
+	require.Equal(t, "POSTS_PK", posts.PrimaryKey.Name)
+	require.Len(t, posts.Indexes, 1)
+	require.Equal(t, posts.Columns[1], posts.Indexes[0].Parts[0].C)
+	require.Equal(t, "USERS_PK", users.PrimaryKey.Name)
+	require.Empty(t, users.Indexes)
+	require.Nil(t, tags.PrimaryKey)
+
+	require.Len(t, posts.ForeignKeys, 1)
+	fk := posts.ForeignKeys[0]
+	require.Equal(t, schema.Cascade, fk.OnDelete)
+	require.Equal(t, users, fk.RefTable, "referenced tables are linked")
+	require.Equal(t, []schema.Attr{&ConstraintState{Name: "AUTHOR_FK", Deferrable: true, InitiallyDeferred: true}}, posts.Attrs)
+	require.Equal(t, []schema.Attr{&schema.Check{Name: "TAGS_ID_CK", Expr: "ID > 0", Attrs: []schema.Attr{&CheckColumns{Columns: []string{"ID"}}}}}, tags.Attrs)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestDriver_InspectSchemaBatchExtras(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("11.2.0.4.0")
+	drv, err := Open(db, WithLastDDL())
+	require.NoError(t, err)
+	mk.schemaTables("ATLAS", "POSTS", "USERS")
+	m.ExpectQuery(sqltest.Escape(schemaColumnsQueryNoIdentity)).
+		WithArgs("ATLAS").
+		WillReturnRows(sqltest.Rows(`
+ COLUMN_NAME | DATA_TYPE | NULLABLE | DATA_DEFAULT | DATA_LENGTH | CHAR_LENGTH | DATA_PRECISION | DATA_SCALE | CHARACTER_SET_NAME | IDENTITY_COLUMN | GENERATION_TYPE | IDENTITY_OPTIONS | COMMENTS | VIRTUAL_COLUMN | HIDDEN_COLUMN | USER_GENERATED | CHAR_USED | COLLATION | DEFAULT_ON_NULL | SEQUENCE_NAME | TABLE_NAME
+-------------+-----------+----------+--------------+-------------+-------------+----------------+------------+--------------------+-----------------+-----------------+------------------+----------+----------------+---------------+----------------+-----------+-----------+-----------------+---------------+------------
+ ID          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |          | NO             | NO            | YES            |           |           |                 |               | POSTS
+ BODY        | CLOB      | Y        |              |        4000 |           0 |                |            |                    | NO              |                 |                  |          | NO             | NO            | YES            |           |           |                 |               | POSTS
+ ID          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |          | NO             | NO            | YES            |           |           |                 |               | USERS
+`))
+	m.ExpectQuery(sqltest.Escape(schemaIndexesQuery)).
+		WithArgs("ATLAS").
+		WillReturnRows(sqlmock.NewRows([]string{"INDEX_NAME", "INDEX_TYPE", "UNIQUENESS", "CONSTRAINT_TYPE", "COLUMN_NAME", "DESCEND", "COLUMN_EXPRESSION", "TABLESPACE_NAME", "LOCALITY", "TABLE_NAME"}))
+	m.ExpectQuery(sqltest.Escape(schemaFKsQuery)).
+		WithArgs("ATLAS").
+		WillReturnRows(sqlmock.NewRows([]string{"CONSTRAINT_NAME", "TABLE_NAME", "COLUMN_NAME", "OWNER", "REFERENCED_TABLE_NAME", "REFERENCED_COLUMN_NAME", "REFERENCED_SCHEMA_NAME", "UPDATE_RULE", "DELETE_RULE"}))
+	m.ExpectQuery(sqltest.Escape(schemaChecksQuery)).
+		WithArgs("ATLAS").
+		WillReturnRows(sqlmock.NewRows([]string{"CONSTRAINT_NAME", "SEARCH_CONDITION", "COLUMN_NAME", "DEFERRABLE", "DEFERRED", "STATUS", "VALIDATED", "TABLE_NAME"}))
+	// The extra attributes of all tables are queried once.
+	m.ExpectQuery(sqltest.Escape(schemaLobsQuery)).
+		WithArgs("ATLAS").
+		WillReturnRows(sqltest.Rows(`
+ COLUMN_NAME | SECUREFILE | IN_ROW | PCTVERSION | RETENTION | TABLE_NAME
+-------------+------------+--------+------------+-----------+------------
+ BODY        | YES        | YES    |            | 900       | POSTS
+`))
+	m.ExpectQuery(sqltest.Escape(schemaIdentityTriggersQuery)).
+		WithArgs("ATLAS").
+		WillReturnRows(sqltest.Rows(`
+ TRIGGER_NAME | WHEN_CLAUSE | INCREMENT_BY | LAST_NUMBER | TABLE_NAME
+--------------+-------------+--------------+-------------+------------
+ USERS_ID_TRG |             | 1            | 21          | USERS
+ OTHER_ID_TRG |             | 1            | 1           | OTHER
+`))
+	m.ExpectQuery(sqltest.Escape(schemaLastDDLQuery)).
+		WithArgs("ATLAS").
+		WillReturnRows(sqltest.Rows(`
+ LAST_DDL_TIME       | OBJECT_NAME
+---------------------+-------------
+ 2026-10-14 09:30:15 | POSTS
+ 2026-10-15 10:00:00 | USERS
+`))
+	s := &schema.Schema{Name: "ATLAS"}
+	err = drv.Inspector.(*inspect).inspectTables(context.Background(), s, []string{"POSTS", "USERS"}, nil)
+	require.NoError(t, err)
+	require.NoError(t, m.ExpectationsWereMet())
+	require.Len(t, s.Tables, 2)
+	posts, users := s.Tables[0], s.Tables[1]
+	require.Equal(t, []schema.Attr{&LOBStorage{SecureFile: true}}, posts.Columns[1].Attrs)
+	require.Empty(t, posts.Columns[0].Attrs)
+	require.Equal(t, []schema.Attr{&Identity{Generation: "ALWAYS", Sequence: &Sequence{Increment: 1, Last: 21}, SequenceName: "USERS_ID_SEQ"}}, users.Columns[0].Attrs)
+	require.Equal(t, []schema.Attr{&LastDDL{T: time.Date(2026, 10, 14, 9, 30, 15, 0, time.UTC)}}, posts.Attrs)
+	require.Equal(t, []schema.Attr{&LastDDL{T: time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)}}, users.Attrs)
+}
+
+func TestDriver_InspectSchemaCurrentFallback(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	m.ExpectQuery(sqltest.Escape(currentSchemaQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"CURRENT_SCHEMA", "SESSION_USER"}).AddRow(nil, "ATLAS"))
+	mk.tables("ATLAS")
+	mk.noViews("ATLAS")
+	mk.noSequences("ATLAS")
+	mk.noSynonyms("ATLAS")
+	s, err := drv.InspectSchema(context.Background(), "", nil)
+	require.NoError(t, err)
+	require.Equal(t, "ATLAS", s.Name)
+
+	m.ExpectQuery(sqltest.Escape(currentSchemaQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"CURRENT_SCHEMA", "SESSION_USER"}).AddRow(nil, nil))
+	s, err = drv.InspectSchema(context.Background(), "", nil)
+	require.Nil(t, s)
+	require.EqualError(t, err, "oracle: could not resolve the attached schema: both CURRENT_SCHEMA and SESSION_USER are empty")
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestDriver_InspectRealm(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	m.ExpectQuery(sqltest.Escape(schemasQuery)).
+		WillReturnRows(sqltest.Rows(`
+ USERNAME
+----------
+ ATLAS
+ TEST
+`))
+	mk.tables("ATLAS")
+	mk.noViews("ATLAS")
+	mk.noSequences("ATLAS")
+	mk.noSynonyms("ATLAS")
+	mk.tables("TEST")
+	mk.noViews("TEST")
+	mk.noSequences("TEST")
+	mk.noSynonyms("TEST")
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(publicSynonymsQuery, "IN (:1, :2)"))).
+		WithArgs("ATLAS", "TEST").
+		WillReturnRows(sqlmock.NewRows([]string{"OWNER", "SYNONYM_NAME", "TABLE_OWNER", "TABLE_NAME", "DB_LINK"}))
+	realm, err := drv.InspectRealm(context.Background(), &schema.InspectRealmOption{})
+	require.NoError(t, err)
+	require.EqualValues(t, func() *schema.Realm {
+		r := &schema.Realm{
+			Schemas: []*schema.Schema{
+				{Name: "ATLAS"},
+				{Name: "TEST"},
+			},
+			Attrs: []schema.Attr{
+				&schema.Charset{V: "AL32UTF8"},
+				&schema.Collation{V: "BINARY"},
+			},
+		}
+		r.Schemas[0].Realm = r
+		r.Schemas[1].Realm = r
+		return r
+	}(), realm)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestDriver_InspectViews(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= :1"))).
+		WithArgs("ATLAS").
+		WillReturnRows(sqltest.Rows(`
+ USERNAME
+----------
+ ATLAS
+`))
+	mk.tables("ATLAS")
+	const def = `SELECT u.ID, u.NAME, o.TOTAL FROM USERS u JOIN ORDERS o ON o.USER_ID = u.ID`
+	m.ExpectQuery(sqltest.Escape(viewsQuery)).
+		WithArgs("ATLAS").
+		WillReturnRows(sqlmock.NewRows([]string{"VIEW_NAME", "TEXT"}).AddRow("USER_ORDERS", def))
+	m.ExpectQuery(sqltest.Escape(mviewsQuery)).
+		WithArgs("ATLAS").
+		WillReturnRows(sqlmock.NewRows([]string{"MVIEW_NAME", "QUERY", "REFRESH_METHOD", "REFRESH_MODE", "BUILD_MODE"}))
+	m.ExpectQuery(sqltest.Escape(columnsQuery)).
+		WithArgs("ATLAS", "USER_ORDERS").
+		WillReturnRows(sqltest.Rows(`
+ COLUMN_NAME | DATA_TYPE | NULLABLE | DATA_DEFAULT | DATA_LENGTH | CHAR_LENGTH | DATA_PRECISION | DATA_SCALE | CHARACTER_SET_NAME | IDENTITY_COLUMN | GENERATION_TYPE | IDENTITY_OPTIONS | COMMENTS | VIRTUAL_COLUMN | HIDDEN_COLUMN | USER_GENERATED | CHAR_USED | COLLATION | DEFAULT_ON_NULL | SEQUENCE_NAME
+-------------+-----------+----------+--------------+-------------+-------------+----------------+------------+--------------------+-----------------+-----------------+------------------+----------+----------------
+ ID          | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |
+ NAME        | VARCHAR2  | Y        |              |          40 |          40 |                |            |                    | NO              |                 |                  |
+ TOTAL       | NUMBER    | Y        |              |          22 |           0 |             12 |          2 |                    | NO              |                 |                  |
+`))
+	mk.noSequences("ATLAS")
+	mk.noSynonyms("ATLAS")
+	s, err := drv.InspectSchema(context.Background(), "ATLAS", nil)
+	require.NoError(t, err)
+	require.Len(t, s.Tables, 1)
+	v := s.Tables[0]
+	require.Equal(t, "USER_ORDERS", v.Name)
+	require.True(t, v.Schema == s)
+	require.Equal(t, []schema.Attr{&View{Def: def}}, v.Attrs)
+	require.EqualValues(t, []*schema.Column{
+		{Name: "ID", Type: &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: "number", Precision: 10}}},
+		{Name: "NAME", Type: &schema.ColumnType{Raw: "VARCHAR2", Null: true, Type: &schema.StringType{T: "varchar2", Size: 40}}},
+		{Name: "TOTAL", Type: &schema.ColumnType{Raw: "NUMBER", Null: true, Type: &schema.DecimalType{T: "number", Precision: 12, Scale: 2}}},
+	}, v.Columns)
+	require.NoError(t, m.ExpectationsWereMet())
+
+	// Iteration errors are not ignored.
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= :1"))).
+		WithArgs("ATLAS").
+		WillReturnRows(sqlmock.NewRows([]string{"USERNAME"}).AddRow("ATLAS"))
+	mk.tables("ATLAS")
+	m.ExpectQuery(sqltest.Escape(viewsQuery)).
+		WithArgs("ATLAS").
+		WillReturnRows(sqlmock.NewRows([]string{"VIEW_NAME", "TEXT"}).AddRow("USER_ORDERS", def).AddRow("USER_POSTS", def).RowError(1, errors.New("connection reset")))
+	_, err = drv.InspectSchema(context.Background(), "ATLAS", nil)
+	require.EqualError(t, err, `oracle: scanning schema "ATLAS" views: connection reset`)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestDriver_InspectMaterializedViews(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= :1"))).
+		WithArgs("ATLAS").
+		WillReturnRows(sqltest.Rows(`
+ USERNAME
+----------
+ ATLAS
+`))
+	mk.tables("ATLAS")
+	m.ExpectQuery(sqltest.Escape(viewsQuery)).
+		WithArgs("ATLAS").
+		WillReturnRows(sqlmock.NewRows([]string{"VIEW_NAME", "TEXT"}))
+	const def = `SELECT USER_ID, COUNT(*) AS CNT FROM ORDERS GROUP BY USER_ID`
+	m.ExpectQuery(sqltest.Escape(mviewsQuery)).
+		WithArgs("ATLAS").
+		WillReturnRows(sqlmock.NewRows([]string{"MVIEW_NAME", "QUERY", "REFRESH_METHOD", "REFRESH_MODE", "BUILD_MODE"}).AddRow("ORDERS_MV", def, "FAST", "COMMIT", "IMMEDIATE"))
+	m.ExpectQuery(sqltest.Escape(columnsQuery)).
+		WithArgs("ATLAS", "ORDERS_MV").
+		WillReturnRows(sqltest.Rows(`
+ COLUMN_NAME | DATA_TYPE | NULLABLE | DATA_DEFAULT | DATA_LENGTH | CHAR_LENGTH | DATA_PRECISION | DATA_SCALE | CHARACTER_SET_NAME | IDENTITY_COLUMN | GENERATION_TYPE | IDENTITY_OPTIONS | COMMENTS | VIRTUAL_COLUMN | HIDDEN_COLUMN | USER_GENERATED | CHAR_USED | COLLATION | DEFAULT_ON_NULL | SEQUENCE_NAME
+-------------+-----------+----------+--------------+-------------+-------------+----------------+------------+--------------------+-----------------+-----------------+------------------+----------+----------------
+ USER_ID     | NUMBER    | N        |              |          22 |           0 |             10 |          0 |                    | NO              |                 |                  |
+ CNT         | NUMBER    | Y        |              |          22 |           0 |                |            |                    | NO              |                 |                  |
+`))
+	mk.noSequences("ATLAS")
+	mk.noSynonyms("ATLAS")
+	s, err := drv.InspectSchema(context.Background(), "ATLAS", nil)
+	require.NoError(t, err)
+	require.Len(t, s.Tables, 1)
+	mv := s.Tables[0]
+	require.Equal(t, "ORDERS_MV", mv.Name)
+	require.Equal(t, []schema.Attr{&MaterializedView{Def: def, RefreshMethod: "FAST", RefreshMode: "COMMIT", BuildMode: "IMMEDIATE"}}, mv.Attrs)
+	require.Len(t, mv.Columns, 2)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestDriver_InspectExcludePrefixes(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db, WithExcludePrefixes("APEX$", "WWV_"))
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= :1"))).
+		WithArgs("ATLAS").
+		WillReturnRows(sqltest.Rows(`
+ USERNAME
+----------
+ ATLAS
+`))
+	mk.tables("ATLAS", "APEX$_ACL", "USERS", "WWV_FLOWS")
+	mk.tableExistsInSchema("ATLAS", "USERS", true)
+	m.ExpectQuery(sqltest.Escape(columnsQuery)).
+		WithArgs("ATLAS", "USERS").
+		WillReturnRows(sqlmock.NewRows(nil))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	m.ExpectQuery(sqltest.Escape(viewsQuery)).
+		WithArgs("ATLAS").
+		WillReturnRows(sqlmock.NewRows([]string{"VIEW_NAME", "TEXT"}).AddRow("WWV_FLOW_ITEMS", "SELECT 1 FROM DUAL"))
+	m.ExpectQuery(sqltest.Escape(mviewsQuery)).
+		WithArgs("ATLAS").
+		WillReturnRows(sqlmock.NewRows([]string{"MVIEW_NAME", "QUERY", "REFRESH_METHOD", "REFRESH_MODE", "BUILD_MODE"}))
+	m.ExpectQuery(sqltest.Escape(sequencesQuery)).
+		WithArgs("ATLAS").
+		WillReturnRows(sqltest.Rows(`
+ SEQUENCE_NAME | MIN_VALUE | MAX_VALUE                    | INCREMENT_BY | CYCLE_FLAG | CACHE_SIZE | LAST_NUMBER | ORDER_FLAG | KEEP_VALUE | SCALE_FLAG | EXTEND_FLAG | SHARDED_FLAG | SESSION_FLAG
+---------------+-----------+------------------------------+--------------+------------+------------+-------------+------------+------------+------------+-------------+--------------+--------------
+ APEX$_SEQ     | 1         | 9999999999999999999999999999 | 1            | N          | 20         | 1           | N          | N          | N          | N           | N            | N
+ USERS_SEQ     | 1         | 9999999999999999999999999999 | 1            | N          | 20         | 1           | N          | N          | N          | N           | N            | N
+`))
+	mk.noSynonyms("ATLAS")
+	s, err := drv.InspectSchema(context.Background(), "ATLAS", nil)
+	require.NoError(t, err)
+	require.Len(t, s.Tables, 1)
+	require.Equal(t, "USERS", s.Tables[0].Name)
+	require.Len(t, s.Attrs, 1)
+	require.Equal(t, "USERS_SEQ", s.Attrs[0].(*Sequence).Name)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestDriver_InspectSequences(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= :1"))).
+		WithArgs("ATLAS").
+		WillReturnRows(sqltest.Rows(`
+ USERNAME
+----------
+ ATLAS
+`))
+	mk.tables("ATLAS")
+	mk.noViews("ATLAS")
+	m.ExpectQuery(sqltest.Escape(sequencesQuery)).
+		WithArgs("ATLAS").
+		WillReturnRows(sqltest.Rows(`
+ SEQUENCE_NAME | MIN_VALUE                    | MAX_VALUE                    | INCREMENT_BY | CYCLE_FLAG | CACHE_SIZE | LAST_NUMBER | ORDER_FLAG | KEEP_VALUE | SCALE_FLAG | EXTEND_FLAG | SHARDED_FLAG | SESSION_FLAG
+---------------+------------------------------+------------------------------+--------------+------------+------------+-------------+------------+------------+------------+-------------+--------------+--------------
+ COUNTDOWN_SEQ | -999999999999999999999999999 | -1                           | -1           | N          | 20         | -1          | N          | N          | N          | N           | N            | Y
+ LEDGER_SEQ    | 0                            | 99999999999999999999         | 1            | N          | 20         | 1           | Y          | Y          | Y          | Y           | N            | N
+ ORDERS_SEQ    | 1                            | 9999999999999999999999999999 | 1            | N          | 0          | 1           | N          | N          | N          | N           | Y            | N
+ TICKETS_SEQ   | 10                           | 1000                         | 5            | Y          | 50         | 100         | Y          | N          | N          | N           | N            | N
+`))
+	mk.noSynonyms("ATLAS")
+	s, err := drv.InspectSchema(context.Background(), "ATLAS", nil)
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Attr{
+		&Sequence{Name: "COUNTDOWN_SEQ", Last: -1, Increment: -1, Cache: 20, Session: true},
+		&Sequence{Name: "LEDGER_SEQ", Last: 1, Increment: 1, Max: math.MaxInt64, Cache: 20, Order: true, Keep: true, Scale: true, Extend: true},
+		&Sequence{Name: "ORDERS_SEQ", Last: 1, Increment: 1, Shard: true},
+		&Sequence{Name: "TICKETS_SEQ", Last: 100, Increment: 5, Min: 10, Max: 1000, Cache: 50, Cycle: true, Order: true},
+	}, s.Attrs)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestDriver_InspectSequencesNoScale(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("12.2.0.1.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= :1"))).
+		WithArgs("ATLAS").
+		WillReturnRows(sqltest.Rows(`
+ USERNAME
+----------
+ ATLAS
+`))
+	mk.tables("ATLAS")
+	mk.noViews("ATLAS")
+	m.ExpectQuery(sqltest.Escape(sequencesQueryNoScale)).
+		WithArgs("ATLAS").
+		WillReturnRows(sqltest.Rows(`
+ SEQUENCE_NAME | MIN_VALUE | MAX_VALUE                    | INCREMENT_BY | CYCLE_FLAG | CACHE_SIZE | LAST_NUMBER | ORDER_FLAG | KEEP_VALUE | SCALE_FLAG | EXTEND_FLAG | SHARDED_FLAG | SESSION_FLAG
+---------------+-----------+------------------------------+--------------+------------+------------+-------------+------------+------------+------------+-------------+--------------+--------------
+ ORDERS_SEQ    | 1         | 9999999999999999999999999999 | 1            | N          | 20         | 1           | Y          | N          | N          | N           | N            | N
+`))
+	mk.noSynonyms("ATLAS")
+	s, err := drv.InspectSchema(context.Background(), "ATLAS", nil)
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Attr{
+		&Sequence{Name: "ORDERS_SEQ", Last: 1, Increment: 1, Cache: 20, Order: true},
+	}, s.Attrs)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestInspect_IdentityExhaustion(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	i := drv.Inspector.(*inspect)
+
+	// A sequence that is about to reach its MAXVALUE.
+	m.ExpectQuery(sqltest.Escape(identitySequenceQuery)).
+		WithArgs("ATLAS", "USERS", "ID").
+		WillReturnRows(sqltest.Rows(`
+ LAST_NUMBER | MIN_VALUE | MAX_VALUE | INCREMENT_BY
+-------------+-----------+-----------+--------------
+ 9990        | 1         | 9999      | 1
+`))
+	n, err := i.IdentityExhaustion(context.Background(), "ATLAS", "USERS", "ID")
+	require.NoError(t, err)
+	require.EqualValues(t, 10, n)
+
+	// Descending sequences are bound by their MINVALUE.
+	m.ExpectQuery(sqltest.Escape(identitySequenceQuery)).
+		WithArgs("ATLAS", "USERS", "ID").
+		WillReturnRows(sqlmock.NewRows([]string{"LAST_NUMBER", "MIN_VALUE", "MAX_VALUE", "INCREMENT_BY"}).
+			AddRow("-95", "-100", "-1", -2))
+	n, err = i.IdentityExhaustion(context.Background(), "ATLAS", "USERS", "ID")
+	require.NoError(t, err)
+	require.EqualValues(t, 3, n)
+
+	// The default MAXVALUE exceeds the int64 range.
+	m.ExpectQuery(sqltest.Escape(identitySequenceQuery)).
+		WithArgs("ATLAS", "USERS", "ID").
+		WillReturnRows(sqltest.Rows(`
+ LAST_NUMBER | MIN_VALUE | MAX_VALUE                    | INCREMENT_BY
+-------------+-----------+------------------------------+--------------
+ 1           | 1         | 9999999999999999999999999999 | 1
+`))
+	n, err = i.IdentityExhaustion(context.Background(), "ATLAS", "USERS", "ID")
+	require.NoError(t, err)
+	require.EqualValues(t, math.MaxInt64, n)
+
+	m.ExpectQuery(sqltest.Escape(identitySequenceQuery)).
+		WithArgs("ATLAS", "USERS", "NAME").
+		WillReturnRows(sqlmock.NewRows([]string{"LAST_NUMBER", "MIN_VALUE", "MAX_VALUE", "INCREMENT_BY"}))
+	_, err = i.IdentityExhaustion(context.Background(), "ATLAS", "USERS", "NAME")
+	require.True(t, schema.IsNotExistError(err))
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestParallel(t *testing.T) {
+	tests := []struct {
+		degree, instances string
+		expect            *Parallel
+	}{
+		{degree: "         1", instances: "         1"},
+		{degree: "1", instances: ""},
+		{degree: "   DEFAULT", instances: "         1", expect: &Parallel{Degree: ParallelDefault, Instances: 1}},
+		{degree: "         8", instances: "         2", expect: &Parallel{Degree: 8, Instances: 2}},
+		{degree: "   DEFAULT", instances: "   DEFAULT", expect: &Parallel{Degree: ParallelDefault, Instances: ParallelDefault}},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.expect, parallel(tt.degree, tt.instances))
+	}
+}
+
+func TestDriver_InspectSynonyms(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= :1"))).
+		WithArgs("ATLAS").
+		WillReturnRows(sqltest.Rows(`
+ USERNAME
+----------
+ ATLAS
+`))
+	mk.tables("ATLAS")
+	mk.noViews("ATLAS")
+	mk.noSequences("ATLAS")
+	m.ExpectQuery(sqltest.Escape(synonymsQuery)).
+		WithArgs("ATLAS").
+		WillReturnRows(sqltest.Rows(`
+ OWNER | SYNONYM_NAME | TABLE_OWNER | TABLE_NAME | DB_LINK
+-------+--------------+-------------+------------+-----------------
+ ATLAS | CUSTOMERS    | CRM         | CUSTOMERS  |
+ ATLAS | RATES        | FINANCE     | FX_RATES   | FIN.EXAMPLE.COM
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(publicSynonymsQuery, "= :1"))).
+		WithArgs("ATLAS").
+		WillReturnRows(sqltest.Rows(`
+ OWNER  | SYNONYM_NAME | TABLE_OWNER | TABLE_NAME | DB_LINK
+--------+--------------+-------------+------------+---------
+ PUBLIC | USERS        | ATLAS       | USERS      |
+`))
+	realm, err := drv.InspectRealm(context.Background(), &schema.InspectRealmOption{Schemas: []string{"ATLAS"}})
+	require.NoError(t, err)
+	require.Equal(t, []schema.Attr{
+		&Synonym{Name: "CUSTOMERS", TableSchema: "CRM", Table: "CUSTOMERS"},
+		&Synonym{Name: "RATES", TableSchema: "FINANCE", Table: "FX_RATES", DBLink: "FIN.EXAMPLE.COM"},
+	}, realm.Schemas[0].Attrs)
+	require.Equal(t, []schema.Attr{
+		&schema.Charset{V: "AL32UTF8"},
+		&schema.Collation{V: "BINARY"},
+		&Synonym{Name: "USERS", Public: true, TableSchema: "ATLAS", Table: "USERS"},
+	}, realm.Attrs)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestInStrings(t *testing.T) {
+	query, args := inStrings([]string{"users"}, tablesQueryArgs, []interface{}{"ATLAS"})
+	require.Equal(t, fmt.Sprintf(tablesQueryArgs, "= :2"), query)
+	require.Equal(t, []interface{}{"ATLAS", "users"}, args)
+
+	query, args = inStrings([]string{"a", "b", "c"}, schemasQueryArgs, nil)
+	require.Equal(t, fmt.Sprintf(schemasQueryArgs, "IN (:1, :2, :3)"), query)
+	require.Equal(t, []interface{}{"a", "b", "c"}, args)
+}
+
+func TestDefaultExpr(t *testing.T) {
+	for x, want := range map[string]schema.Expr{
+		"0":                     &schema.Literal{V: "0"},
+		"-1.5 ":                 &schema.Literal{V: "-1.5"},
+		"2E10":                  &schema.Literal{V: "2E10"},
+		"1.5f":                  &schema.Literal{V: "1.5f"},
+		"'unknown'\n":           &schema.Literal{V: "'unknown'"},
+		"N'name'":               &schema.Literal{V: "N'name'"},
+		"'it''s'":               &schema.Literal{V: "'it''s'"},
+		"SYSTIMESTAMP":          &schema.RawExpr{X: "SYSTIMESTAMP"},
+		"SYSDATE ":              &schema.RawExpr{X: "SYSDATE"},
+		"USER":                  &schema.RawExpr{X: "USER"},
+		"SYS_GUID()":            &schema.RawExpr{X: "SYS_GUID()"},
+		"0x1F":                  &schema.RawExpr{X: "0x1F"},
+		"NaN":                   &schema.RawExpr{X: "NaN"},
+		"'a' || 'b'":            &schema.RawExpr{X: "'a' || 'b'"},
+		"TO_DATE('2020-01-01')": &schema.RawExpr{X: "TO_DATE('2020-01-01')"},
+	} {
+		require.Equal(t, want, defaultExpr(x), x)
+	}
+}
+
+func TestInspect_Names(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	i := &inspect{conn{ExecQuerier: db}}
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "IN (:1, :2)"))).
+		WithArgs("ATLAS", "TEST").
+		WillReturnRows(sqltest.Rows(`
+ USERNAME
+----------
+ ATLAS
+`))
+	schemas, err := i.schemas(context.Background(), &schema.InspectRealmOption{Schemas: []string{"ATLAS", "TEST"}})
+	require.NoError(t, err)
+	require.Equal(t, []*schema.Schema{{Name: "ATLAS"}}, schemas)
+
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(tablesQueryArgs, "= :2"))).
+		WithArgs("ATLAS", "USERS").
+		WillReturnRows(sqltest.Rows(`
+ TABLE_NAME
+------------
+ USERS
+`))
+	names, err := i.tableNames(context.Background(), "ATLAS", &schema.InspectOptions{Tables: []string{"USERS"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"USERS"}, names)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+type mock struct {
+	sqlmock.Sqlmock
+}
+
+func (m mock) version(version string) {
+	m.params(version, SemanticsByte)
+}
+
+func (m mock) params(version, semantics string) {
+	m.ExpectQuery(sqltest.Escape(paramsQuery)).
+		WillReturnRows(sqltest.Rows(`
+ VERSION | NLS_LENGTH_SEMANTICS | NLS_COMP | NLS_SORT | NLS_CHARACTERSET
+---------+----------------------+----------+----------+------------------
+ ` + version + ` | ` + semantics + ` | BINARY | BINARY | AL32UTF8
+`))
+	// The container name is queried for versions >= 12c.
+	if v, err := parseVersion(version); err == nil && semver.Compare("v"+v, "v12.1.0") >= 0 {
+		m.container("ORCLPDB1")
+	}
+}
+
+func (m mock) container(name string) {
+	m.ExpectQuery(sqltest.Escape(containerQuery)).
+		WillReturnRows(sqltest.Rows(`
+ SYS_CONTEXT('USERENV','CON_NAME')
+-----------------------------------
+ ` + name + `
+`))
+}
+
+func (m mock) tableExists(schema, table string, exists bool) {
+	rows := sqlmock.NewRows([]string{"OWNER", "COMMENTS", "DEGREE", "INSTANCES", "DEPENDENCIES", "TEMPORARY", "DURATION", "TYPE_NAME", "DEFAULT_DIRECTORY_NAME", "ACCESS_PARAMETERS", "TABLESPACE_NAME", "IOT_TYPE", "PCT_THRESHOLD", "OVERFLOW_NAME", "MAPPING_NAME"})
+	if exists {
+		rows.AddRow(schema, nil, "         1", "         1", "DISABLED", "N", nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	}
+	m.ExpectQuery(sqltest.Escape(tableQuery)).
+		WithArgs(table).
+		WillReturnRows(rows)
+}
+
+func (m mock) tableExistsInSchema(schema, table string, exists bool) {
+	rows := sqlmock.NewRows([]string{"OWNER", "COMMENTS", "DEGREE", "INSTANCES", "DEPENDENCIES", "TEMPORARY", "DURATION", "TYPE_NAME", "DEFAULT_DIRECTORY_NAME", "ACCESS_PARAMETERS", "TABLESPACE_NAME", "IOT_TYPE", "PCT_THRESHOLD", "OVERFLOW_NAME", "MAPPING_NAME"})
+	if exists {
+		rows.AddRow(schema, nil, "         1", "         1", "DISABLED", "N", nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	}
+	m.ExpectQuery(sqltest.Escape(tableSchemaQuery)).
+		WithArgs(table, schema).
+		WillReturnRows(rows)
+}
+
+func (m mock) schemaTables(schema string, names ...string) {
+	rows := sqlmock.NewRows([]string{"OWNER", "COMMENTS", "DEGREE", "INSTANCES", "DEPENDENCIES", "TEMPORARY", "DURATION", "TYPE_NAME", "DEFAULT_DIRECTORY_NAME", "ACCESS_PARAMETERS", "TABLESPACE_NAME", "IOT_TYPE", "PCT_THRESHOLD", "OVERFLOW_NAME", "MAPPING_NAME", "TABLE_NAME"})
+	for _, name := range names {
+		rows.AddRow(schema, nil, "         1", "         1", "DISABLED", "N", nil, nil, nil, nil, nil, nil, nil, nil, nil, name)
+	}
+	m.ExpectQuery(sqltest.Escape(schemaTablesQuery)).
+		WithArgs(schema).
+		WillReturnRows(rows)
+}
+
+func (m mock) noIndexes() {
+	m.ExpectQuery(sqltest.Escape(indexesQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"INDEX_NAME", "INDEX_TYPE", "UNIQUENESS", "CONSTRAINT_TYPE", "COLUMN_NAME", "DESCEND", "COLUMN_EXPRESSION", "TABLESPACE_NAME", "LOCALITY"}))
+}
+
+func (m mock) noFKs() {
+	m.ExpectQuery(sqltest.Escape(fksQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"CONSTRAINT_NAME", "TABLE_NAME", "COLUMN_NAME", "OWNER", "REFERENCED_TABLE_NAME", "REFERENCED_COLUMN_NAME", "REFERENCED_SCHEMA_NAME", "UPDATE_RULE", "DELETE_RULE"}))
+}
+
+func (m mock) noChecks() {
+	m.ExpectQuery(sqltest.Escape(checksQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"CONSTRAINT_NAME", "SEARCH_CONDITION", "COLUMN_NAME", "DEFERRABLE", "DEFERRED", "STATUS", "VALIDATED"}))
+}
+
+func (m mock) tables(schema string, names ...string) {
+	rows := sqlmock.NewRows([]string{"TABLE_NAME"})
+	for i := range names {
+		rows.AddRow(names[i])
+	}
+	m.ExpectQuery(sqltest.Escape(tablesQuery)).
+		WithArgs(schema).
+		WillReturnRows(rows)
+}
+
+func (m mock) noSequences(schema string) {
+	m.ExpectQuery(sqltest.Escape(sequencesQuery)).
+		WithArgs(schema).
+		WillReturnRows(sqlmock.NewRows([]string{"SEQUENCE_NAME", "MIN_VALUE", "MAX_VALUE", "INCREMENT_BY", "CYCLE_FLAG", "CACHE_SIZE", "LAST_NUMBER", "ORDER_FLAG", "KEEP_VALUE", "SCALE_FLAG", "EXTEND_FLAG", "SHARDED_FLAG", "SESSION_FLAG"}))
+}
+
+func (m mock) noViews(schema string) {
+	m.ExpectQuery(sqltest.Escape(viewsQuery)).
+		WithArgs(schema).
+		WillReturnRows(sqlmock.NewRows([]string{"VIEW_NAME", "TEXT"}))
+	m.ExpectQuery(sqltest.Escape(mviewsQuery)).
+		WithArgs(schema).
+		WillReturnRows(sqlmock.NewRows([]string{"MVIEW_NAME", "QUERY", "REFRESH_METHOD", "REFRESH_MODE", "BUILD_MODE"}))
+}
+
+func (m mock) noSynonyms(schema string) {
+	m.ExpectQuery(sqltest.Escape(synonymsQuery)).
+		WithArgs(schema).
+		WillReturnRows(sqlmock.NewRows([]string{"OWNER", "SYNONYM_NAME", "TABLE_OWNER", "TABLE_NAME", "DB_LINK"}))
+}
+
+func BenchmarkInspectColumns(b *testing.B) {
+	const (
+		tables  = 50
+		latency = 100 * time.Microsecond
+	)
+	var (
+		ctx    = context.Background()
+		s      = &schema.Schema{Name: "ATLAS"}
+		header = []string{"COLUMN_NAME", "DATA_TYPE", "NULLABLE", "DATA_DEFAULT", "DATA_LENGTH", "CHAR_LENGTH", "DATA_PRECISION", "DATA_SCALE", "CHARACTER_SET_NAME", "IDENTITY_COLUMN", "GENERATION_TYPE", "IDENTITY_OPTIONS", "COMMENTS", "VIRTUAL_COLUMN", "HIDDEN_COLUMN", "USER_GENERATED", "CHAR_USED", "COLLATION", "DEFAULT_ON_NULL", "SEQUENCE_NAME"}
+		row    = []driver.Value{"ID", "NUMBER", "N", nil, 22, 0, 10, 0, nil, "NO", nil, nil, nil, "NO", "NO", "YES", nil, nil, nil, nil}
+	)
+	connect := func(b *testing.B) (*inspect, sqlmock.Sqlmock) {
+		db, m, err := sqlmock.New()
+		require.NoError(b, err)
+		mock{m}.version("19.0.0.0.0")
+		drv, err := Open(db)
+		require.NoError(b, err)
+		return drv.Inspector.(*inspect), m
+	}
+	b.Run("PerTable", func(b *testing.B) {
+		i, m := connect(b)
+		for n := 0; n < b.N; n++ {
+			b.StopTimer()
+			for j := 0; j < tables; j++ {
+				m.ExpectQuery(sqltest.Escape(columnsQuery)).
+					WillDelayFor(latency).
+					WillReturnRows(sqlmock.NewRows(header).AddRow(row...))
+			}
+			b.StartTimer()
+			for j := 0; j < tables; j++ {
+				if err := i.columns(ctx, &schema.Table{Name: fmt.Sprintf("T%d", j), Schema: s}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("Schema", func(b *testing.B) {
+		i, m := connect(b)
+		for n := 0; n < b.N; n++ {
+			b.StopTimer()
+			rows := sqlmock.NewRows(append(header, "TABLE_NAME"))
+			for j := 0; j < tables; j++ {
+				rows.AddRow(append(row, fmt.Sprintf("T%d", j))...)
+			}
+			m.ExpectQuery(sqltest.Escape(schemaColumnsQuery)).
+				WillDelayFor(latency).
+				WillReturnRows(rows)
+			b.StartTimer()
+			if _, err := i.schemaColumns(ctx, s.Name); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkInspectTables(b *testing.B) {
+	const (
+		tables  = 200
+		latency = 100 * time.Microsecond
+	)
+	var (
+		ctx   = context.Background()
+		names = make([]string, tables)
+	)
+	for j := range names {
+		names[j] = fmt.Sprintf("T%d", j)
+	}
+	// Tables are inspected by an 11g database, with their last DDL time,
+	// to include the queries of the LOB storage, the last DDL time and the
+	// triggers that emulate identity columns.
+	connect := func(b *testing.B) (*inspect, mock) {
+		db, m, err := sqlmock.New()
+		require.NoError(b, err)
+		mock{m}.version("11.2.0.4.0")
+		drv, err := Open(db, WithLastDDL())
+		require.NoError(b, err)
+		return drv.Inspector.(*inspect), mock{m}
+	}
+	// rows returns the given rows of a table, or the rows
+	// of each table if the query is a schema-wide query.
+	rows := func(header []string, batch bool, rs ...[]driver.Value) *sqlmock.Rows {
+		if !batch {
+			rows := sqlmock.NewRows(header)
+			for _, r := range rs {
+				rows.AddRow(r...)
+			}
+			return rows
+		}
+		rows := sqlmock.NewRows(append(header, "TABLE_NAME"))
+		for _, name := range names {
+			for _, r := range rs {
+				rows.AddRow(append(r, name)...)
+			}
+		}
+		return rows
+	}
+	var (
+		tablesH   = []string{"OWNER", "COMMENTS", "DEGREE", "INSTANCES", "DEPENDENCIES", "TEMPORARY", "DURATION", "TYPE_NAME", "DEFAULT_DIRECTORY_NAME", "ACCESS_PARAMETERS", "TABLESPACE_NAME", "IOT_TYPE", "PCT_THRESHOLD", "OVERFLOW_NAME", "MAPPING_NAME"}
+		tablesR   = []driver.Value{"ATLAS", nil, "         1", "         1", "DISABLED", "N", nil, nil, nil, nil, "USERS", nil, nil, nil, nil}
+		columnsH  = []string{"COLUMN_NAME", "DATA_TYPE", "NULLABLE", "DATA_DEFAULT", "DATA_LENGTH", "CHAR_LENGTH", "DATA_PRECISION", "DATA_SCALE", "CHARACTER_SET_NAME", "IDENTITY_COLUMN", "GENERATION_TYPE", "IDENTITY_OPTIONS", "COMMENTS", "VIRTUAL_COLUMN", "HIDDEN_COLUMN", "USER_GENERATED", "CHAR_USED", "COLLATION", "DEFAULT_ON_NULL", "SEQUENCE_NAME"}
+		columnsR  = []driver.Value{"ID", "NUMBER", "N", nil, 22, 0, 10, 0, nil, "NO", nil, nil, nil, "NO", "NO", "YES", nil, nil, nil, nil}
+		lobColR   = []driver.Value{"BODY", "CLOB", "Y", nil, 4000, 0, nil, nil, nil, "NO", nil, nil, nil, "NO", "NO", "YES", nil, nil, nil, nil}
+		indexesH  = []string{"INDEX_NAME", "INDEX_TYPE", "UNIQUENESS", "CONSTRAINT_TYPE", "COLUMN_NAME", "DESCEND", "COLUMN_EXPRESSION", "TABLESPACE_NAME", "LOCALITY"}
+		indexesR  = []driver.Value{"PK", "NORMAL", "UNIQUE", "P", "ID", "ASC", nil, nil, nil}
+		fksH      = []string{"CONSTRAINT_NAME", "TABLE_NAME", "COLUMN_NAME", "OWNER", "REFERENCED_TABLE_NAME", "REFERENCED_COLUMN_NAME", "REFERENCED_SCHEMA_NAME", "UPDATE_RULE", "DELETE_RULE"}
+		checksH   = []string{"CONSTRAINT_NAME", "SEARCH_CONDITION", "COLUMN_NAME", "DEFERRABLE", "DEFERRED", "STATUS", "VALIDATED"}
+		checksR   = []driver.Value{"CK", "ID > 0", "ID", "NOT DEFERRABLE", "IMMEDIATE", "ENABLED", "VALIDATED"}
+		lobsH     = []string{"COLUMN_NAME", "SECUREFILE", "IN_ROW", "PCTVERSION", "RETENTION"}
+		lobsR     = []driver.Value{"BODY", "YES", "YES", nil, 900}
+		triggersH = []string{"TRIGGER_NAME", "WHEN_CLAUSE", "INCREMENT_BY", "LAST_NUMBER"}
+		lastDDLH  = []string{"LAST_DDL_TIME"}
+		lastDDLR  = []driver.Value{"2026-10-14 09:30:15"}
+	)
+	b.Run("PerTable", func(b *testing.B) {
+		i, m := connect(b)
+		for n := 0; n < b.N; n++ {
+			b.StopTimer()
+			for range names {
+				m.ExpectQuery(sqltest.Escape(tableSchemaQuery)).WillDelayFor(latency).WillReturnRows(rows(tablesH, false, tablesR))
+				m.ExpectQuery(sqltest.Escape(columnsQueryNoIdentity)).WillDelayFor(latency).WillReturnRows(rows(columnsH, false, columnsR, lobColR))
+				m.ExpectQuery(sqltest.Escape(indexesQuery)).WillDelayFor(latency).WillReturnRows(rows(indexesH, false, indexesR))
+				m.ExpectQuery(sqltest.Escape(fksQuery)).WillDelayFor(latency).WillReturnRows(rows(fksH, false))
+				m.ExpectQuery(sqltest.Escape(checksQuery)).WillDelayFor(latency).WillReturnRows(rows(checksH, false, checksR))
+				m.ExpectQuery(sqltest.Escape(lobsQuery)).WillDelayFor(latency).WillReturnRows(rows(lobsH, false, lobsR))
+				m.ExpectQuery(sqltest.Escape(identityTriggersQuery)).WillDelayFor(latency).WillReturnRows(rows(triggersH, false))
+				m.ExpectQuery(sqltest.Escape(lastDDLQuery)).WillDelayFor(latency).WillReturnRows(rows(lastDDLH, false, lastDDLR))
+			}
+			b.StartTimer()
+			s := &schema.Schema{Name: "ATLAS"}
+			for _, name := range names {
+				if _, err := i.inspectTable(ctx, name, &schema.InspectTableOptions{Schema: s.Name}, s); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("Batched", func(b *testing.B) {
+		i, m := connect(b)
+		for n := 0; n < b.N; n++ {
+			b.StopTimer()
+			m.ExpectQuery(sqltest.Escape(schemaTablesQuery)).WillDelayFor(latency).WillReturnRows(rows(tablesH, true, tablesR))
+			m.ExpectQuery(sqltest.Escape(schemaColumnsQueryNoIdentity)).WillDelayFor(latency).WillReturnRows(rows(columnsH, true, columnsR, lobColR))
+			m.ExpectQuery(sqltest.Escape(schemaIndexesQuery)).WillDelayFor(latency).WillReturnRows(rows(indexesH, true, indexesR))
+			m.ExpectQuery(sqltest.Escape(schemaFKsQuery)).WillDelayFor(latency).WillReturnRows(sqlmock.NewRows(fksH))
+			m.ExpectQuery(sqltest.Escape(schemaChecksQuery)).WillDelayFor(latency).WillReturnRows(rows(checksH, true, checksR))
+			m.ExpectQuery(sqltest.Escape(schemaLobsQuery)).WillDelayFor(latency).WillReturnRows(rows(lobsH, true, lobsR))
+			m.ExpectQuery(sqltest.Escape(schemaIdentityTriggersQuery)).WillDelayFor(latency).WillReturnRows(rows(triggersH, true))
+			m.ExpectQuery(sqltest.Escape(schemaLastDDLQuery)).WillDelayFor(latency).WillReturnRows(rows(lastDDLH, true, lastDDLR))
+			b.StartTimer()
+			if err := i.inspectTables(ctx, &schema.Schema{Name: "ATLAS"}, names, nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
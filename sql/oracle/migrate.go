@@ -0,0 +1,2250 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"ariga.io/atlas/sql/internal/sqlx"
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/schema"
+)
+
+// A planApply provides migration capabilities for schema elements.
+type planApply struct{ conn }
+
+// PlanChanges returns a migration plan for the given schema changes.
+func (p *planApply) PlanChanges(ctx context.Context, name string, changes []schema.Change) (*migrate.Plan, error) {
+	s := &state{
+		conn: p.conn,
+		Plan: migrate.Plan{
+			Name:       name,
+			Reversible: true,
+			// DDL statements in Oracle are committed implicitly,
+			// and therefore, cannot be executed in a transaction.
+			Transactional: false,
+		},
+	}
+	if err := s.plan(ctx, changes); err != nil {
+		return nil, err
+	}
+	for _, c := range s.Changes {
+		if c.Reverse == "" {
+			s.Reversible = false
+		}
+	}
+	return &s.Plan, nil
+}
+
+// ApplyChanges applies the changes on the database. An error is returned
+// if the driver is unable to produce a plan to do so, or one of the statements
+// is failed or unsupported.
+func (p *planApply) ApplyChanges(ctx context.Context, changes []schema.Change) error {
+	return sqlx.ApplyChanges(ctx, changes, p)
+}
+
+// state represents the state of a planning. It is not part of
+// planApply so that multiple planning/applying can be called
+// in parallel.
+type state struct {
+	conn
+	migrate.Plan
+	// The current schema of the session in session schema mode.
+	current string
+}
+
+// plan builds the statements for the given changes. An error is
+// returned if one of the changes is not supported.
+func (s *state) plan(_ context.Context, changes []schema.Change) error {
+	planned, err := sqlx.DetachCycles(changes)
+	if err != nil {
+		return err
+	}
+	for _, c := range planned {
+		if s.sessionSchema {
+			s.setSchema(changeSchema(c))
+		}
+		switch c := c.(type) {
+		case *schema.AddTable:
+			err = s.addTable(c)
+		case *schema.DropTable:
+			err = s.dropTable(c)
+		case *schema.ModifyTable:
+			err = s.modifyTable(c)
+		case *schema.ModifySchema:
+			err = s.modifySchema(c)
+		default:
+			err = fmt.Errorf("oracle: unsupported change %T", c)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setSchema switches the current schema of the session to the given schema,
+// if it is not the current one. Reverse statements do not depend on the session
+// schema, as they are qualified, and the statement is used as its own reverse.
+func (s *state) setSchema(name string) {
+	if name == "" || name == s.current {
+		return
+	}
+	s.current = name
+	cmd := Build("ALTER SESSION SET CURRENT_SCHEMA =").Ident(name).String()
+	s.append(&migrate.Change{
+		Cmd:     cmd,
+		Reverse: cmd,
+		Comment: fmt.Sprintf("set the current schema to %q", name),
+	})
+}
+
+// changeSchema returns the name of the schema the given change applies to.
+func changeSchema(c schema.Change) string {
+	var (
+		t *schema.Table
+		s *schema.Schema
+	)
+	switch c := c.(type) {
+	case *schema.AddTable:
+		t = c.T
+	case *schema.DropTable:
+		t = c.T
+	case *schema.ModifyTable:
+		t = c.T
+	case *schema.ModifySchema:
+		s = c.S
+	}
+	if t != nil {
+		s = t.Schema
+	}
+	if s == nil {
+		return ""
+	}
+	return s.Name
+}
+
+// addTable builds the statements for creating a table in a schema.
+func (s *state) addTable(add *schema.AddTable) error {
+	// IF NOT EXISTS is not supported by Oracle (< 23c).
+	if sqlx.Has(add.Extra, &schema.IfNotExists{}) {
+		return fmt.Errorf("oracle: IF NOT EXISTS is not supported for table %q", add.T.Name)
+	}
+	if v := viewOf(add.T); v != nil {
+		s.append(&migrate.Change{
+			Cmd:     viewCreate(s.build("CREATE"), add.T, v),
+			Source:  add,
+			Comment: fmt.Sprintf("create %q view", add.T.Name),
+			Reverse: viewDrop(Build("DROP"), add.T, v),
+		})
+		return nil
+	}
+	var (
+		err error
+		b   = s.build("CREATE")
+	)
+	if sqlx.Has(add.T.Attrs, &Temporary{}) {
+		b.P("GLOBAL TEMPORARY")
+	}
+	b.P("TABLE").Table(add.T)
+	b.Wrap(func(b *sqlx.Builder) {
+		err = b.MapCommaErr(add.T.Columns, func(i int, b *sqlx.Builder) error {
+			return s.column(b, add.T.Columns[i])
+		})
+		if pk := add.T.PrimaryKey; pk != nil && err == nil && !s.separatePrimaryKey(add.T) {
+			b.Comma()
+			if pk.Name != "" && !generatedName(pk.Name) {
+				b.P("CONSTRAINT").Ident(pk.Name)
+			}
+			b.P("PRIMARY KEY")
+			err = s.indexParts(b, pk.Parts)
+			// The index of the primary key is partitioned with the table.
+			if sqlx.Has(pk.Attrs, &LocalIndex{}) {
+				b.P("USING INDEX LOCAL")
+			}
+		}
+		for _, fk := range add.T.ForeignKeys {
+			if err == nil {
+				err = s.fk(b.Comma(), fk)
+			}
+		}
+		for _, attr := range add.T.Attrs {
+			if c, ok := attr.(*schema.Check); ok {
+				b.Comma()
+				check(b, c)
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if m := temporaryOf(add.T); m != "" {
+		b.P("ON COMMIT", m, "ROWS")
+	}
+	org := &Organization{}
+	if organizationOf(add.T) == OrganizationIndex {
+		if add.T.PrimaryKey == nil {
+			return fmt.Errorf("oracle: index-organized table %q must have a primary key", add.T.Name)
+		}
+		sqlx.Has(add.T.Attrs, org)
+		b.P("ORGANIZATION INDEX")
+	}
+	// Physical attributes are omitted from portable DDL.
+	if ts := (&Tablespace{}); sqlx.Has(add.T.Attrs, ts) && !s.portable {
+		b.P("TABLESPACE").Ident(ts.Name)
+	}
+	if org.PctThreshold > 0 && !s.portable {
+		b.P("PCTTHRESHOLD", strconv.Itoa(org.PctThreshold))
+	}
+	if org.Mapping {
+		b.P("MAPPING TABLE")
+	}
+	if org.Overflow {
+		b.P("OVERFLOW")
+	}
+	if ext := (&External{}); sqlx.Has(add.T.Attrs, ext) {
+		external(b, ext)
+	}
+	s.lobStorage(b, add.T.Columns...)
+	if sqlx.Has(add.T.Attrs, &Parallel{}) && !s.portable {
+		b.P(parallelClause(parallelOf(add.T.Attrs)))
+	}
+	if sqlx.Has(add.T.Attrs, &RowDependencies{}) && !s.portable {
+		b.P("ROWDEPENDENCIES")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.String(),
+		Source:  add,
+		Comment: fmt.Sprintf("create %q table", add.T.Name),
+		Reverse: Build("DROP TABLE").Table(add.T).String(),
+	})
+	if s.separatePrimaryKey(add.T) {
+		if err := s.addPrimaryKey(add.T); err != nil {
+			return err
+		}
+	}
+	for _, c := range add.T.Columns {
+		if err := s.identityTrigger(add.T, c); err != nil {
+			return err
+		}
+	}
+	if err := s.addIndexes(add.T, add.T.Indexes...); err != nil {
+		return err
+	}
+	s.addComments(add.T)
+	for _, a := range audits(add.T.Attrs) {
+		s.append(s.auditTable(add.T, nil, a))
+	}
+	for _, p := range ilmPolicies(add.T.Attrs) {
+		c, err := s.ilmTable(add.T, nil, p)
+		if err != nil {
+			return err
+		}
+		s.append(c)
+	}
+	return nil
+}
+
+// separatePrimaryKey reports if the primary key of the given table is created
+// separately from the table. See WithSeparatePrimaryKeys for more info.
+func (s *state) separatePrimaryKey(t *schema.Table) bool {
+	return s.separatePK && t.PrimaryKey != nil && organizationOf(t) != OrganizationIndex
+}
+
+// addPrimaryKey builds the statements for creating the unique index of the table
+// primary key, and adding the primary key constraint using it. Unnamed primary
+// keys are backed by an index that is named after their table.
+func (s *state) addPrimaryKey(t *schema.Table) error {
+	var (
+		pk   = t.PrimaryKey
+		name = pk.Name
+	)
+	if name == "" || generatedName(name) {
+		name = t.Name + "_PK"
+	}
+	idx := &schema.Index{Name: name, Unique: true, Table: t, Parts: pk.Parts, Attrs: pk.Attrs}
+	if err := s.addIndexes(t, idx); err != nil {
+		return err
+	}
+	b := s.build("ALTER TABLE").Table(t).P("ADD")
+	if pk.Name != "" && !generatedName(pk.Name) {
+		b.P("CONSTRAINT").Ident(pk.Name)
+	}
+	b.P("PRIMARY KEY")
+	if err := s.indexParts(b, pk.Parts); err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.P("USING INDEX").Table(objectOf(t, name)).String(),
+		Reverse: Build("ALTER TABLE").Table(t).P("DROP PRIMARY KEY").String(),
+		Comment: fmt.Sprintf("Add primary key to table: %q", t.Name),
+	})
+	return nil
+}
+
+// external writes the ORGANIZATION EXTERNAL clause of an external table.
+func external(b *sqlx.Builder, ext *External) {
+	b.P("ORGANIZATION EXTERNAL").Wrap(func(b *sqlx.Builder) {
+		if ext.Type != "" {
+			b.P("TYPE", ext.Type)
+		}
+		if ext.Directory != "" {
+			b.P("DEFAULT DIRECTORY").Ident(ext.Directory)
+		}
+		if ext.Params != "" {
+			b.P("ACCESS PARAMETERS").Wrap(func(b *sqlx.Builder) {
+				b.WriteString(ext.Params)
+			})
+		}
+		if len(ext.Locations) > 0 {
+			b.P("LOCATION").Wrap(func(b *sqlx.Builder) {
+				b.MapComma(ext.Locations, func(i int, b *sqlx.Builder) {
+					l := ext.Locations[i]
+					if l.Directory != "" {
+						b.Ident(l.Directory)
+						b.Truncate(b.Len() - 1)
+						b.WriteByte(':')
+					}
+					b.WriteString(quote(l.Name))
+				})
+			})
+		}
+	})
+}
+
+// modifySchema builds the statements for bringing the schema attributes into
+// their modified state. Standalone sequences and collection types are the only
+// schema attributes that are supported by Oracle.
+func (s *state) modifySchema(modify *schema.ModifySchema) error {
+	for _, change := range modify.Changes {
+		switch change := change.(type) {
+		case *schema.AddAttr:
+			switch a := change.A.(type) {
+			case *Sequence:
+				s.createSequence(modify.S, a)
+			case *Collection:
+				if err := s.createCollection(modify.S, a); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("oracle: unexpected schema AddAttr: %T", change.A)
+			}
+		case *schema.DropAttr:
+			switch a := change.A.(type) {
+			case *Sequence:
+				s.dropSequence(modify.S, a)
+			case *Collection:
+				if err := s.dropCollection(modify.S, a); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("oracle: unexpected schema DropAttr: %T", change.A)
+			}
+		case *schema.ModifyAttr:
+			from, ok1 := change.From.(*Sequence)
+			to, ok2 := change.To.(*Sequence)
+			if !ok1 || !ok2 {
+				return fmt.Errorf("oracle: unexpected schema ModifyAttr: %T, %T", change.From, change.To)
+			}
+			s.alterSequence(modify.S, from, to)
+		default:
+			return fmt.Errorf("oracle: unsupported ModifySchema change %T", change)
+		}
+	}
+	return nil
+}
+
+func (s *state) createSequence(sc *schema.Schema, seq *Sequence) {
+	s.append(&migrate.Change{
+		Cmd:     seqCreate(s.build("CREATE SEQUENCE"), sc, seq),
+		Reverse: Build("DROP SEQUENCE").Table(seqObject(sc, seq)).String(),
+		Comment: fmt.Sprintf("create %q sequence", seq.Name),
+	})
+}
+
+func (s *state) dropSequence(sc *schema.Schema, seq *Sequence) {
+	s.append(&migrate.Change{
+		Cmd:     s.build("DROP SEQUENCE").Table(seqObject(sc, seq)).String(),
+		Reverse: seqCreate(Build("CREATE SEQUENCE"), sc, seq),
+		Comment: fmt.Sprintf("drop %q sequence", seq.Name),
+	})
+}
+
+func (s *state) createCollection(sc *schema.Schema, c *Collection) error {
+	def, err := formatCollection(c.T)
+	if err != nil {
+		return err
+	}
+	obj := &schema.Table{Name: c.T.T, Schema: sc}
+	s.append(&migrate.Change{
+		Cmd:     s.build("CREATE TYPE").Table(obj).P("AS", def).String(),
+		Reverse: Build("DROP TYPE").Table(obj).String(),
+		Comment: fmt.Sprintf("create %q type", c.T.T),
+	})
+	return nil
+}
+
+func (s *state) dropCollection(sc *schema.Schema, c *Collection) error {
+	def, err := formatCollection(c.T)
+	if err != nil {
+		return err
+	}
+	obj := &schema.Table{Name: c.T.T, Schema: sc}
+	s.append(&migrate.Change{
+		Cmd:     s.build("DROP TYPE").Table(obj).String(),
+		Reverse: Build("CREATE TYPE").Table(obj).P("AS", def).String(),
+		Comment: fmt.Sprintf("drop %q type", c.T.T),
+	})
+	return nil
+}
+
+// alterSequence builds the statements for changing the sequence options.
+// The START WITH value cannot be altered, and therefore, changing it
+// requires recreating the sequence.
+func (s *state) alterSequence(sc *schema.Schema, from, to *Sequence) {
+	if from.Start != 0 && to.Start != 0 && from.Start != to.Start {
+		s.dropSequence(sc, from)
+		s.createSequence(sc, to)
+		return
+	}
+	opts, ropts := seqAlterOptions(from, to), seqAlterOptions(to, from)
+	if len(opts) == 0 {
+		return
+	}
+	s.append(&migrate.Change{
+		Cmd:     s.build("ALTER SEQUENCE").Table(seqObject(sc, to)).P(opts...).String(),
+		Reverse: Build("ALTER SEQUENCE").Table(seqObject(sc, from)).P(ropts...).String(),
+		Comment: fmt.Sprintf("modify %q sequence", to.Name),
+	})
+}
+
+// seqCreate returns the CREATE SEQUENCE statement of a standalone sequence,
+// using the given builder that holds the CREATE SEQUENCE phrase.
+func seqCreate(b *sqlx.Builder, sc *schema.Schema, seq *Sequence) string {
+	// Inspected sequences are re-created from the value they continue from.
+	if seq.Start == 0 && seq.Last != 0 {
+		c := *seq
+		c.Start = seq.Last
+		seq = &c
+	}
+	b.Table(seqObject(sc, seq)).P(seqOptions(seq)...)
+	switch seq.Cache {
+	case 0:
+		b.P("NOCACHE")
+	case defaultSeqCache:
+	default:
+		b.P("CACHE", strconv.FormatInt(seq.Cache, 10))
+	}
+	if seq.Cycle {
+		b.P("CYCLE")
+	}
+	if seq.Keep {
+		b.P("KEEP")
+	}
+	if seq.Scale {
+		b.P("SCALE")
+		if seq.Extend {
+			b.P("EXTEND")
+		}
+	}
+	if seq.Shard {
+		b.P("SHARD")
+		if seq.Extend {
+			b.P("EXTEND")
+		}
+	}
+	if seq.Session {
+		b.P("SESSION")
+	}
+	return b.String()
+}
+
+// seqAlterOptions returns the options for altering the sequence from one state to the other.
+func seqAlterOptions(from, to *Sequence) []string {
+	var opts []string
+	flag := func(from, to bool, on, off string) {
+		switch {
+		case from == to:
+		case to:
+			opts = append(opts, on)
+		default:
+			opts = append(opts, off)
+		}
+	}
+	if from.Increment != to.Increment {
+		opts = append(opts, "INCREMENT BY "+strconv.FormatInt(to.Increment, 10))
+	}
+	switch {
+	case from.Min == to.Min:
+	case to.Min == 0:
+		opts = append(opts, "NOMINVALUE")
+	default:
+		opts = append(opts, "MINVALUE "+strconv.FormatInt(to.Min, 10))
+	}
+	switch {
+	case from.Max == to.Max:
+	case to.Max == 0:
+		opts = append(opts, "NOMAXVALUE")
+	default:
+		opts = append(opts, "MAXVALUE "+strconv.FormatInt(to.Max, 10))
+	}
+	switch {
+	case from.Cache == to.Cache:
+	case to.Cache == 0:
+		opts = append(opts, "NOCACHE")
+	default:
+		opts = append(opts, "CACHE "+strconv.FormatInt(to.Cache, 10))
+	}
+	flag(from.Cycle, to.Cycle, "CYCLE", "NOCYCLE")
+	flag(from.Order, to.Order, "ORDER", "NOORDER")
+	flag(from.Keep, to.Keep, "KEEP", "NOKEEP")
+	// The EXTEND option is shared by the SCALE and SHARD clauses.
+	for _, c := range []struct {
+		from, to bool
+		kw       string
+	}{
+		{from.Scale, to.Scale, "SCALE"},
+		{from.Shard, to.Shard, "SHARD"},
+	} {
+		switch {
+		case c.from != c.to && !c.to:
+			opts = append(opts, "NO"+c.kw)
+		case c.from != c.to, c.to && from.Extend != to.Extend:
+			if to.Extend {
+				opts = append(opts, c.kw+" EXTEND")
+			} else {
+				opts = append(opts, c.kw+" NOEXTEND")
+			}
+		}
+	}
+	flag(from.Session, to.Session, "SESSION", "GLOBAL")
+	return opts
+}
+
+// seqObject returns a table that shares its qualified name with the sequence.
+func seqObject(s *schema.Schema, seq *Sequence) *schema.Table {
+	return &schema.Table{Name: seq.Name, Schema: s}
+}
+
+// Purge is a clause for schema.DropTable changes that drops the table
+// immediately, instead of moving it to the recycle bin. Tables dropped
+// without it can be restored using FLASHBACK TABLE ... TO BEFORE DROP.
+//
+//	&schema.DropTable{T: t, Extra: []schema.Clause{&oracle.Purge{}}}
+type Purge struct {
+	schema.Clause
+}
+
+// dropTable builds the statement for dropping a table from a schema.
+func (s *state) dropTable(drop *schema.DropTable) error {
+	// IF EXISTS is not supported by Oracle (< 23c).
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		return fmt.Errorf("oracle: IF EXISTS is not supported for table %q", drop.T.Name)
+	}
+	if v := viewOf(drop.T); v != nil {
+		s.append(&migrate.Change{
+			Cmd:     viewDrop(s.build("DROP"), drop.T, v),
+			Source:  drop,
+			Comment: fmt.Sprintf("drop %q view", drop.T.Name),
+			Reverse: viewCreate(Build("CREATE"), drop.T, v),
+		})
+		return nil
+	}
+	b := s.build("DROP TABLE").Table(drop.T)
+	comment := fmt.Sprintf("drop %q table", drop.T.Name)
+	if sqlx.Has(drop.Extra, &Purge{}) {
+		b.P("PURGE")
+		comment += " bypassing the recycle bin"
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.String(),
+		Source:  drop,
+		Comment: comment,
+	})
+	// The triggers of emulated identity columns (< 12c) are
+	// dropped with the table, but their sequences are not.
+	if !s.supportsIdentity() {
+		for _, c := range drop.T.Columns {
+			if _, ok := identity(c.Attrs); ok {
+				seq, _ := identityObjects(drop.T, c)
+				s.append(&migrate.Change{
+					Cmd:     s.build("DROP SEQUENCE").Table(seq).String(),
+					Source:  drop,
+					Comment: fmt.Sprintf("drop sequence of identity column %q of table %q", c.Name, drop.T.Name),
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// modifyTable builds the statements that bring the table into its modified state.
+// Unlike other databases, Oracle does not allow mixing column and constraint
+// clauses in one ALTER TABLE statement, and therefore, each change is planned
+// as a separate statement.
+func (s *state) modifyTable(modify *schema.ModifyTable) error {
+	if viewOf(modify.T) != nil {
+		s.modifyView(modify)
+		return nil
+	}
+	var (
+		changes     []schema.Change
+		addI, dropI []*schema.Index
+		comments    []*migrate.Change
+		auditing    []*migrate.Change
+		lobs        []*migrate.Change
+		visibility  []*migrate.Change
+		identities  []*migrate.Change
+		states      []*migrate.Change
+		parallels   []*migrate.Change
+		ilms        []*migrate.Change
+	)
+	for _, change := range skipAutoChanges(modify.Changes) {
+		switch change := change.(type) {
+		case *schema.AddAttr, *schema.ModifyAttr, *schema.DropAttr:
+			// Moving the table to another tablespace.
+			if isTablespaceChange(change) {
+				if !s.portable {
+					changes = append(changes, change)
+				}
+				continue
+			}
+			if from, to, ok := parallelChange(change); ok {
+				if !s.portable {
+					parallels = append(parallels, &migrate.Change{
+						Cmd:     s.build("ALTER TABLE").Table(modify.T).P(parallelClause(to)).String(),
+						Reverse: Build("ALTER TABLE").Table(modify.T).P(parallelClause(from)).String(),
+						Comment: fmt.Sprintf("change parallel mode of %q table", modify.T.Name),
+					})
+				}
+				continue
+			}
+			if from, to, ok := auditChange(change); ok {
+				auditing = append(auditing, s.auditTable(modify.T, from, to))
+				continue
+			}
+			if from, to, ok := ilmChange(change); ok {
+				c, err := s.ilmTable(modify.T, from, to)
+				if err != nil {
+					return err
+				}
+				ilms = append(ilms, c)
+				continue
+			}
+			if from, to, ok := fkStateChange(change); ok {
+				cs, err := s.modifyFKState(modify, from, to)
+				if err != nil {
+					return err
+				}
+				states = append(states, cs...)
+				continue
+			}
+			from, to, err := commentChange(change)
+			if err != nil {
+				return err
+			}
+			comments = append(comments, s.tableComment(modify.T, to, from))
+		case *schema.AddColumn:
+			if c := (schema.Comment{}); sqlx.Has(change.C.Attrs, &c) && c.Text != "" {
+				comments = append(comments, s.columnComment(modify.T, change.C, c.Text, ""))
+			}
+			changes = append(changes, change)
+		case *schema.ModifyColumn:
+			// The LOB storage, the visibility, the emulated identity (< 12c) and
+			// the NOT NULL state of a column are changed by statements of their own.
+			// Other attributes are changed by the MODIFY clause of the column.
+			if change.Change.Is(schema.ChangeAttr) {
+				if lobStorageChanged(change.From.Attrs, change.To.Attrs) {
+					c, err := s.modifyLOB(modify.T, change.From, change.To)
+					if err != nil {
+						return err
+					}
+					lobs = append(lobs, c)
+				}
+				if notNullStateChanged(change.From, change.To) {
+					states = append(states, s.modifyNotNullState(modify.T, change.From, change.To)...)
+				}
+				if invisibleChanged(change.From.Attrs, change.To.Attrs) {
+					c, err := s.modifyVisibility(modify.T, change.To)
+					if err != nil {
+						return err
+					}
+					visibility = append(visibility, c)
+				}
+				// Identity columns are emulated by sequences and triggers (< 12c).
+				emulated := !s.supportsIdentity() && identityChanged(change.From.Attrs, change.To.Attrs)
+				if emulated {
+					cs, err := s.modifyIdentityTrigger(modify.T, change.From, change.To)
+					if err != nil {
+						return err
+					}
+					identities = append(identities, cs...)
+				}
+				if (emulated || !identityChanged(change.From.Attrs, change.To.Attrs)) && !virtualChanged(change.From.Attrs, change.To.Attrs) {
+					change = &schema.ModifyColumn{From: change.From, To: change.To, Change: change.Change &^ schema.ChangeAttr}
+				}
+				if change.Change == schema.NoChange {
+					continue
+				}
+			}
+			if change.Change.Is(schema.ChangeComment) {
+				from, to, err := commentChange(sqlx.CommentDiff(change.From.Attrs, change.To.Attrs))
+				if err != nil {
+					return err
+				}
+				comments = append(comments, s.columnComment(modify.T, change.To, to, from))
+				// If only the comment of the column was changed.
+				if change.Change&^schema.ChangeComment == schema.NoChange {
+					continue
+				}
+			}
+			changes = append(changes, change)
+		case *schema.AddIndex:
+			addI = append(addI, change.I)
+		case *schema.DropIndex:
+			dropI = append(dropI, change.I)
+		case *schema.ModifyIndex:
+			// Index modification requires rebuilding the index.
+			addI = append(addI, change.To)
+			dropI = append(dropI, change.From)
+		case *schema.ModifyForeignKey:
+			// Foreign-key modification is translated into 2 steps.
+			// Dropping the current foreign key and creating a new one.
+			changes = append(changes, &schema.DropForeignKey{
+				F: change.From,
+			}, &schema.AddForeignKey{
+				F: change.To,
+			})
+		case *schema.ModifyCheck:
+			// The ENABLE and VALIDATE states of checks are altered
+			// in place. Other changes require re-creating the check.
+			if cs1, cs2 := checkState(change.From), checkState(change.To); change.From.Name != "" && change.From.Expr == change.To.Expr && !deferrableChanged(cs1, cs2) {
+				states = append(states, s.modifyState(modify.T, change.From.Name, cs1, cs2))
+				continue
+			}
+			switch {
+			case change.From.Name == "":
+				return fmt.Errorf("oracle: cannot modify unnamed check constraint of table %q", modify.T.Name)
+			case change.From.Name != change.To.Name:
+				return fmt.Errorf("oracle: mismatch check constraint names: %q != %q", change.From.Name, change.To.Name)
+			}
+			changes = append(changes, &schema.DropCheck{
+				C: change.From,
+			}, &schema.AddCheck{
+				C: change.To,
+			})
+		default:
+			changes = append(changes, change)
+		}
+	}
+	s.dropIndexes(modify.T, dropI...)
+	for _, batch := range s.coalesce(changes) {
+		var err error
+		if len(batch) == 1 {
+			err = s.alterTable(modify.T, batch[0])
+		} else {
+			err = s.alterColumns(modify.T, batch)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, c := range changes {
+		if isTablespaceChange(c) {
+			if err := s.rebuildIndexes(modify.T, addI); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	s.append(lobs...)
+	s.append(visibility...)
+	s.append(identities...)
+	s.append(states...)
+	s.append(parallels...)
+	if err := s.addIndexes(modify.T, addI...); err != nil {
+		return err
+	}
+	s.append(comments...)
+	s.append(auditing...)
+	s.append(ilms...)
+	return nil
+}
+
+// modifyVisibility returns the change for making the given column visible or invisible.
+// Visibility changes cannot be combined with other column changes in the same clause.
+func (s *state) modifyVisibility(t *schema.Table, c *schema.Column) (*migrate.Change, error) {
+	if !s.supportsInvisible() {
+		return nil, fmt.Errorf("oracle: invisible columns are not supported by version %s (column %q)", s.version, c.Name)
+	}
+	to, from := "VISIBLE", "INVISIBLE"
+	if sqlx.Has(c.Attrs, &Invisible{}) {
+		to, from = from, to
+	}
+	modify := func(b *sqlx.Builder, v string) string {
+		return b.Table(t).P("MODIFY").Wrap(func(b *sqlx.Builder) {
+			b.Ident(c.Name).P(v)
+		}).String()
+	}
+	return &migrate.Change{
+		Cmd:     modify(s.build("ALTER TABLE"), to),
+		Reverse: modify(Build("ALTER TABLE"), from),
+		Comment: fmt.Sprintf("make column %q of table %q %s", c.Name, t.Name, strings.ToLower(to)),
+	}, nil
+}
+
+// lobStorage writes the LOB storage clauses of the given columns. Like other
+// physical attributes, the storage of LOBs is omitted from portable DDL.
+func (s *state) lobStorage(b *sqlx.Builder, cols ...*schema.Column) {
+	if s.portable {
+		return
+	}
+	for _, c := range cols {
+		ls := &LOBStorage{}
+		if !sqlx.Has(c.Attrs, ls) {
+			continue
+		}
+		b.P("LOB").Wrap(func(b *sqlx.Builder) {
+			b.Ident(c.Name)
+		})
+		var params []string
+		// LOBs are stored in-row by default.
+		if ls.OutOfRow {
+			params = append(params, "DISABLE STORAGE IN ROW")
+		}
+		if ls.SecureFile {
+			b.P("STORE AS SECUREFILE")
+		} else {
+			b.P("STORE AS BASICFILE")
+			if ls.Retention || ls.PctVersion > 0 {
+				params = append(params, lobVersions(ls))
+			}
+		}
+		if len(params) > 0 {
+			b.Wrap(func(b *sqlx.Builder) {
+				b.P(params...)
+			})
+		}
+	}
+}
+
+// modifyLOB returns the change for modifying the storage options of a LOB column.
+// Converting BASICFILE LOBs to SECUREFILE (or vice versa), or moving them in or out
+// of the row, requires moving the LOB segment, and is not supported by the planner.
+func (s *state) modifyLOB(t *schema.Table, from, to *schema.Column) (*migrate.Change, error) {
+	ls1, ls2 := &LOBStorage{}, &LOBStorage{}
+	sqlx.Has(from.Attrs, ls1)
+	sqlx.Has(to.Attrs, ls2)
+	if ls1.SecureFile != ls2.SecureFile {
+		return nil, fmt.Errorf("oracle: changing the LOB storage of column %q between BASICFILE and SECUREFILE is not supported", to.Name)
+	}
+	if ls1.OutOfRow != ls2.OutOfRow {
+		return nil, fmt.Errorf("oracle: changing the in-row storage of LOB column %q is not supported", to.Name)
+	}
+	modify := func(b *sqlx.Builder, ls *LOBStorage) string {
+		return b.Table(t).P("MODIFY LOB").Wrap(func(b *sqlx.Builder) {
+			b.Ident(to.Name)
+		}).P("(" + lobVersions(ls) + ")").String()
+	}
+	return &migrate.Change{
+		Cmd:     modify(s.build("ALTER TABLE"), ls2),
+		Reverse: modify(Build("ALTER TABLE"), ls1),
+		Comment: fmt.Sprintf("modify the LOB storage of column %q of table %q", to.Name, t.Name),
+	}, nil
+}
+
+// lobVersions returns the clause that controls how old versions of a BASICFILE LOB are kept.
+func lobVersions(ls *LOBStorage) string {
+	if ls.Retention {
+		return "RETENTION"
+	}
+	return "PCTVERSION " + strconv.Itoa(ls.PctVersion)
+}
+
+// viewOf returns the View or MaterializedView attribute
+// of the given table, or nil if it is a regular table.
+func viewOf(t *schema.Table) schema.Attr {
+	for _, a := range t.Attrs {
+		switch a.(type) {
+		case *View, *MaterializedView:
+			return a
+		}
+	}
+	return nil
+}
+
+// viewCreate returns the statement for creating the given (materialized) view,
+// using the given builder that holds the CREATE (OR REPLACE) phrase.
+func viewCreate(b *sqlx.Builder, t *schema.Table, v schema.Attr) string {
+	switch v := v.(type) {
+	case *View:
+		b.P("VIEW").Table(t).P("AS", strings.TrimSpace(v.Def))
+	case *MaterializedView:
+		b.P("MATERIALIZED VIEW").Table(t)
+		switch strings.ToUpper(v.BuildMode) {
+		case "DEFERRED":
+			b.P("BUILD DEFERRED")
+		case "PREBUILT":
+			b.P("ON PREBUILT TABLE")
+		}
+		switch method, mode := strings.ToUpper(v.RefreshMethod), strings.ToUpper(v.RefreshMode); {
+		case method == "NEVER":
+			b.P("NEVER REFRESH")
+		case method != "":
+			b.P("REFRESH", method)
+			if mode != "" && mode != "NEVER" {
+				b.P("ON", mode)
+			}
+		}
+		b.P("AS", strings.TrimSpace(v.Def))
+	}
+	return b.String()
+}
+
+// viewDrop returns the statement for dropping the given (materialized)
+// view, using the given builder that holds the DROP phrase.
+func viewDrop(b *sqlx.Builder, t *schema.Table, v schema.Attr) string {
+	if _, ok := v.(*MaterializedView); ok {
+		b.P("MATERIALIZED")
+	}
+	return b.P("VIEW").Table(t).String()
+}
+
+// modifyView builds the statements for changing the definition of a view. Views
+// are replaced, and materialized views are re-created, as their query cannot be
+// altered. Other changes (e.g. columns) are derived from the definition, and are
+// therefore skipped.
+func (s *state) modifyView(modify *schema.ModifyTable) {
+	for _, c := range modify.Changes {
+		m, ok := c.(*schema.ModifyAttr)
+		if !ok {
+			continue
+		}
+		switch m.To.(type) {
+		case *View:
+			s.append(&migrate.Change{
+				Cmd:     viewCreate(s.build("CREATE OR REPLACE"), modify.T, m.To),
+				Source:  modify,
+				Comment: fmt.Sprintf("replace %q view", modify.T.Name),
+				Reverse: viewCreate(Build("CREATE OR REPLACE"), modify.T, m.From),
+			})
+		case *MaterializedView:
+			s.append(&migrate.Change{
+				Cmd:     viewDrop(s.build("DROP"), modify.T, m.From),
+				Source:  modify,
+				Comment: fmt.Sprintf("drop %q view", modify.T.Name),
+				Reverse: viewCreate(Build("CREATE"), modify.T, m.From),
+			}, &migrate.Change{
+				Cmd:     viewCreate(s.build("CREATE"), modify.T, m.To),
+				Source:  modify,
+				Comment: fmt.Sprintf("create %q view", modify.T.Name),
+				Reverse: viewDrop(Build("DROP"), modify.T, m.To),
+			})
+		}
+	}
+}
+
+// auditChange returns the auditing options of the given
+// attribute change, if it is an auditing option change.
+func auditChange(c schema.Change) (from, to *Audit, ok bool) {
+	switch c := c.(type) {
+	case *schema.AddAttr:
+		to, ok = c.A.(*Audit)
+	case *schema.DropAttr:
+		from, ok = c.A.(*Audit)
+	case *schema.ModifyAttr:
+		var ok1, ok2 bool
+		from, ok1 = c.From.(*Audit)
+		to, ok2 = c.To.(*Audit)
+		ok = ok1 && ok2
+	}
+	return from, to, ok
+}
+
+// ilmChange extracts the ILM policies from the given attribute change.
+func ilmChange(c schema.Change) (from, to *ILMPolicy, ok bool) {
+	switch c := c.(type) {
+	case *schema.AddAttr:
+		to, ok = c.A.(*ILMPolicy)
+	case *schema.DropAttr:
+		from, ok = c.A.(*ILMPolicy)
+	case *schema.ModifyAttr:
+		var ok1, ok2 bool
+		from, ok1 = c.From.(*ILMPolicy)
+		to, ok2 = c.To.(*ILMPolicy)
+		ok = ok1 && ok2
+	}
+	return from, to, ok
+}
+
+// ilmTable returns the change for adding the given ILM policy to the table, for
+// deleting it if the desired policy (to) is nil, or for enabling (or disabling)
+// it otherwise. Policies are named by the database, and therefore, the names of
+// the added policies are not known, and their statements are not reversible.
+func (s *state) ilmTable(t *schema.Table, from, to *ILMPolicy) (*migrate.Change, error) {
+	if !s.supportsILM() {
+		return nil, fmt.Errorf("oracle: ILM policies are not supported by version %s (table %q)", s.version, t.Name)
+	}
+	switch {
+	case from == nil:
+		c := &migrate.Change{
+			Cmd:     ilmAddCmd(s.build("ALTER TABLE"), t, to),
+			Comment: fmt.Sprintf("add %s ILM policy to %q table", strings.ToLower(to.Action), t.Name),
+		}
+		if to.Name != "" {
+			c.Reverse = Build("ALTER TABLE").Table(t).P("ILM DELETE POLICY").Ident(to.Name).String()
+		}
+		return c, nil
+	case to == nil:
+		return &migrate.Change{
+			Cmd:     s.build("ALTER TABLE").Table(t).P("ILM DELETE POLICY").Ident(from.Name).String(),
+			Reverse: ilmAddCmd(Build("ALTER TABLE"), t, from),
+			Comment: fmt.Sprintf("delete ILM policy %q of %q table", from.Name, t.Name),
+		}, nil
+	default:
+		mode := func(p *ILMPolicy) string {
+			if p.Enabled {
+				return "ILM ENABLE POLICY"
+			}
+			return "ILM DISABLE POLICY"
+		}
+		return &migrate.Change{
+			Cmd:     s.build("ALTER TABLE").Table(t).P(mode(to)).Ident(from.Name).String(),
+			Reverse: Build("ALTER TABLE").Table(t).P(mode(from)).Ident(from.Name).String(),
+			Comment: fmt.Sprintf("change state of ILM policy %q of %q table", from.Name, t.Name),
+		}, nil
+	}
+}
+
+// ilmAddCmd writes the statement for adding the given ILM policy to the table.
+// For example: ILM ADD POLICY ROW STORE COMPRESS ADVANCED ROW AFTER 30 DAYS OF
+// NO MODIFICATION, or ILM ADD POLICY TIER TO ARCHIVE_TBS SEGMENT.
+func ilmAddCmd(b *sqlx.Builder, t *schema.Table, p *ILMPolicy) string {
+	b.Table(t).P("ILM ADD POLICY")
+	switch c := strings.ToUpper(p.Compression); {
+	case strings.EqualFold(p.Action, "STORAGE"):
+		b.P("TIER TO").Ident(p.Tier)
+	case c == "" || c == "BASIC" || c == "ADVANCED":
+		b.P("ROW STORE COMPRESS", c)
+	default:
+		// Hybrid columnar compression (e.g. QUERY HIGH).
+		b.P("COLUMN STORE COMPRESS FOR", c)
+	}
+	b.P(strings.ToUpper(p.Scope))
+	if p.Days > 0 {
+		cond, ok := ilmConditions[strings.ToUpper(p.Condition)]
+		switch {
+		case ok:
+		case p.Condition == "":
+			cond = "NO MODIFICATION"
+		default:
+			cond = strings.ToUpper(p.Condition)
+		}
+		b.P("AFTER", strconv.Itoa(p.Days), "DAYS OF", cond)
+	}
+	return b.String()
+}
+
+// ilmConditions maps the condition types of ILM policies, as
+// they are reported by the data dictionary, to their clauses.
+var ilmConditions = map[string]string{
+	"LAST MODIFICATION TIME": "NO MODIFICATION",
+	"LAST ACCESS TIME":       "NO ACCESS",
+	"CREATION TIME":          "CREATION",
+}
+
+// auditTable returns the change for auditing the table using the given option,
+// or for disabling the auditing option if the desired option (to) is nil.
+func (s *state) auditTable(t *schema.Table, from, to *Audit) *migrate.Change {
+	if to == nil {
+		return &migrate.Change{
+			Cmd:     noauditCmd(s.build("NOAUDIT"), t, from),
+			Reverse: auditCmd(Build("AUDIT"), t, from),
+			Comment: fmt.Sprintf("disable %s auditing on %q table", strings.ToUpper(from.Option), t.Name),
+		}
+	}
+	c := &migrate.Change{
+		Cmd:     auditCmd(s.build("AUDIT"), t, to),
+		Reverse: noauditCmd(Build("NOAUDIT"), t, to),
+		Comment: fmt.Sprintf("audit %s on %q table", strings.ToUpper(to.Option), t.Name),
+	}
+	// Auditing an option again replaces its mode.
+	if from != nil {
+		c.Reverse = auditCmd(Build("AUDIT"), t, from)
+	}
+	return c
+}
+
+func auditCmd(b *sqlx.Builder, t *schema.Table, a *Audit) string {
+	b.P(strings.ToUpper(a.Option), "ON").Table(t).P("BY", strings.ToUpper(a.By))
+	if a.When != "" {
+		b.P("WHENEVER", strings.ToUpper(a.When))
+	}
+	return b.String()
+}
+
+func noauditCmd(b *sqlx.Builder, t *schema.Table, a *Audit) string {
+	b.P(strings.ToUpper(a.Option), "ON").Table(t)
+	if a.When != "" {
+		b.P("WHENEVER", strings.ToUpper(a.When))
+	}
+	return b.String()
+}
+
+func (s *state) addComments(t *schema.Table) {
+	var c schema.Comment
+	if sqlx.Has(t.Attrs, &c) && c.Text != "" {
+		s.append(s.tableComment(t, c.Text, ""))
+	}
+	for i := range t.Columns {
+		if sqlx.Has(t.Columns[i].Attrs, &c) && c.Text != "" {
+			s.append(s.columnComment(t, t.Columns[i], c.Text, ""))
+		}
+	}
+}
+
+// tableComment returns the change for setting the comment of a table.
+// Comments are removed in Oracle by setting them to an empty string.
+func (s *state) tableComment(t *schema.Table, to, from string) *migrate.Change {
+	return &migrate.Change{
+		Cmd:     s.build("COMMENT ON TABLE").Table(t).P("IS", quote(to)).String(),
+		Comment: fmt.Sprintf("set comment to table: %q", t.Name),
+		Reverse: Build("COMMENT ON TABLE").Table(t).P("IS", quote(from)).String(),
+	}
+}
+
+func (s *state) columnComment(t *schema.Table, c *schema.Column, to, from string) *migrate.Change {
+	return &migrate.Change{
+		Cmd:     columnComment(s.build("COMMENT ON COLUMN"), t, c, to),
+		Comment: fmt.Sprintf("set comment to column: %q on table: %q", c.Name, t.Name),
+		Reverse: columnComment(Build("COMMENT ON COLUMN"), t, c, from),
+	}
+}
+
+// columnComment writes the column qualifier and its comment
+// to the builder that holds the COMMENT ON COLUMN phrase.
+func columnComment(b *sqlx.Builder, t *schema.Table, c *schema.Column, comment string) string {
+	b.Table(t)
+	// Replace the trailing whitespace with the column qualifier.
+	b.Truncate(b.Len() - 1)
+	b.WriteByte('.')
+	return b.Ident(c.Name).P("IS", quote(comment)).String()
+}
+
+// isTablespaceChange reports if the given attribute change moves the table to another tablespace.
+func isTablespaceChange(c schema.Change) bool {
+	switch c := c.(type) {
+	case *schema.AddAttr:
+		_, ok := c.A.(*Tablespace)
+		return ok
+	case *schema.ModifyAttr:
+		_, ok1 := c.From.(*Tablespace)
+		_, ok2 := c.To.(*Tablespace)
+		return ok1 && ok2
+	}
+	return false
+}
+
+// fkStateChange extracts the foreign-key states from the given attribute change.
+func fkStateChange(c schema.Change) (from, to *ConstraintState, ok bool) {
+	if c, isModify := c.(*schema.ModifyAttr); isModify {
+		from, ok1 := c.From.(*ConstraintState)
+		to, ok2 := c.To.(*ConstraintState)
+		return from, to, ok1 && ok2
+	}
+	return nil, nil, false
+}
+
+// modifyFKState returns the statements for changing the state of a foreign key.
+// The ENABLE and VALIDATE states are altered in place, and DEFERRABLE changes are
+// planned by dropping and re-creating the foreign key, as they cannot be altered.
+// Foreign keys that are re-created by other changes (e.g. columns) are skipped.
+func (s *state) modifyFKState(modify *schema.ModifyTable, from, to *ConstraintState) ([]*migrate.Change, error) {
+	for _, c := range modify.Changes {
+		if m, ok := c.(*schema.ModifyForeignKey); ok && m.To.Symbol == to.Name {
+			return nil, nil
+		}
+	}
+	if !deferrableChanged(from, to) {
+		return []*migrate.Change{s.modifyState(modify.T, from.Name, from, to)}, nil
+	}
+	fk, ok := modify.T.ForeignKey(to.Name)
+	if !ok {
+		return nil, fmt.Errorf("oracle: missing foreign key %q of table %q", to.Name, modify.T.Name)
+	}
+	add := func(b *sqlx.Builder, cs *ConstraintState) (string, error) {
+		err := s.fkState(b.Table(modify.T).P("ADD"), fk, cs)
+		return b.String(), err
+	}
+	add1, err := add(Build("ALTER TABLE"), from)
+	if err != nil {
+		return nil, err
+	}
+	add2, err := add(s.build("ALTER TABLE"), to)
+	if err != nil {
+		return nil, err
+	}
+	return []*migrate.Change{
+		{
+			Cmd:     s.build("ALTER TABLE").Table(modify.T).P("DROP CONSTRAINT").Ident(fk.Symbol).String(),
+			Reverse: add1,
+			Comment: fmt.Sprintf("drop foreign key %q of table %q", fk.Symbol, modify.T.Name),
+		},
+		{
+			Cmd:     add2,
+			Reverse: Build("ALTER TABLE").Table(modify.T).P("DROP CONSTRAINT").Ident(fk.Symbol).String(),
+			Comment: fmt.Sprintf("re-create foreign key %q of table %q", fk.Symbol, modify.T.Name),
+		},
+	}, nil
+}
+
+// modifyState returns the change for altering the ENABLE and VALIDATE states of the named constraint.
+func (s *state) modifyState(t *schema.Table, name string, from, to *ConstraintState) *migrate.Change {
+	return &migrate.Change{
+		Cmd:     s.build("ALTER TABLE").Table(t).P("MODIFY CONSTRAINT").Ident(name).P(enableClause(to)).String(),
+		Reverse: Build("ALTER TABLE").Table(t).P("MODIFY CONSTRAINT").Ident(name).P(enableClause(from)).String(),
+		Comment: fmt.Sprintf("change state of constraint %q of table %q", name, t.Name),
+	}
+}
+
+// parallelChange extracts the PARALLEL clauses from the given attribute change.
+func parallelChange(c schema.Change) (from, to *Parallel, ok bool) {
+	if c, isModify := c.(*schema.ModifyAttr); isModify {
+		from, ok1 := c.From.(*Parallel)
+		to, ok2 := c.To.(*Parallel)
+		return from, to, ok1 && ok2
+	}
+	return nil, nil, false
+}
+
+// parallelClause returns the PARALLEL (or NOPARALLEL) clause of a table.
+func parallelClause(p *Parallel) string {
+	if p.Degree == 1 && p.Instances == 1 {
+		return "NOPARALLEL"
+	}
+	value := func(n int) string {
+		if n == ParallelDefault {
+			return "DEFAULT"
+		}
+		return strconv.Itoa(n)
+	}
+	return fmt.Sprintf("PARALLEL (DEGREE %s INSTANCES %s)", value(p.Degree), value(p.Instances))
+}
+
+// commentChange extracts the comment texts from the given attribute change.
+func commentChange(c schema.Change) (from, to string, err error) {
+	switch c := c.(type) {
+	case *schema.AddAttr:
+		toC, ok := c.A.(*schema.Comment)
+		if ok {
+			to = toC.Text
+			return
+		}
+		err = fmt.Errorf("oracle: unexpected AddAttr.(%T) for comment change", c.A)
+	case *schema.ModifyAttr:
+		fromC, ok1 := c.From.(*schema.Comment)
+		toC, ok2 := c.To.(*schema.Comment)
+		if ok1 && ok2 {
+			from, to = fromC.Text, toC.Text
+			return
+		}
+		err = fmt.Errorf("oracle: unsupported ModifyAttr(%T, %T) change", c.From, c.To)
+	case *schema.DropAttr:
+		fromC, ok := c.A.(*schema.Comment)
+		if ok {
+			from = fromC.Text
+			return
+		}
+		err = fmt.Errorf("oracle: unexpected DropAttr.(%T) for comment change", c.A)
+	default:
+		err = fmt.Errorf("oracle: unexpected change %T", c)
+	}
+	return
+}
+
+// alterTable builds the ALTER TABLE statement for the given table change.
+func (s *state) alterTable(t *schema.Table, change schema.Change) error {
+	var (
+		b       = s.build("ALTER TABLE").Table(t)
+		reverse = Build("ALTER TABLE").Table(t)
+		comment = fmt.Sprintf("Modify %q table", t.Name)
+	)
+	switch change := change.(type) {
+	case *schema.AddColumn:
+		var err error
+		b.P("ADD").Wrap(func(b *sqlx.Builder) {
+			err = s.column(b, change.C)
+		})
+		if err != nil {
+			return err
+		}
+		s.lobStorage(b, change.C)
+		reverse.P("DROP COLUMN").Ident(change.C.Name)
+		if s.rewritesRows(change.C) {
+			comment = fmt.Sprintf("Modify %q table (updates all rows to set the default value of column %q)", t.Name, change.C.Name)
+		}
+	case *schema.DropColumn:
+		b.P("DROP COLUMN").Ident(change.C.Name)
+		reverse = nil
+	case *schema.ModifyColumn:
+		// Comments are set separately by modifyTable.
+		k := change.Change & ^schema.ChangeComment
+		if err := s.modifyColumn(b, k, change.From, change.To); err != nil {
+			return err
+		}
+		// Identity cannot be added to an existing
+		// column, and therefore, dropping it cannot
+		// be reversed.
+		if sqlx.Has(change.From.Attrs, &Identity{}) && !sqlx.Has(change.To.Attrs, &Identity{}) {
+			reverse = nil
+		} else if err := s.modifyColumn(reverse, k, change.To, change.From); err != nil {
+			return err
+		}
+	case *schema.AddForeignKey:
+		if err := s.fk(b.P("ADD"), change.F); err != nil {
+			return err
+		}
+		reverse.P("DROP CONSTRAINT").Ident(change.F.Symbol)
+		if change.F.Symbol == "" {
+			reverse = nil
+		}
+	case *schema.DropForeignKey:
+		b.P("DROP CONSTRAINT").Ident(change.F.Symbol)
+		if err := s.fk(reverse.P("ADD"), change.F); err != nil {
+			return err
+		}
+	case *schema.AddCheck:
+		check(b.P("ADD"), change.C)
+		// Reverse operation is supported if
+		// the constraint name is not generated.
+		reverse.P("DROP CONSTRAINT").Ident(change.C.Name)
+		if change.C.Name == "" {
+			reverse = nil
+		}
+	case *schema.DropCheck:
+		b.P("DROP CONSTRAINT").Ident(change.C.Name)
+		check(reverse.P("ADD"), change.C)
+	// Note that moving a table marks its indexes as
+	// UNUSABLE until they are rebuilt. See rebuildIndexes.
+	case *schema.AddAttr:
+		b.P("MOVE TABLESPACE").Ident(change.A.(*Tablespace).Name)
+		// The previous tablespace is unknown.
+		reverse = nil
+	case *schema.ModifyAttr:
+		b.P("MOVE TABLESPACE").Ident(change.To.(*Tablespace).Name)
+		reverse.P("MOVE TABLESPACE").Ident(change.From.(*Tablespace).Name)
+	default:
+		return fmt.Errorf("oracle: unsupported change %T on table %q", change, t.Name)
+	}
+	c := &migrate.Change{
+		Cmd: b.String(),
+		Source: &schema.ModifyTable{
+			T:       t,
+			Changes: []schema.Change{change},
+		},
+		Comment: comment,
+	}
+	if reverse != nil {
+		c.Reverse = reverse.String()
+	}
+	s.append(c)
+	if add, ok := change.(*schema.AddColumn); ok {
+		return s.identityTrigger(t, add.C)
+	}
+	return nil
+}
+
+// coalesce groups consecutive column changes of the same kind, as Oracle allows
+// adding, modifying or dropping multiple columns in a single clause of an ALTER
+// TABLE statement. e.g. ADD (c1 ..., c2 ...). Other changes, and column changes
+// that cannot be combined (see columnsClause), are kept in their own group.
+func (s *state) coalesce(changes []schema.Change) [][]schema.Change {
+	var batches [][]schema.Change
+	for i, c := range changes {
+		if n := len(batches); n > 0 && i > 0 {
+			if k := s.columnsClause(c); k != "" && k == s.columnsClause(changes[i-1]) {
+				batches[n-1] = append(batches[n-1], c)
+				continue
+			}
+		}
+		batches = append(batches, []schema.Change{c})
+	}
+	return batches
+}
+
+// columnsClause returns the clause of the ALTER TABLE statement that is used by
+// the given change, or an empty string if the change cannot be combined with
+// other changes of the same kind. Adding columns that rewrite all rows is kept
+// separate for reporting it in the change comment, and so are changes of the
+// column attributes (e.g. identity), as they are not always reversible.
+func (s *state) columnsClause(c schema.Change) string {
+	switch c := c.(type) {
+	case *schema.AddColumn:
+		if !s.rewritesRows(c.C) {
+			return "ADD"
+		}
+	case *schema.ModifyColumn:
+		if !c.Change.Is(schema.ChangeAttr) {
+			return "MODIFY"
+		}
+	case *schema.DropColumn:
+		return "DROP"
+	}
+	return ""
+}
+
+// alterColumns writes a single ALTER TABLE statement for a group of column changes
+// of the same kind. Note that a DROP clause cannot be combined with other clauses,
+// and therefore, groups of different kinds are not merged into the same statement.
+func (s *state) alterColumns(t *schema.Table, changes []schema.Change) error {
+	var (
+		err     error
+		b       = s.build("ALTER TABLE").Table(t)
+		reverse = Build("ALTER TABLE").Table(t)
+	)
+	switch k := s.columnsClause(changes[0]); k {
+	case "ADD":
+		cols := make([]*schema.Column, len(changes))
+		b.P("ADD").Wrap(func(b *sqlx.Builder) {
+			err = b.MapCommaErr(changes, func(i int, b *sqlx.Builder) error {
+				cols[i] = changes[i].(*schema.AddColumn).C
+				return s.column(b, cols[i])
+			})
+		})
+		s.lobStorage(b, cols...)
+		reverse.P("DROP").Wrap(func(b *sqlx.Builder) {
+			b.MapComma(changes, func(i int, b *sqlx.Builder) {
+				b.Ident(changes[i].(*schema.AddColumn).C.Name)
+			})
+		})
+	case "MODIFY":
+		b.P("MODIFY").Wrap(func(b *sqlx.Builder) {
+			err = b.MapCommaErr(changes, func(i int, b *sqlx.Builder) error {
+				m := changes[i].(*schema.ModifyColumn)
+				return s.modifyColumnDef(b, m.Change&^schema.ChangeComment, m.From, m.To)
+			})
+		})
+		if err != nil {
+			return err
+		}
+		reverse.P("MODIFY").Wrap(func(b *sqlx.Builder) {
+			err = b.MapCommaErr(changes, func(i int, b *sqlx.Builder) error {
+				m := changes[i].(*schema.ModifyColumn)
+				return s.modifyColumnDef(b, m.Change&^schema.ChangeComment, m.To, m.From)
+			})
+		})
+	case "DROP":
+		b.P("DROP").Wrap(func(b *sqlx.Builder) {
+			b.MapComma(changes, func(i int, b *sqlx.Builder) {
+				b.Ident(changes[i].(*schema.DropColumn).C.Name)
+			})
+		})
+		reverse = nil
+	default:
+		return fmt.Errorf("oracle: unexpected column changes %T on table %q", changes[0], t.Name)
+	}
+	if err != nil {
+		return err
+	}
+	c := &migrate.Change{
+		Cmd: b.String(),
+		Source: &schema.ModifyTable{
+			T:       t,
+			Changes: changes,
+		},
+		Comment: fmt.Sprintf("Modify %q table", t.Name),
+	}
+	if reverse != nil {
+		c.Reverse = reverse.String()
+	}
+	s.append(c)
+	for _, c := range changes {
+		if add, ok := c.(*schema.AddColumn); ok {
+			if err := s.identityTrigger(t, add.C); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// rewritesRows reports if adding the given column to an existing table updates
+// all its rows, instead of storing the default value in the data dictionary only.
+func (s *state) rewritesRows(c *schema.Column) bool {
+	return c.Default != nil && c.Type.Null && !s.supportsFastAddColumn()
+}
+
+// modifyColumn writes the MODIFY clause for changing the column from one state to the other.
+func (s *state) modifyColumn(b *sqlx.Builder, k schema.ChangeKind, from, to *schema.Column) error {
+	var err error
+	b.P("MODIFY").Wrap(func(b *sqlx.Builder) {
+		err = s.modifyColumnDef(b, k, from, to)
+	})
+	return err
+}
+
+// modifyColumnDef writes the column definition of the MODIFY clause.
+func (s *state) modifyColumnDef(b *sqlx.Builder, k schema.ChangeKind, from, to *schema.Column) error {
+	b.Ident(to.Name)
+	if k.Is(schema.ChangeType) {
+		f, err := formatColumnType(to)
+		if err != nil {
+			return err
+		}
+		b.P(f)
+		k &= ^schema.ChangeType
+	}
+	if k.Is(schema.ChangeCollation) {
+		if err := s.collate(b, to); err != nil {
+			return err
+		}
+		// Removing the collation resets it to the default one.
+		if collation(to.Attrs) == DefaultCollation {
+			b.P("COLLATE", DefaultCollation)
+		}
+		k &= ^schema.ChangeCollation
+	}
+	if k.Is(schema.ChangeAttr) && virtualChanged(from.Attrs, to.Attrs) {
+		v1, v2 := &Virtual{}, &Virtual{}
+		if !sqlx.Has(from.Attrs, v1) || !sqlx.Has(to.Attrs, v2) {
+			return fmt.Errorf("oracle: converting column %q to (or from) a virtual column requires recreating it", to.Name)
+		}
+		virtual(b, v2)
+	}
+	if k.Is(schema.ChangeDefault) {
+		// Setting the default to NULL removes it.
+		if to.Default == nil {
+			b.P("DEFAULT NULL")
+		} else if err := s.columnDefault(b, to); err != nil {
+			return err
+		}
+		k &= ^schema.ChangeDefault
+	}
+	if k.Is(schema.ChangeNull) {
+		notNullClause(b, to)
+		k &= ^schema.ChangeNull
+	}
+	if k.Is(schema.ChangeAttr) {
+		// Emulated identity columns (< 12c) are changed by statements of their own.
+		if identityChanged(from.Attrs, to.Attrs) && s.supportsIdentity() {
+			if err := s.modifyIdentity(b, from, to); err != nil {
+				return err
+			}
+		}
+		k &= ^schema.ChangeAttr
+	}
+	if !k.Is(schema.NoChange) {
+		return fmt.Errorf("oracle: unsupported change %d for column %q", k, from.Name)
+	}
+	return nil
+}
+
+// modifyIdentity writes the clause for changing the identity options of a column.
+func (s *state) modifyIdentity(b *sqlx.Builder, from, to *schema.Column) error {
+	if !s.supportsIdentity() {
+		return fmt.Errorf("oracle: identity columns are not supported by version %s (column %q)", s.version, to.Name)
+	}
+	_, ok := identity(from.Attrs)
+	switch id, ok2 := identity(to.Attrs); {
+	case ok && ok2:
+		identityClause(b, id)
+	case ok:
+		b.P("DROP IDENTITY")
+	default:
+		return fmt.Errorf("oracle: cannot add identity to existing column %q", to.Name)
+	}
+	return nil
+}
+
+// identityClause writes the identity clause of the given identity to the builder.
+func identityClause(b *sqlx.Builder, id *Identity) {
+	b.P("GENERATED", id.Generation, "AS IDENTITY")
+	if opts := seqOptions(id.Sequence); len(opts) > 0 {
+		b.Wrap(func(b *sqlx.Builder) {
+			b.P(opts...)
+		})
+	}
+}
+
+// seqOptions returns the sequence options that are different from their defaults.
+func seqOptions(seq *Sequence) []string {
+	var opts []string
+	if seq.Start != defaultSeqStart && seq.Start != 0 {
+		opts = append(opts, "START WITH "+strconv.FormatInt(seq.Start, 10))
+	}
+	if seq.Increment != defaultSeqIncrement && seq.Increment != 0 {
+		opts = append(opts, "INCREMENT BY "+strconv.FormatInt(seq.Increment, 10))
+	}
+	if seq.Min != 0 {
+		opts = append(opts, "MINVALUE "+strconv.FormatInt(seq.Min, 10))
+	}
+	if seq.Max != 0 {
+		opts = append(opts, "MAXVALUE "+strconv.FormatInt(seq.Max, 10))
+	}
+	if seq.Order {
+		opts = append(opts, "ORDER")
+	}
+	return opts
+}
+
+// identityTrigger emulates the given identity column using a sequence and
+// a BEFORE INSERT trigger, in versions that do not support identity (< 12c).
+func (s *state) identityTrigger(t *schema.Table, c *schema.Column) error {
+	id, ok := identity(c.Attrs)
+	if !ok || s.supportsIdentity() {
+		return nil
+	}
+	changes, err := s.addIdentityTrigger(t, c, id)
+	if err != nil {
+		return err
+	}
+	s.append(changes...)
+	return nil
+}
+
+// addIdentityTrigger returns the statements for creating the sequence and
+// the trigger that emulate the given identity column.
+func (s *state) addIdentityTrigger(t *schema.Table, c *schema.Column, id *Identity) ([]*migrate.Change, error) {
+	seq, trg := identityObjects(t, c)
+	for _, o := range []*schema.Table{seq, trg} {
+		if len(o.Name) > maxIdentLen11 {
+			return nil, fmt.Errorf("oracle: name %q of identity column %q exceeds %d characters", o.Name, c.Name, maxIdentLen11)
+		}
+	}
+	return []*migrate.Change{
+		{
+			Cmd:     s.build("CREATE SEQUENCE").Table(seq).P(seqOptions(id.Sequence)...).String(),
+			Reverse: Build("DROP SEQUENCE").Table(seq).String(),
+			Comment: fmt.Sprintf("create sequence for identity column %q of table %q", c.Name, t.Name),
+		},
+		{
+			Cmd:     identityTriggerCmd(s.build("CREATE OR REPLACE TRIGGER"), t, c, id),
+			Reverse: Build("DROP TRIGGER").Table(trg).String(),
+			Comment: fmt.Sprintf("create trigger for identity column %q of table %q", c.Name, t.Name),
+		},
+	}, nil
+}
+
+// modifyIdentityTrigger returns the statements for changing the emulated identity
+// of a column (< 12c). The START WITH value of the sequence cannot be changed, as
+// it is not known on inspection.
+func (s *state) modifyIdentityTrigger(t *schema.Table, from, to *schema.Column) ([]*migrate.Change, error) {
+	i1, ok1 := identity(from.Attrs)
+	i2, ok2 := identity(to.Attrs)
+	switch {
+	case !ok1:
+		return s.addIdentityTrigger(t, to, i2)
+	case !ok2:
+		seq, trg := identityObjects(t, from)
+		// Dropped sequences are re-created from the value they continue from.
+		opts := *i1.Sequence
+		if opts.Start == 0 {
+			opts.Start = opts.Last
+		}
+		return []*migrate.Change{
+			{
+				Cmd:     s.build("DROP TRIGGER").Table(trg).String(),
+				Reverse: identityTriggerCmd(Build("CREATE OR REPLACE TRIGGER"), t, from, i1),
+				Comment: fmt.Sprintf("drop trigger of identity column %q of table %q", from.Name, t.Name),
+			},
+			{
+				Cmd:     s.build("DROP SEQUENCE").Table(seq).String(),
+				Reverse: Build("CREATE SEQUENCE").Table(seq).P(seqOptions(&opts)...).String(),
+				Comment: fmt.Sprintf("drop sequence of identity column %q of table %q", from.Name, t.Name),
+			},
+		}, nil
+	}
+	if i1.Sequence.Start != 0 && i2.Sequence.Start != 0 && i1.Sequence.Start != i2.Sequence.Start {
+		return nil, fmt.Errorf("oracle: changing the START WITH value of identity column %q requires recreating its sequence", to.Name)
+	}
+	var changes []*migrate.Change
+	if i1.Generation != i2.Generation {
+		changes = append(changes, &migrate.Change{
+			Cmd:     identityTriggerCmd(s.build("CREATE OR REPLACE TRIGGER"), t, to, i2),
+			Reverse: identityTriggerCmd(Build("CREATE OR REPLACE TRIGGER"), t, from, i1),
+			Comment: fmt.Sprintf("change generation of identity column %q of table %q", to.Name, t.Name),
+		})
+	}
+	if i1.Sequence.Increment != i2.Sequence.Increment {
+		seq, _ := identityObjects(t, to)
+		changes = append(changes, &migrate.Change{
+			Cmd:     s.build("ALTER SEQUENCE").Table(seq).P("INCREMENT BY", strconv.FormatInt(i2.Sequence.Increment, 10)).String(),
+			Reverse: Build("ALTER SEQUENCE").Table(seq).P("INCREMENT BY", strconv.FormatInt(i1.Sequence.Increment, 10)).String(),
+			Comment: fmt.Sprintf("change increment of identity column %q of table %q", to.Name, t.Name),
+		})
+	}
+	return changes, nil
+}
+
+// identityTriggerCmd writes the trigger that sets the values of
+// the given identity column from its sequence to the builder.
+func identityTriggerCmd(b *sqlx.Builder, t *schema.Table, c *schema.Column, id *Identity) string {
+	seq, trg := identityObjects(t, c)
+	b.Table(trg).P("BEFORE INSERT ON").Table(t).P("FOR EACH ROW")
+	// Values can be set explicitly on columns
+	// that are generated BY DEFAULT.
+	if id.Generation != defaultIdentityGen {
+		b.P(fmt.Sprintf("WHEN (new.%s IS NULL)", ident(c.Name)))
+	}
+	// The sequence is qualified the same way as the trigger.
+	sb := Build("")
+	sb.Schema = b.Schema
+	b.P("BEGIN", fmt.Sprintf(":new.%s := %s.NEXTVAL;", ident(c.Name), sb.Table(seq).String()), "END;")
+	return b.String()
+}
+
+// identityObjects returns the sequence and the trigger that emulate
+// the given identity column in versions that do not support identity.
+func identityObjects(t *schema.Table, c *schema.Column) (seq, trg *schema.Table) {
+	s, g := identityObjectNames(t.Name, c.Name)
+	return objectOf(t, s), objectOf(t, g)
+}
+
+// identityObjectNames returns the names of the sequence and the trigger
+// that emulate the identity column of the given table and column names.
+func identityObjectNames(table, column string) (seq, trg string) {
+	return table + "_" + column + "_SEQ", table + "_" + column + "_TRG"
+}
+
+// maxIdentLen11 is the maximum length of identifiers in versions < 12.2.
+const maxIdentLen11 = 30
+
+// rebuildIndexes builds the statements for rebuilding the indexes of a table
+// that was moved to another tablespace, as moving a table marks its indexes as
+// UNUSABLE. Indexes that are created by the same change (added) are skipped.
+// The indexes of index-organized tables are moved with them, and are usable.
+func (s *state) rebuildIndexes(t *schema.Table, added []*schema.Index) error {
+	if organizationOf(t) == OrganizationIndex {
+		return nil
+	}
+	skip := make(map[string]bool, len(added))
+	for _, idx := range added {
+		skip[idx.Name] = true
+	}
+	var names []string
+	if pk := t.PrimaryKey; pk != nil {
+		// The name of the index that backs the primary key is
+		// known only if it was inspected (or explicitly named).
+		if pk.Name == "" {
+			return fmt.Errorf("oracle: cannot rebuild the primary key index of table %q after moving the table: the index name is unknown", t.Name)
+		}
+		names = append(names, pk.Name)
+	}
+	for _, idx := range t.Indexes {
+		if !skip[idx.Name] {
+			names = append(names, idx.Name)
+		}
+	}
+	for _, name := range names {
+		s.append(&migrate.Change{
+			Cmd:     s.build("ALTER INDEX").Table(objectOf(t, name)).P("REBUILD").String(),
+			Comment: fmt.Sprintf("rebuild index %q of moved table %q", name, t.Name),
+		})
+	}
+	return nil
+}
+
+func (s *state) dropIndexes(t *schema.Table, indexes ...*schema.Index) {
+	for _, idx := range indexes {
+		s.append(&migrate.Change{
+			Cmd:     s.build("DROP INDEX").Table(objectOf(t, idx.Name)).String(),
+			Comment: fmt.Sprintf("Drop index %q to table: %q", idx.Name, t.Name),
+		})
+	}
+}
+
+func (s *state) addIndexes(t *schema.Table, indexes ...*schema.Index) error {
+	for _, idx := range indexes {
+		bitmap, reverse, err := s.indexAttrs(idx.Attrs)
+		if err != nil {
+			return fmt.Errorf("oracle: index %q: %w", idx.Name, err)
+		}
+		b := s.build("CREATE")
+		switch {
+		case idx.Unique && bitmap:
+			return fmt.Errorf("oracle: bitmap index %q cannot be unique", idx.Name)
+		case idx.Unique:
+			b.P("UNIQUE")
+		case bitmap:
+			b.P("BITMAP")
+		}
+		b.P("INDEX").Table(objectOf(t, idx.Name)).P("ON").Table(t)
+		if err := s.indexParts(b, idx.Parts); err != nil {
+			return fmt.Errorf("oracle: index %q: %w", idx.Name, err)
+		}
+		// Physical attributes are omitted from portable DDL.
+		if reverse && !s.portable {
+			b.P("REVERSE")
+		}
+		if ts := (&Tablespace{}); sqlx.Has(idx.Attrs, ts) && !s.portable {
+			b.P("TABLESPACE").Ident(ts.Name)
+		}
+		if sqlx.Has(idx.Attrs, &LocalIndex{}) {
+			b.P("LOCAL")
+		}
+		s.append(&migrate.Change{
+			Cmd:     b.String(),
+			Reverse: Build("DROP INDEX").Table(objectOf(t, idx.Name)).String(),
+			Comment: fmt.Sprintf("Create index %q to table: %q", idx.Name, t.Name),
+		})
+	}
+	return nil
+}
+
+// column writes the column definition to the builder.
+func (s *state) column(b *sqlx.Builder, c *schema.Column) error {
+	f, err := formatColumnType(c)
+	if err != nil {
+		return err
+	}
+	b.Ident(c.Name).P(f)
+	if err := s.collate(b, c); err != nil {
+		return err
+	}
+	if sqlx.Has(c.Attrs, &Invisible{}) {
+		if !s.supportsInvisible() {
+			return fmt.Errorf("oracle: invisible columns are not supported by version %s (column %q)", s.version, c.Name)
+		}
+		b.P("INVISIBLE")
+	}
+	// Note that DEFAULT (or the identity clause)
+	// must precede the inline constraints.
+	id, ok := identity(c.Attrs)
+	v := &Virtual{}
+	switch {
+	case sqlx.Has(c.Attrs, v):
+		virtual(b, v)
+	case ok && s.supportsIdentity():
+		identityClause(b, id)
+	case ok:
+		// Identity columns are emulated using a sequence
+		// and a trigger in versions that do not support them.
+	default:
+		if err := s.columnDefault(b, c); err != nil {
+			return err
+		}
+	}
+	// Identity columns, and columns with DEFAULT ON NULL, are implicitly NOT NULL.
+	// Disabled NOT NULL constraints are reported as nullable columns.
+	if ok || c.Default != nil && sqlx.Has(c.Attrs, &DefaultOnNull{}) {
+		b.P("NOT NULL")
+	} else {
+		notNullClause(b, c)
+	}
+	for _, attr := range c.Attrs {
+		switch attr.(type) {
+		// The character set is derived from the column
+		// type (e.g. NVARCHAR2 uses the national charset).
+		case *schema.Comment, *schema.Charset, *schema.Collation, *LengthSemantics, *Identity, *DefaultOnNull, *Virtual, *Invisible, *ConstraintState:
+		// The LOB storage is written after the column list.
+		case *LOBStorage:
+		default:
+			return fmt.Errorf("oracle: unsupported attribute %T of column %q", attr, c.Name)
+		}
+	}
+	return nil
+}
+
+// virtual writes the expression clause of a virtual column.
+func virtual(b *sqlx.Builder, v *Virtual) {
+	b.P("GENERATED ALWAYS AS").Wrap(func(b *sqlx.Builder) {
+		b.P(v.Expr)
+	}).P("VIRTUAL")
+}
+
+// collate writes the COLLATE clause of a column to the builder,
+// if it was declared with a collation other than the default one.
+func (s *state) collate(b *sqlx.Builder, c *schema.Column) error {
+	name := collation(c.Attrs)
+	switch {
+	case name == DefaultCollation:
+	case !s.supportsCollation():
+		return fmt.Errorf("oracle: column collations are not supported by version %s (column %q)", s.version, c.Name)
+	default:
+		b.P("COLLATE", name)
+	}
+	return nil
+}
+
+// columnDefault writes the default value of column to the builder. Note that
+// re-declaring the default value of a column without ON NULL removes it.
+func (s *state) columnDefault(b *sqlx.Builder, c *schema.Column) error {
+	var v string
+	switch x := c.Default.(type) {
+	case *schema.Literal:
+		v = x.V
+		switch c.Type.Type.(type) {
+		case *schema.DecimalType, *schema.IntegerType, *schema.FloatType:
+		default:
+			v = quote(v)
+		}
+	case *schema.RawExpr:
+		v = defaultFunc(x.X)
+	default:
+		return nil
+	}
+	b.P("DEFAULT")
+	if sqlx.Has(c.Attrs, &DefaultOnNull{}) {
+		if !s.supportsDefaultOnNull() {
+			return fmt.Errorf("oracle: DEFAULT ON NULL is not supported by version %s (column %q)", s.version, c.Name)
+		}
+		b.P("ON NULL")
+	}
+	b.P(v)
+	return nil
+}
+
+// ansiDefaults maps the ANSI (and common) functions that are used as column
+// defaults to their Oracle equivalents. CURRENT_TIMESTAMP and CURRENT_DATE are
+// supported by Oracle as well, but return the time in the session time zone.
+// Hence, they are mapped to the SYS* functions that return the time of the
+// database server, like the column defaults in other databases.
+var ansiDefaults = map[string]string{
+	"CURRENT_TIMESTAMP":   "SYSTIMESTAMP",
+	"CURRENT_TIMESTAMP()": "SYSTIMESTAMP",
+	"NOW()":               "SYSTIMESTAMP",
+	"CURRENT_DATE":        "SYSDATE",
+	"LOCALTIMESTAMP":      "LOCALTIMESTAMP",
+}
+
+// defaultFunc returns the Oracle form of the given default expression.
+func defaultFunc(x string) string {
+	x = strings.TrimSpace(x)
+	if f, ok := ansiDefaults[strings.ToUpper(x)]; ok {
+		return f
+	}
+	return x
+}
+
+func (s *state) indexParts(b *sqlx.Builder, parts []*schema.IndexPart) (err error) {
+	b.Wrap(func(b *sqlx.Builder) {
+		err = b.MapCommaErr(parts, func(i int, b *sqlx.Builder) error {
+			switch part := parts[i]; {
+			case part.C != nil:
+				b.Ident(part.C.Name)
+			case part.X != nil:
+				// Function-based indexes.
+				x, ok := part.X.(*schema.RawExpr)
+				if !ok {
+					return fmt.Errorf("unexpected index part expression: %T", part.X)
+				}
+				b.P(x.X)
+			}
+			if p := (IndexColumnProperty{}); sqlx.Has(parts[i].Attrs, &p) && p.Desc {
+				b.P("DESC")
+			}
+			return nil
+		})
+	})
+	return err
+}
+
+// indexAttrs reports if the index is a bitmap or a reverse key index. An error
+// is returned if the index has attributes that cannot be expressed by the planner.
+// Note that indexes on expressions (or DESC parts) are reported as FUNCTION-BASED
+// indexes by the database, but they are defined the same way.
+func (s *state) indexAttrs(attrs []schema.Attr) (bitmap, reverse bool, err error) {
+	for _, attr := range attrs {
+		switch a := attr.(type) {
+		case *schema.Comment, *ConType, *Tablespace, *LocalIndex:
+		case *IndexType:
+			switch t := strings.TrimPrefix(strings.ToUpper(a.T), "FUNCTION-BASED "); t {
+			case "NORMAL":
+			case "NORMAL/REV":
+				reverse = true
+			case "BITMAP":
+				bitmap = true
+			default:
+				return false, false, fmt.Errorf("unsupported index type %q", a.T)
+			}
+		default:
+			return false, false, fmt.Errorf("unexpected index attribute: %T", attr)
+		}
+	}
+	return bitmap, reverse, nil
+}
+
+// fk writes the foreign-key constraint to the builder.
+func (s *state) fk(b *sqlx.Builder, fk *schema.ForeignKey) error {
+	// Foreign keys do not hold attributes, and their
+	// states are stored on the table they belong to.
+	var cs *ConstraintState
+	if fk.Table != nil && fk.Symbol != "" {
+		cs, _ = constraintState(fk.Table.Attrs, fk.Symbol)
+	}
+	return s.fkState(b, fk, cs)
+}
+
+// fkState writes the foreign-key constraint to the builder in the given state.
+func (s *state) fkState(b *sqlx.Builder, fk *schema.ForeignKey, cs *ConstraintState) error {
+	if fk.Symbol != "" {
+		b.P("CONSTRAINT").Ident(fk.Symbol)
+	}
+	b.P("FOREIGN KEY")
+	b.Wrap(func(b *sqlx.Builder) {
+		b.MapComma(fk.Columns, func(i int, b *sqlx.Builder) {
+			b.Ident(fk.Columns[i].Name)
+		})
+	})
+	b.P("REFERENCES").Table(fk.RefTable)
+	b.Wrap(func(b *sqlx.Builder) {
+		b.MapComma(fk.RefColumns, func(i int, b *sqlx.Builder) {
+			b.Ident(fk.RefColumns[i].Name)
+		})
+	})
+	// Oracle supports only the NO ACTION rule for updates,
+	// and does not accept it explicitly in the clause.
+	switch fk.OnUpdate {
+	case "", schema.NoAction:
+	default:
+		return fmt.Errorf("oracle: unsupported ON UPDATE %s action of foreign key %q", fk.OnUpdate, fk.Symbol)
+	}
+	switch fk.OnDelete {
+	case "", schema.NoAction:
+	case schema.Cascade, schema.SetNull:
+		b.P("ON DELETE", string(fk.OnDelete))
+	default:
+		return fmt.Errorf("oracle: unsupported ON DELETE %s action of foreign key %q", fk.OnDelete, fk.Symbol)
+	}
+	if cs != nil {
+		constraintStateClause(b, cs)
+	}
+	return nil
+}
+
+// constraintStateClause writes the non-default constraint states to the builder.
+func constraintStateClause(b *sqlx.Builder, cs *ConstraintState) {
+	if cs.Deferrable {
+		b.P("DEFERRABLE")
+		if cs.InitiallyDeferred {
+			b.P("INITIALLY DEFERRED")
+		}
+	}
+	if cs.Disabled {
+		b.P("DISABLE")
+	}
+	if cs.NoValidate {
+		b.P("NOVALIDATE")
+	}
+}
+
+// notNullClause writes the NULL (or NOT NULL) constraint of the column to the builder.
+func notNullClause(b *sqlx.Builder, c *schema.Column) {
+	if !nullable(c) {
+		b.P("NOT")
+	}
+	b.P("NULL")
+	if cs, ok := notNullState(c.Attrs); ok {
+		notNullStateClause(b, cs)
+	}
+}
+
+// modifyNotNullState returns the statements for changing the state of the NOT NULL
+// constraint of a column. The ENABLE and VALIDATE states of inspected constraints
+// are altered in place, and otherwise, the constraint is dropped and re-created.
+func (s *state) modifyNotNullState(t *schema.Table, from, to *schema.Column) []*migrate.Change {
+	cs1, ok1 := notNullState(from.Attrs)
+	cs2, _ := notNullState(to.Attrs)
+	if ok1 && cs1.Name != "" && !deferrableChanged(cs1, cs2) {
+		return []*migrate.Change{{
+			Cmd:     s.build("ALTER TABLE").Table(t).P("MODIFY CONSTRAINT").Ident(cs1.Name).P(enableClause(cs2)).String(),
+			Reverse: Build("ALTER TABLE").Table(t).P("MODIFY CONSTRAINT").Ident(cs1.Name).P(enableClause(cs1)).String(),
+			Comment: fmt.Sprintf("change state of the NOT NULL constraint of column %q of table %q", to.Name, t.Name),
+		}}
+	}
+	modify := func(b *sqlx.Builder, c *schema.Column, null bool) string {
+		b.Table(t).P("MODIFY").Wrap(func(b *sqlx.Builder) {
+			b.Ident(c.Name)
+			if null {
+				b.P("NULL")
+			} else {
+				notNullClause(b, c)
+			}
+		})
+		return b.String()
+	}
+	return []*migrate.Change{
+		{
+			Cmd:     modify(s.build("ALTER TABLE"), from, true),
+			Reverse: modify(Build("ALTER TABLE"), from, false),
+			Comment: fmt.Sprintf("drop the NOT NULL constraint of column %q of table %q", from.Name, t.Name),
+		},
+		{
+			Cmd:     modify(s.build("ALTER TABLE"), to, false),
+			Reverse: modify(Build("ALTER TABLE"), to, true),
+			Comment: fmt.Sprintf("add the NOT NULL constraint of column %q of table %q", to.Name, t.Name),
+		},
+	}
+}
+
+// notNullState returns the non-default state of the NOT NULL constraint of a column.
+func notNullState(attrs []schema.Attr) (*ConstraintState, bool) {
+	cs := &ConstraintState{}
+	if !sqlx.Has(attrs, cs) {
+		return nil, false
+	}
+	return cs, true
+}
+
+// notNullStateClause writes the state of a NOT NULL constraint to the builder.
+// Unlike other constraints, the ENABLE keyword is written explicitly, as in
+// NOT NULL ENABLE NOVALIDATE, the way it is generated by DBMS_METADATA.
+func notNullStateClause(b *sqlx.Builder, cs *ConstraintState) {
+	if cs.Deferrable {
+		b.P("DEFERRABLE")
+		if cs.InitiallyDeferred {
+			b.P("INITIALLY DEFERRED")
+		}
+	}
+	b.P(enableClause(cs))
+}
+
+// enableClause returns the ENABLE (or DISABLE) and VALIDATE (or
+// NOVALIDATE) clauses of the given state. A nil state is the default.
+func enableClause(cs *ConstraintState) string {
+	mode, validate := "ENABLE", "VALIDATE"
+	if cs != nil && cs.Disabled {
+		mode = "DISABLE"
+	}
+	if cs != nil && cs.NoValidate {
+		validate = "NOVALIDATE"
+	}
+	return mode + " " + validate
+}
+
+// objectOf returns a table that shares its qualified name with the schema
+// object (e.g. index or sequence) of the given table. Indexes, sequences
+// and triggers in Oracle are qualified the same way tables are.
+func objectOf(t *schema.Table, name string) *schema.Table {
+	return &schema.Table{Name: name, Schema: t.Schema}
+}
+
+func (s *state) append(c ...*migrate.Change) {
+	s.Changes = append(s.Changes, c...)
+}
+
+// build instantiates a new builder for a statement of the plan. In session
+// schema mode, objects in the current schema of the session are not qualified.
+// Reverse statements are built with Build, and always qualified, as they are
+// executed in the opposite order, regardless of the current schema.
+func (s *state) build(phrase string) *sqlx.Builder {
+	b := Build(phrase)
+	b.Schema = s.current
+	return b
+}
+
+// Build instantiates a new builder and writes the given phrase to it.
+func Build(phrase string) *sqlx.Builder {
+	b := &sqlx.Builder{QuoteChar: '"', Unquoted: unquoted}
+	return b.P(phrase)
+}
+
+// skipAutoChanges filters unnecessary changes that are automatically
+// happened by the database when ALTER TABLE is executed.
+func skipAutoChanges(changes []schema.Change) []schema.Change {
+	var (
+		dropC   = make(map[string]bool)
+		planned = make([]schema.Change, 0, len(changes))
+	)
+	for _, c := range changes {
+		if c, ok := c.(*schema.DropColumn); ok {
+			dropC[c.C.Name] = true
+		}
+	}
+search:
+	for _, c := range changes {
+		switch c := c.(type) {
+		// Indexes involving the column are automatically dropped
+		// with it. This true for multi-columns indexes as well.
+		case *schema.DropIndex:
+			for _, p := range c.I.Parts {
+				if p.C != nil && dropC[p.C.Name] {
+					continue search
+				}
+			}
+		// Simple case for skipping constraint dropping,
+		// if the child table columns were dropped.
+		case *schema.DropForeignKey:
+			for _, c := range c.F.Columns {
+				if dropC[c.Name] {
+					continue search
+				}
+			}
+		}
+		planned = append(planned, c)
+	}
+	return planned
+}
+
+// check writes the CHECK constraint to the builder.
+func check(b *sqlx.Builder, c *schema.Check) {
+	expr := c.Expr
+	// Expressions should be wrapped with parens.
+	if t := strings.TrimSpace(expr); !strings.HasPrefix(t, "(") || !strings.HasSuffix(t, ")") {
+		expr = "(" + t + ")"
+	}
+	if c.Name != "" {
+		b.P("CONSTRAINT").Ident(c.Name)
+	}
+	b.P("CHECK", expr)
+	if cs := (&ConstraintState{}); sqlx.Has(c.Attrs, cs) {
+		constraintStateClause(b, cs)
+	}
+}
+
+// quote returns the given string as a string literal, unless it is already
+// a string literal, including national character literals (e.g. N'a').
+func quote(s string) string {
+	if isStringLiteral(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// ident returns the given name as an identifier for the generated DDL. Oracle
+// folds unquoted identifiers to uppercase, and therefore, names are quoted,
+// unless they are stored the same way when unquoted. See unquoted for details.
+func ident(name string) string {
+	if unquoted(name) {
+		return name
+	}
+	return `"` + name + `"`
+}
+
+// reSimpleIdent matches the names that are valid unquoted identifiers and are
+// not affected by case folding. i.e. uppercase names that start with a letter.
+var reSimpleIdent = regexp.MustCompile(`^[A-Z][A-Z0-9_$#]*$`)
+
+// unquoted reports if the given name can be written without quotes. Names that
+// contain lowercase letters or special characters, or names that are reserved
+// words (e.g. NUMBER or USER), must be quoted.
+func unquoted(name string) bool {
+	return reSimpleIdent.MatchString(name) && !reservedWords[name]
+}
+
+// reservedWords holds the Oracle SQL reserved words, and the keywords
+// that cannot be used as unquoted identifiers of schema objects.
+// https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/Oracle-SQL-Reserved-Words.html
+var reservedWords = func() map[string]bool {
+	words := make(map[string]bool)
+	for _, w := range strings.Fields(`
+ACCESS ADD ALL ALTER AND ANY AS ASC AUDIT BETWEEN BY CHAR CHECK CLUSTER COLUMN
+COLUMN_VALUE COMMENT COMPRESS CONNECT CREATE CURRENT DATE DECIMAL DEFAULT DELETE
+DESC DISTINCT DROP ELSE EXCLUSIVE EXISTS FILE FLOAT FOR FROM GRANT GROUP HAVING
+IDENTIFIED IMMEDIATE IN INCREMENT INDEX INITIAL INSERT INTEGER INTERSECT INTO IS
+LEVEL LIKE LOCK LONG MAXEXTENTS MINUS MLSLABEL MODE MODIFY NESTED_TABLE_ID NOAUDIT
+NOCOMPRESS NOT NOWAIT NULL NUMBER OF OFFLINE ON ONLINE OPTION OR ORDER PCTFREE
+PRIOR PUBLIC RAW RENAME RESOURCE REVOKE ROW ROWID ROWNUM ROWS SELECT SESSION SET
+SHARE SIZE SMALLINT START SUCCESSFUL SYNONYM SYSDATE TABLE THEN TO TRIGGER UID
+UNION UNIQUE UPDATE USER VALIDATE VALUES VARCHAR VARCHAR2 VIEW WHENEVER WHERE WITH
+`) {
+		words[w] = true
+	}
+	return words
+}()
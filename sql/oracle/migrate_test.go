@@ -0,0 +1,1605 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package oracle
+
+import (
+	"context"
+	"testing"
+
+	"ariga.io/atlas/sql/internal/sqltest"
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/schema"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanChanges(t *testing.T) {
+	users := &schema.Table{
+		Name:   "USERS",
+		Schema: &schema.Schema{Name: "ATLAS"},
+		Columns: []*schema.Column{
+			{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}},
+			{Name: "NAME", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar2", Size: 100}, Null: true}, Attrs: []schema.Attr{&LengthSemantics{T: SemanticsChar}}},
+		},
+	}
+	users.PrimaryKey = &schema.Index{Name: "USERS_PK", Unique: true, Table: users, Parts: []*schema.IndexPart{{SeqNo: 1, C: users.Columns[0]}}}
+	posts := &schema.Table{
+		Name:   "POSTS",
+		Schema: users.Schema,
+		Columns: []*schema.Column{
+			{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}},
+			{Name: "AUTHOR_ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}, Null: true}},
+			{Name: "TITLE", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar2", Size: 255}}, Default: &schema.Literal{V: "untitled"}},
+		},
+		Attrs: []schema.Attr{
+			&schema.Check{Name: "TITLE_LEN", Expr: `LENGTH("TITLE") > 0`},
+			&RowDependencies{},
+		},
+	}
+	posts.PrimaryKey = &schema.Index{Name: "SYS_C0012345", Unique: true, Table: posts, Parts: []*schema.IndexPart{{SeqNo: 1, C: posts.Columns[0]}}}
+	posts.ForeignKeys = []*schema.ForeignKey{
+		{Symbol: "AUTHOR_FK", Table: posts, Columns: posts.Columns[1:2], RefTable: users, RefColumns: users.Columns[:1], OnDelete: schema.Cascade},
+	}
+	posts.Indexes = []*schema.Index{
+		{Name: "TITLE_IDX", Table: posts, Parts: []*schema.IndexPart{{SeqNo: 1, C: posts.Columns[2], Attrs: []schema.Attr{&IndexColumnProperty{Desc: true}}}}, Attrs: []schema.Attr{&IndexType{T: "FUNCTION-BASED NORMAL"}}},
+	}
+	tests := []struct {
+		changes []schema.Change
+		plan    *migrate.Plan
+	}{
+		{
+			changes: []schema.Change{
+				&schema.AddTable{T: posts},
+			},
+			plan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{Cmd: `CREATE TABLE ATLAS.POSTS (ID number(10) NOT NULL, AUTHOR_ID number(10) NULL, TITLE varchar2(255) DEFAULT 'untitled' NOT NULL, PRIMARY KEY (ID), CONSTRAINT AUTHOR_FK FOREIGN KEY (AUTHOR_ID) REFERENCES ATLAS.USERS (ID) ON DELETE CASCADE, CONSTRAINT TITLE_LEN CHECK (LENGTH("TITLE") > 0)) ROWDEPENDENCIES`, Reverse: `DROP TABLE ATLAS.POSTS`},
+					{Cmd: `CREATE INDEX ATLAS.TITLE_IDX ON ATLAS.POSTS (TITLE DESC)`, Reverse: `DROP INDEX ATLAS.TITLE_IDX`},
+				},
+			},
+		},
+		// Referenced tables are created first.
+		{
+			changes: []schema.Change{
+				&schema.AddTable{T: posts},
+				&schema.AddTable{T: users},
+			},
+			plan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{Cmd: `CREATE TABLE ATLAS.USERS (ID number(10) NOT NULL, NAME varchar2(100 CHAR) NULL, CONSTRAINT USERS_PK PRIMARY KEY (ID))`, Reverse: `DROP TABLE ATLAS.USERS`},
+					{Cmd: `CREATE TABLE ATLAS.POSTS (ID number(10) NOT NULL, AUTHOR_ID number(10) NULL, TITLE varchar2(255) DEFAULT 'untitled' NOT NULL, PRIMARY KEY (ID), CONSTRAINT AUTHOR_FK FOREIGN KEY (AUTHOR_ID) REFERENCES ATLAS.USERS (ID) ON DELETE CASCADE, CONSTRAINT TITLE_LEN CHECK (LENGTH("TITLE") > 0)) ROWDEPENDENCIES`, Reverse: `DROP TABLE ATLAS.POSTS`},
+					{Cmd: `CREATE INDEX ATLAS.TITLE_IDX ON ATLAS.POSTS (TITLE DESC)`, Reverse: `DROP INDEX ATLAS.TITLE_IDX`},
+				},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.DropTable{T: posts},
+			},
+			plan: &migrate.Plan{
+				Changes: []*migrate.Change{
+					{Cmd: `DROP TABLE ATLAS.POSTS`},
+				},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{
+					T: users,
+					Changes: []schema.Change{
+						&schema.AddColumn{
+							C: &schema.Column{Name: "EMAIL", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar2", Size: 255}, Null: true}},
+						},
+						&schema.ModifyColumn{
+							From:   &schema.Column{Name: "NAME", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar2", Size: 100}, Null: true}},
+							To:     &schema.Column{Name: "NAME", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar2", Size: 200}}, Default: &schema.Literal{V: "'unknown'"}},
+							Change: schema.ChangeType | schema.ChangeNull | schema.ChangeDefault,
+						},
+						&schema.AddCheck{
+							C: &schema.Check{Name: "NAME_LEN", Expr: `(LENGTH("NAME") > 1)`},
+						},
+						&schema.AddIndex{
+							I: &schema.Index{Name: "NAME_IDX", Unique: true, Parts: []*schema.IndexPart{{SeqNo: 1, C: users.Columns[1]}}},
+						},
+					},
+				},
+			},
+			plan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{Cmd: `ALTER TABLE ATLAS.USERS ADD (EMAIL varchar2(255) NULL)`, Reverse: `ALTER TABLE ATLAS.USERS DROP COLUMN EMAIL`},
+					{Cmd: `ALTER TABLE ATLAS.USERS MODIFY (NAME varchar2(200) DEFAULT 'unknown' NOT NULL)`, Reverse: `ALTER TABLE ATLAS.USERS MODIFY (NAME varchar2(100) DEFAULT NULL NULL)`},
+					{Cmd: `ALTER TABLE ATLAS.USERS ADD CONSTRAINT NAME_LEN CHECK (LENGTH("NAME") > 1)`, Reverse: `ALTER TABLE ATLAS.USERS DROP CONSTRAINT NAME_LEN`},
+					{Cmd: `CREATE UNIQUE INDEX ATLAS.NAME_IDX ON ATLAS.USERS (NAME)`, Reverse: `DROP INDEX ATLAS.NAME_IDX`},
+				},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{
+					T: posts,
+					Changes: []schema.Change{
+						&schema.DropColumn{C: posts.Columns[2]},
+						// Dropped with the column.
+						&schema.DropIndex{I: posts.Indexes[0]},
+						&schema.DropForeignKey{F: posts.ForeignKeys[0]},
+					},
+				},
+			},
+			plan: &migrate.Plan{
+				Changes: []*migrate.Change{
+					{Cmd: `ALTER TABLE ATLAS.POSTS DROP COLUMN TITLE`},
+					{Cmd: `ALTER TABLE ATLAS.POSTS DROP CONSTRAINT AUTHOR_FK`, Reverse: `ALTER TABLE ATLAS.POSTS ADD CONSTRAINT AUTHOR_FK FOREIGN KEY (AUTHOR_ID) REFERENCES ATLAS.USERS (ID) ON DELETE CASCADE`},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version("19.0.0.0.0")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", tt.changes)
+		require.NoError(t, err)
+		require.Equal(t, tt.plan.Reversible, plan.Reversible)
+		require.Equal(t, tt.plan.Transactional, plan.Transactional)
+		require.Len(t, plan.Changes, len(tt.plan.Changes))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.plan.Changes[i].Cmd, c.Cmd)
+			require.Equal(t, tt.plan.Changes[i].Reverse, c.Reverse)
+		}
+	}
+}
+
+func TestPlanChanges_Temporary(t *testing.T) {
+	for mode, expect := range map[string]string{
+		"":               `CREATE GLOBAL TEMPORARY TABLE ATLAS.SESSIONS (ID number(10) NOT NULL) ON COMMIT DELETE ROWS`,
+		OnCommitDelete:   `CREATE GLOBAL TEMPORARY TABLE ATLAS.SESSIONS (ID number(10) NOT NULL) ON COMMIT DELETE ROWS`,
+		OnCommitPreserve: `CREATE GLOBAL TEMPORARY TABLE ATLAS.SESSIONS (ID number(10) NOT NULL) ON COMMIT PRESERVE ROWS`,
+	} {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version("19.0.0.0.0")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{
+			&schema.AddTable{T: &schema.Table{
+				Name:    "SESSIONS",
+				Schema:  &schema.Schema{Name: "ATLAS"},
+				Columns: []*schema.Column{{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}}},
+				Attrs:   []schema.Attr{&Temporary{OnCommit: mode}},
+			}},
+		})
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, 1)
+		require.Equal(t, expect, plan.Changes[0].Cmd)
+		require.Equal(t, `DROP TABLE ATLAS.SESSIONS`, plan.Changes[0].Reverse)
+	}
+}
+
+func TestPlanChanges_AddColumnDefault(t *testing.T) {
+	users := &schema.Table{Name: "USERS", Schema: &schema.Schema{Name: "ATLAS"}}
+	for _, tt := range []struct {
+		version string
+		null    bool
+		comment string
+	}{
+		// NOT NULL columns with a default are added as a metadata-only operation since 11g.
+		{version: "11.2.0.4.0", comment: `Modify "USERS" table`},
+		{version: "19.0.0.0.0", comment: `Modify "USERS" table`},
+		// Nullable columns with a default are added this way only since 12c.
+		{version: "11.2.0.4.0", null: true, comment: `Modify "USERS" table (updates all rows to set the default value of column "ACTIVE")`},
+		{version: "19.0.0.0.0", null: true, comment: `Modify "USERS" table`},
+	} {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version(tt.version)
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{
+			&schema.ModifyTable{T: users, Changes: []schema.Change{
+				&schema.AddColumn{C: &schema.Column{Name: "ACTIVE", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 1}, Null: tt.null}, Default: &schema.Literal{V: "1"}}},
+			}},
+		})
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, 1)
+		require.Equal(t, tt.comment, plan.Changes[0].Comment)
+		require.Equal(t, `ALTER TABLE ATLAS.USERS DROP COLUMN ACTIVE`, plan.Changes[0].Reverse)
+	}
+}
+
+func TestPlanChanges_DefaultOnNull(t *testing.T) {
+	db, mk, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{mk}.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	users := &schema.Table{Name: "USERS", Schema: &schema.Schema{Name: "ATLAS"}}
+	active := &schema.Column{Name: "ACTIVE", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 1}, Null: true}, Default: &schema.Literal{V: "1"}, Attrs: []schema.Attr{&DefaultOnNull{}}}
+	plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{
+		&schema.ModifyTable{T: users, Changes: []schema.Change{
+			&schema.AddColumn{C: active},
+		}},
+	})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `ALTER TABLE ATLAS.USERS ADD (ACTIVE number(1) DEFAULT ON NULL 1 NOT NULL)`, plan.Changes[0].Cmd)
+
+	// Dropping the ON NULL clause keeps the default.
+	plan, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{
+		&schema.ModifyTable{T: users, Changes: []schema.Change{
+			&schema.ModifyColumn{From: active, To: &schema.Column{Name: "ACTIVE", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 1}}, Default: &schema.Literal{V: "1"}}, Change: schema.ChangeDefault},
+		}},
+	})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `ALTER TABLE ATLAS.USERS MODIFY (ACTIVE DEFAULT 1)`, plan.Changes[0].Cmd)
+	require.Equal(t, `ALTER TABLE ATLAS.USERS MODIFY (ACTIVE DEFAULT ON NULL 1)`, plan.Changes[0].Reverse)
+}
+
+func TestPlanChanges_DefaultFuncs(t *testing.T) {
+	db, mk, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{mk}.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{
+		&schema.AddTable{T: &schema.Table{Name: "EVENTS", Schema: &schema.Schema{Name: "ATLAS"}, Columns: []*schema.Column{
+			{Name: "CREATED_AT", Type: &schema.ColumnType{Type: &schema.TimeType{T: "timestamp"}}, Default: &schema.RawExpr{X: "CURRENT_TIMESTAMP"}},
+			{Name: "CREATED_ON", Type: &schema.ColumnType{Type: &schema.TimeType{T: "date"}}, Default: &schema.RawExpr{X: "current_date"}},
+			{Name: "LOCAL_AT", Type: &schema.ColumnType{Type: &schema.TimeType{T: "timestamp"}}, Default: &schema.RawExpr{X: "LOCALTIMESTAMP"}},
+		}}},
+	})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `CREATE TABLE ATLAS.EVENTS (CREATED_AT timestamp DEFAULT SYSTIMESTAMP NOT NULL, CREATED_ON date DEFAULT SYSDATE NOT NULL, LOCAL_AT timestamp DEFAULT LOCALTIMESTAMP NOT NULL)`, plan.Changes[0].Cmd)
+}
+
+func TestPlanChanges_DefaultLiterals(t *testing.T) {
+	db, mk, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{mk}.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{
+		&schema.AddTable{T: &schema.Table{Name: "USERS", Schema: &schema.Schema{Name: "ATLAS"}, Columns: []*schema.Column{
+			{Name: "NAME", Type: &schema.ColumnType{Type: &schema.StringType{T: "nvarchar2", Size: 10}}, Default: &schema.Literal{V: "N'x'"}},
+			{Name: "NICK", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar2", Size: 10}}, Default: &schema.Literal{V: "'it''s'"}},
+			{Name: "CITY", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar2", Size: 10}}, Default: &schema.Literal{V: "it's"}},
+		}}},
+	})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `CREATE TABLE ATLAS.USERS (NAME nvarchar2(10) DEFAULT N'x' NOT NULL, NICK varchar2(10) DEFAULT 'it''s' NOT NULL, CITY varchar2(10) DEFAULT 'it''s' NOT NULL)`, plan.Changes[0].Cmd)
+}
+
+func TestPlanChanges_Audit(t *testing.T) {
+	db, mk, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{mk}.version("19.0.0.0.0")
+	drv, err := Open(db, WithAudit())
+	require.NoError(t, err)
+	from := &schema.Table{Name: "ORDERS", Schema: &schema.Schema{Name: "ATLAS"}, Attrs: []schema.Attr{
+		&Audit{Option: "SELECT", By: AuditBySession},
+		&Audit{Option: "DELETE", By: AuditByAccess},
+	}}
+	to := &schema.Table{Name: "ORDERS", Schema: from.Schema, Attrs: []schema.Attr{
+		&Audit{Option: "select", By: "access"},
+		&Audit{Option: "INSERT", By: AuditByAccess, When: AuditNotSuccessful},
+	}}
+	changes, err := drv.TableDiff(from, to)
+	require.NoError(t, err)
+	require.Equal(t, []schema.Change{
+		&schema.ModifyAttr{From: from.Attrs[0], To: to.Attrs[0]},
+		&schema.DropAttr{A: from.Attrs[1]},
+		&schema.AddAttr{A: to.Attrs[1]},
+	}, changes)
+	plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.ModifyTable{T: to, Changes: changes}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 3)
+	for i, c := range []struct{ cmd, reverse string }{
+		{`AUDIT SELECT ON ATLAS.ORDERS BY ACCESS`, `AUDIT SELECT ON ATLAS.ORDERS BY SESSION`},
+		{`NOAUDIT DELETE ON ATLAS.ORDERS`, `AUDIT DELETE ON ATLAS.ORDERS BY ACCESS`},
+		{`AUDIT INSERT ON ATLAS.ORDERS BY ACCESS WHENEVER NOT SUCCESSFUL`, `NOAUDIT INSERT ON ATLAS.ORDERS WHENEVER NOT SUCCESSFUL`},
+	} {
+		require.Equal(t, c.cmd, plan.Changes[i].Cmd)
+		require.Equal(t, c.reverse, plan.Changes[i].Reverse)
+	}
+
+	// Auditing options are created along with the table.
+	plan, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.AddTable{T: &schema.Table{
+		Name:    "ORDERS",
+		Schema:  from.Schema,
+		Columns: []*schema.Column{{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}}},
+		Attrs:   []schema.Attr{&Audit{Option: "SELECT", By: AuditByAccess}},
+	}}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 2)
+	require.Equal(t, `AUDIT SELECT ON ATLAS.ORDERS BY ACCESS`, plan.Changes[1].Cmd)
+
+	// Auditing options are not compared unless the audit mode is enabled.
+	mock{mk}.version("19.0.0.0.0")
+	drv, err = Open(db)
+	require.NoError(t, err)
+	changes, err = drv.TableDiff(from, to)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
+
+func TestPlanChanges_ILM(t *testing.T) {
+	db, mk, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{mk}.version("19.0.0.0.0")
+	drv, err := Open(db, WithILM())
+	require.NoError(t, err)
+	from := &schema.Table{Name: "ORDERS", Schema: &schema.Schema{Name: "ATLAS"}, Attrs: []schema.Attr{
+		&ILMPolicy{Name: "P1", Action: "COMPRESSION", Scope: "ROW", Compression: "ADVANCED", Condition: "LAST MODIFICATION TIME", Days: 30, Enabled: true},
+		&ILMPolicy{Name: "P2", Action: "COMPRESSION", Scope: "SEGMENT", Compression: "QUERY HIGH", Condition: "LAST ACCESS TIME", Days: 90, Enabled: true},
+	}}
+	to := &schema.Table{Name: "ORDERS", Schema: from.Schema, Attrs: []schema.Attr{
+		&ILMPolicy{Action: "COMPRESSION", Scope: "ROW", Compression: "ADVANCED", Condition: "LAST MODIFICATION TIME", Days: 30},
+		&ILMPolicy{Action: "STORAGE", Scope: "SEGMENT", Tier: "ARCHIVE", Enabled: true},
+	}}
+	changes, err := drv.TableDiff(from, to)
+	require.NoError(t, err)
+	require.Equal(t, []schema.Change{
+		&schema.ModifyAttr{From: from.Attrs[0], To: to.Attrs[0]},
+		&schema.DropAttr{A: from.Attrs[1]},
+		&schema.AddAttr{A: to.Attrs[1]},
+	}, changes)
+	plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.ModifyTable{T: to, Changes: changes}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 3)
+	for i, c := range []struct{ cmd, reverse string }{
+		{`ALTER TABLE ATLAS.ORDERS ILM DISABLE POLICY P1`, `ALTER TABLE ATLAS.ORDERS ILM ENABLE POLICY P1`},
+		{`ALTER TABLE ATLAS.ORDERS ILM DELETE POLICY P2`, `ALTER TABLE ATLAS.ORDERS ILM ADD POLICY COLUMN STORE COMPRESS FOR QUERY HIGH SEGMENT AFTER 90 DAYS OF NO ACCESS`},
+		{`ALTER TABLE ATLAS.ORDERS ILM ADD POLICY TIER TO ARCHIVE SEGMENT`, ``},
+	} {
+		require.Equal(t, c.cmd, plan.Changes[i].Cmd)
+		require.Equal(t, c.reverse, plan.Changes[i].Reverse)
+	}
+
+	// Policies are added along with the table.
+	plan, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.AddTable{T: &schema.Table{
+		Name:    "ORDERS",
+		Schema:  from.Schema,
+		Columns: []*schema.Column{{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}}},
+		Attrs:   []schema.Attr{from.Attrs[0]},
+	}}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 2)
+	require.Equal(t, `ALTER TABLE ATLAS.ORDERS ILM ADD POLICY ROW STORE COMPRESS ADVANCED ROW AFTER 30 DAYS OF NO MODIFICATION`, plan.Changes[1].Cmd)
+
+	// Policies are not compared unless the ILM mode is enabled.
+	mock{mk}.version("19.0.0.0.0")
+	drv, err = Open(db)
+	require.NoError(t, err)
+	changes, err = drv.TableDiff(from, to)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	// ILM is not supported by 11g.
+	mock{mk}.version("11.2.0.4.0")
+	drv, err = Open(db, WithILM())
+	require.NoError(t, err)
+	_, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.ModifyTable{T: to, Changes: []schema.Change{&schema.AddAttr{A: to.Attrs[1]}}}})
+	require.Error(t, err)
+}
+
+func TestPlanChanges_DropPurge(t *testing.T) {
+	db, mk, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{mk}.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	s := &schema.Schema{Name: "ATLAS"}
+	plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{
+		&schema.DropTable{T: &schema.Table{Name: "POSTS", Schema: s}},
+		&schema.DropTable{T: &schema.Table{Name: "USERS", Schema: s}, Extra: []schema.Clause{&Purge{}}},
+	})
+	require.NoError(t, err)
+	require.False(t, plan.Reversible)
+	require.Len(t, plan.Changes, 2)
+	require.Equal(t, `DROP TABLE ATLAS.POSTS`, plan.Changes[0].Cmd)
+	require.Equal(t, `drop "POSTS" table`, plan.Changes[0].Comment)
+	require.Equal(t, `DROP TABLE ATLAS.USERS PURGE`, plan.Changes[1].Cmd)
+	require.Equal(t, `drop "USERS" table bypassing the recycle bin`, plan.Changes[1].Comment)
+}
+
+func TestPlanChanges_VirtualInvisible(t *testing.T) {
+	db, mk, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{mk}.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	number := &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}
+	users := &schema.Table{
+		Name:   "USERS",
+		Schema: &schema.Schema{Name: "ATLAS"},
+		Columns: []*schema.Column{
+			{Name: "PRICE", Type: number},
+			{Name: "TOTAL", Type: number, Attrs: []schema.Attr{&Virtual{Expr: "PRICE * 2"}}},
+			{Name: "SECRET", Type: number, Attrs: []schema.Attr{&Invisible{}}},
+		},
+	}
+	plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.AddTable{T: users}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `CREATE TABLE ATLAS.USERS (PRICE number(10) NOT NULL, TOTAL number(10) GENERATED ALWAYS AS (PRICE * 2) VIRTUAL NOT NULL, SECRET number(10) INVISIBLE NOT NULL)`, plan.Changes[0].Cmd)
+
+	total := &schema.Column{Name: "TOTAL", Type: number, Attrs: []schema.Attr{&Virtual{Expr: "PRICE * 3"}}}
+	secret := &schema.Column{Name: "SECRET", Type: number}
+	changes, err := drv.TableDiff(users, &schema.Table{Name: "USERS", Schema: users.Schema, Columns: []*schema.Column{users.Columns[0], total, secret}})
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	plan, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.ModifyTable{T: users, Changes: changes}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 2)
+	require.Equal(t, `ALTER TABLE ATLAS.USERS MODIFY (TOTAL GENERATED ALWAYS AS (PRICE * 3) VIRTUAL)`, plan.Changes[0].Cmd)
+	require.Equal(t, `ALTER TABLE ATLAS.USERS MODIFY (TOTAL GENERATED ALWAYS AS (PRICE * 2) VIRTUAL)`, plan.Changes[0].Reverse)
+	require.Equal(t, `ALTER TABLE ATLAS.USERS MODIFY (SECRET VISIBLE)`, plan.Changes[1].Cmd)
+	require.Equal(t, `ALTER TABLE ATLAS.USERS MODIFY (SECRET INVISIBLE)`, plan.Changes[1].Reverse)
+
+	// Virtual columns cannot be converted to regular columns.
+	_, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.ModifyTable{T: users, Changes: []schema.Change{
+		&schema.ModifyColumn{From: users.Columns[1], To: &schema.Column{Name: "TOTAL", Type: number}, Change: schema.ChangeAttr},
+	}}})
+	require.EqualError(t, err, `oracle: converting column "TOTAL" to (or from) a virtual column requires recreating it`)
+}
+
+func TestPlanChanges_Views(t *testing.T) {
+	db, mk, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{mk}.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	number := &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}
+	from := schema.New("ATLAS").AddTables(
+		schema.NewTable("ACTIVE").AddColumns(&schema.Column{Name: "ID", Type: number}).AddAttrs(&View{Def: "SELECT ID FROM USERS WHERE ACTIVE = 1"}),
+		schema.NewTable("TOTALS").AddColumns(&schema.Column{Name: "ID", Type: number}).AddAttrs(&MaterializedView{Def: "SELECT ID FROM USERS", RefreshMethod: "FAST", RefreshMode: "COMMIT", BuildMode: "IMMEDIATE"}),
+	)
+	to := schema.New("ATLAS").AddTables(
+		schema.NewTable("ACTIVE").AddColumns(&schema.Column{Name: "ID", Type: number}, &schema.Column{Name: "NAME", Type: number}).AddAttrs(&View{Def: "SELECT ID, NAME FROM USERS WHERE ACTIVE = 1"}),
+	)
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	plan, err := drv.PlanChanges(context.Background(), "plan", changes)
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 2)
+	require.Equal(t, `CREATE OR REPLACE VIEW ATLAS.ACTIVE AS SELECT ID, NAME FROM USERS WHERE ACTIVE = 1`, plan.Changes[0].Cmd)
+	require.Equal(t, `CREATE OR REPLACE VIEW ATLAS.ACTIVE AS SELECT ID FROM USERS WHERE ACTIVE = 1`, plan.Changes[0].Reverse)
+	require.Equal(t, `DROP MATERIALIZED VIEW ATLAS.TOTALS`, plan.Changes[1].Cmd)
+
+	changes, err = drv.SchemaDiff(to, from)
+	require.NoError(t, err)
+	plan, err = drv.PlanChanges(context.Background(), "plan", changes)
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 2)
+	require.Equal(t, `CREATE MATERIALIZED VIEW ATLAS.TOTALS REFRESH FAST ON COMMIT AS SELECT ID FROM USERS`, plan.Changes[1].Cmd)
+	require.Equal(t, `DROP MATERIALIZED VIEW ATLAS.TOTALS`, plan.Changes[1].Reverse)
+
+	// Views cannot be converted to tables.
+	_, err = drv.SchemaDiff(from, schema.New("ATLAS").AddTables(schema.NewTable("ACTIVE").AddColumns(&schema.Column{Name: "ID", Type: number})))
+	require.EqualError(t, err, `oracle: changing the kind of "ACTIVE" (table, view or materialized view) requires recreating it`)
+}
+
+func TestPlanChanges_Collation(t *testing.T) {
+	users := &schema.Table{
+		Name:   "USERS",
+		Schema: &schema.Schema{Name: "ATLAS"},
+		Columns: []*schema.Column{
+			{Name: "NAME", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar2", Size: 10}}, Attrs: []schema.Attr{&schema.Collation{V: DefaultCollation}}},
+			{Name: "EMAIL", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar2", Size: 10}}, Attrs: []schema.Attr{&schema.Collation{V: "BINARY_CI"}}},
+		},
+	}
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.AddTable{T: users}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `CREATE TABLE ATLAS.USERS (NAME varchar2(10) NOT NULL, EMAIL varchar2(10) COLLATE BINARY_CI NOT NULL)`, plan.Changes[0].Cmd)
+
+	from, to := users.Columns[1], &schema.Column{Name: "EMAIL", Type: users.Columns[1].Type}
+	plan, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{
+		&schema.ModifyTable{T: users, Changes: []schema.Change{&schema.ModifyColumn{From: from, To: to, Change: schema.ChangeCollation}}},
+	})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `ALTER TABLE ATLAS.USERS MODIFY (EMAIL COLLATE USING_NLS_COMP)`, plan.Changes[0].Cmd)
+	require.Equal(t, `ALTER TABLE ATLAS.USERS MODIFY (EMAIL COLLATE BINARY_CI)`, plan.Changes[0].Reverse)
+
+	// Column collations are not supported before 18c.
+	db, m, err = sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("12.2.0.1.0")
+	drv, err = Open(db)
+	require.NoError(t, err)
+	_, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.AddTable{T: users}})
+	require.EqualError(t, err, `oracle: column collations are not supported by version 12.2.0 (column "EMAIL")`)
+}
+
+func TestPlanChanges_IndexOrganized(t *testing.T) {
+	users := &schema.Table{
+		Name:    "USERS",
+		Schema:  &schema.Schema{Name: "ATLAS"},
+		Columns: []*schema.Column{{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}}},
+		Attrs: []schema.Attr{
+			&Organization{T: OrganizationIndex, PctThreshold: 20, Overflow: true},
+			&Tablespace{Name: "USERS"},
+		},
+	}
+	users.PrimaryKey = &schema.Index{Name: "USERS_PK", Parts: []*schema.IndexPart{{SeqNo: 1, C: users.Columns[0]}}}
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.AddTable{T: users}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `CREATE TABLE ATLAS.USERS (ID number(10) NOT NULL, CONSTRAINT USERS_PK PRIMARY KEY (ID)) ORGANIZATION INDEX TABLESPACE USERS PCTTHRESHOLD 20 OVERFLOW`, plan.Changes[0].Cmd)
+	require.Equal(t, `DROP TABLE ATLAS.USERS`, plan.Changes[0].Reverse)
+
+	// Index-organized tables are stored in their primary key index.
+	users.PrimaryKey = nil
+	_, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.AddTable{T: users}})
+	require.EqualError(t, err, `oracle: index-organized table "USERS" must have a primary key`)
+}
+
+func TestPlanChanges_SessionSchema(t *testing.T) {
+	var (
+		atlas = &schema.Schema{Name: "ATLAS"}
+		hr    = &schema.Schema{Name: "HR"}
+		users = &schema.Table{
+			Name:    "USERS",
+			Schema:  hr,
+			Columns: []*schema.Column{{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}}},
+		}
+		posts = &schema.Table{
+			Name:   "POSTS",
+			Schema: atlas,
+			Columns: []*schema.Column{
+				{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}},
+				{Name: "AUTHOR_ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}},
+			},
+		}
+	)
+	posts.ForeignKeys = []*schema.ForeignKey{
+		{Symbol: "AUTHOR_FK", Table: posts, Columns: posts.Columns[1:], RefTable: users, RefColumns: users.Columns},
+	}
+	posts.Indexes = []*schema.Index{
+		{Name: "AUTHOR_IDX", Table: posts, Parts: []*schema.IndexPart{{SeqNo: 1, C: posts.Columns[1]}}},
+	}
+	changes := []schema.Change{
+		&schema.AddTable{T: users},
+		&schema.AddTable{T: posts},
+		&schema.ModifySchema{S: atlas, Changes: []schema.Change{&schema.AddAttr{A: &Sequence{Name: "POSTS_SEQ", Start: 1, Increment: 1, Cache: 20}}}},
+		&schema.ModifyTable{T: users, Changes: []schema.Change{&schema.AddAttr{A: &schema.Comment{Text: "users"}}}},
+	}
+	for _, tt := range []struct {
+		opts []Option
+		want []*migrate.Change
+	}{
+		// Objects are qualified by default.
+		{
+			want: []*migrate.Change{
+				{Cmd: `CREATE TABLE HR.USERS (ID number(10) NOT NULL)`, Reverse: `DROP TABLE HR.USERS`},
+				{Cmd: `CREATE SEQUENCE ATLAS.POSTS_SEQ`, Reverse: `DROP SEQUENCE ATLAS.POSTS_SEQ`},
+				{Cmd: `COMMENT ON TABLE HR.USERS IS 'users'`, Reverse: `COMMENT ON TABLE HR.USERS IS ''`},
+				{Cmd: `CREATE TABLE ATLAS.POSTS (ID number(10) NOT NULL, AUTHOR_ID number(10) NOT NULL, CONSTRAINT AUTHOR_FK FOREIGN KEY (AUTHOR_ID) REFERENCES HR.USERS (ID))`, Reverse: `DROP TABLE ATLAS.POSTS`},
+				{Cmd: `CREATE INDEX ATLAS.AUTHOR_IDX ON ATLAS.POSTS (AUTHOR_ID)`, Reverse: `DROP INDEX ATLAS.AUTHOR_IDX`},
+			},
+		},
+		// Objects in the current schema of the session are not qualified,
+		// except in reverse statements that do not depend on the session.
+		{
+			opts: []Option{WithSessionSchema()},
+			want: []*migrate.Change{
+				{Cmd: `ALTER SESSION SET CURRENT_SCHEMA = HR`, Reverse: `ALTER SESSION SET CURRENT_SCHEMA = HR`},
+				{Cmd: `CREATE TABLE USERS (ID number(10) NOT NULL)`, Reverse: `DROP TABLE HR.USERS`},
+				{Cmd: `ALTER SESSION SET CURRENT_SCHEMA = ATLAS`, Reverse: `ALTER SESSION SET CURRENT_SCHEMA = ATLAS`},
+				{Cmd: `CREATE SEQUENCE POSTS_SEQ`, Reverse: `DROP SEQUENCE ATLAS.POSTS_SEQ`},
+				{Cmd: `ALTER SESSION SET CURRENT_SCHEMA = HR`, Reverse: `ALTER SESSION SET CURRENT_SCHEMA = HR`},
+				{Cmd: `COMMENT ON TABLE USERS IS 'users'`, Reverse: `COMMENT ON TABLE HR.USERS IS ''`},
+				{Cmd: `ALTER SESSION SET CURRENT_SCHEMA = ATLAS`, Reverse: `ALTER SESSION SET CURRENT_SCHEMA = ATLAS`},
+				{Cmd: `CREATE TABLE POSTS (ID number(10) NOT NULL, AUTHOR_ID number(10) NOT NULL, CONSTRAINT AUTHOR_FK FOREIGN KEY (AUTHOR_ID) REFERENCES HR.USERS (ID))`, Reverse: `DROP TABLE ATLAS.POSTS`},
+				{Cmd: `CREATE INDEX AUTHOR_IDX ON POSTS (AUTHOR_ID)`, Reverse: `DROP INDEX ATLAS.AUTHOR_IDX`},
+			},
+		},
+	} {
+		db, m, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{m}.version("19.0.0.0.0")
+		drv, err := Open(db, tt.opts...)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", changes)
+		require.NoError(t, err)
+		require.True(t, plan.Reversible)
+		require.Len(t, plan.Changes, len(tt.want))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.want[i].Cmd, c.Cmd)
+			require.Equal(t, tt.want[i].Reverse, c.Reverse)
+		}
+	}
+}
+
+func TestPlanChanges_SeparatePrimaryKeys(t *testing.T) {
+	var (
+		users = &schema.Table{
+			Name:    "USERS",
+			Schema:  &schema.Schema{Name: "ATLAS"},
+			Columns: []*schema.Column{{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}}},
+		}
+		tags = &schema.Table{
+			Name:    "TAGS",
+			Schema:  users.Schema,
+			Columns: []*schema.Column{{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}}},
+		}
+	)
+	users.PrimaryKey = &schema.Index{Name: "USERS_PK", Parts: []*schema.IndexPart{{SeqNo: 1, C: users.Columns[0]}}, Attrs: []schema.Attr{&Tablespace{Name: "INDX"}}}
+	// Unnamed primary keys are backed by an index that is named after their table.
+	tags.PrimaryKey = &schema.Index{Name: "SYS_C001", Parts: []*schema.IndexPart{{SeqNo: 1, C: tags.Columns[0]}}}
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("19.0.0.0.0")
+	drv, err := Open(db, WithSeparatePrimaryKeys())
+	require.NoError(t, err)
+	plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.AddTable{T: users}, &schema.AddTable{T: tags}})
+	require.NoError(t, err)
+	require.True(t, plan.Reversible)
+	require.Len(t, plan.Changes, 6)
+	for i, c := range []*migrate.Change{
+		{Cmd: `CREATE TABLE ATLAS.USERS (ID number(10) NOT NULL)`, Reverse: `DROP TABLE ATLAS.USERS`},
+		{Cmd: `CREATE UNIQUE INDEX ATLAS.USERS_PK ON ATLAS.USERS (ID) TABLESPACE INDX`, Reverse: `DROP INDEX ATLAS.USERS_PK`},
+		{Cmd: `ALTER TABLE ATLAS.USERS ADD CONSTRAINT USERS_PK PRIMARY KEY (ID) USING INDEX ATLAS.USERS_PK`, Reverse: `ALTER TABLE ATLAS.USERS DROP PRIMARY KEY`},
+		{Cmd: `CREATE TABLE ATLAS.TAGS (ID number(10) NOT NULL)`, Reverse: `DROP TABLE ATLAS.TAGS`},
+		{Cmd: `CREATE UNIQUE INDEX ATLAS.TAGS_PK ON ATLAS.TAGS (ID)`, Reverse: `DROP INDEX ATLAS.TAGS_PK`},
+		{Cmd: `ALTER TABLE ATLAS.TAGS ADD PRIMARY KEY (ID) USING INDEX ATLAS.TAGS_PK`, Reverse: `ALTER TABLE ATLAS.TAGS DROP PRIMARY KEY`},
+	} {
+		require.Equal(t, c.Cmd, plan.Changes[i].Cmd)
+		require.Equal(t, c.Reverse, plan.Changes[i].Reverse)
+	}
+
+	// Primary keys of index-organized tables are part of their definition.
+	users.Attrs = []schema.Attr{&Organization{T: OrganizationIndex}}
+	plan, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.AddTable{T: users}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `CREATE TABLE ATLAS.USERS (ID number(10) NOT NULL, CONSTRAINT USERS_PK PRIMARY KEY (ID)) ORGANIZATION INDEX`, plan.Changes[0].Cmd)
+}
+
+func TestPlanChanges_LocalIndexes(t *testing.T) {
+	users := &schema.Table{
+		Name:   "USERS",
+		Schema: &schema.Schema{Name: "ATLAS"},
+		Columns: []*schema.Column{
+			{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}},
+			{Name: "REGION", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}},
+		},
+	}
+	users.PrimaryKey = &schema.Index{Name: "USERS_PK", Parts: []*schema.IndexPart{{SeqNo: 1, C: users.Columns[0]}, {SeqNo: 2, C: users.Columns[1]}}, Attrs: []schema.Attr{&LocalIndex{}}}
+	users.Indexes = []*schema.Index{{Name: "REGION_IDX", Table: users, Parts: []*schema.IndexPart{{SeqNo: 1, C: users.Columns[1]}}, Attrs: []schema.Attr{&LocalIndex{}}}}
+	for _, tt := range []struct {
+		opts []Option
+		want []string
+	}{
+		{
+			want: []string{
+				`CREATE TABLE ATLAS.USERS (ID number(10) NOT NULL, REGION number(10) NOT NULL, CONSTRAINT USERS_PK PRIMARY KEY (ID, REGION) USING INDEX LOCAL)`,
+				`CREATE INDEX ATLAS.REGION_IDX ON ATLAS.USERS (REGION) LOCAL`,
+			},
+		},
+		{
+			opts: []Option{WithSeparatePrimaryKeys()},
+			want: []string{
+				`CREATE TABLE ATLAS.USERS (ID number(10) NOT NULL, REGION number(10) NOT NULL)`,
+				`CREATE UNIQUE INDEX ATLAS.USERS_PK ON ATLAS.USERS (ID, REGION) LOCAL`,
+				`ALTER TABLE ATLAS.USERS ADD CONSTRAINT USERS_PK PRIMARY KEY (ID, REGION) USING INDEX ATLAS.USERS_PK`,
+				`CREATE INDEX ATLAS.REGION_IDX ON ATLAS.USERS (REGION) LOCAL`,
+			},
+		},
+	} {
+		db, m, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{m}.version("19.0.0.0.0")
+		drv, err := Open(db, tt.opts...)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.AddTable{T: users}})
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, len(tt.want))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.want[i], c.Cmd)
+		}
+	}
+}
+
+func TestPlanChanges_CoalesceColumns(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	users := &schema.Table{Name: "USERS", Schema: &schema.Schema{Name: "ATLAS"}}
+	varchar := func(name string, null bool) *schema.Column {
+		return &schema.Column{Name: name, Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar2", Size: 255}, Null: null}}
+	}
+	id := &schema.Column{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}}
+	for _, tt := range []struct {
+		name    string
+		changes []schema.Change
+		want    []*migrate.Change
+	}{
+		{
+			name: "compatible",
+			changes: []schema.Change{
+				&schema.ModifyColumn{From: varchar("NAME", false), To: varchar("NAME", true), Change: schema.ChangeNull},
+				&schema.ModifyColumn{From: varchar("NICK", true), To: &schema.Column{Name: "NICK", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar2", Size: 255}, Null: true}, Default: &schema.Literal{V: "'a8m'"}}, Change: schema.ChangeDefault},
+				&schema.AddColumn{C: varchar("EMAIL", true)},
+				&schema.AddColumn{C: varchar("PHONE", true)},
+			},
+			want: []*migrate.Change{
+				{
+					Cmd:     `ALTER TABLE ATLAS.USERS MODIFY (NAME NULL, NICK DEFAULT 'a8m')`,
+					Reverse: `ALTER TABLE ATLAS.USERS MODIFY (NAME NOT NULL, NICK DEFAULT NULL)`,
+				},
+				{
+					Cmd:     `ALTER TABLE ATLAS.USERS ADD (EMAIL varchar2(255) NULL, PHONE varchar2(255) NULL)`,
+					Reverse: `ALTER TABLE ATLAS.USERS DROP (EMAIL, PHONE)`,
+				},
+			},
+		},
+		{
+			name: "split",
+			changes: []schema.Change{
+				&schema.DropColumn{C: varchar("NAME", false)},
+				&schema.DropColumn{C: varchar("NICK", false)},
+				&schema.AddColumn{C: varchar("EMAIL", true)},
+				// Constraints, and identity changes, are not coalesced with column changes.
+				&schema.AddCheck{C: &schema.Check{Name: "EMAIL_CHECK", Expr: "EMAIL LIKE '%@%'"}},
+				&schema.ModifyColumn{From: &schema.Column{Name: "ID", Type: id.Type, Attrs: []schema.Attr{&Identity{}}}, To: id, Change: schema.ChangeAttr},
+				&schema.AddColumn{C: varchar("PHONE", true)},
+			},
+			want: []*migrate.Change{
+				{
+					Cmd: `ALTER TABLE ATLAS.USERS DROP (NAME, NICK)`,
+				},
+				{
+					Cmd:     `ALTER TABLE ATLAS.USERS ADD (EMAIL varchar2(255) NULL)`,
+					Reverse: `ALTER TABLE ATLAS.USERS DROP COLUMN EMAIL`,
+				},
+				{
+					Cmd:     `ALTER TABLE ATLAS.USERS ADD CONSTRAINT EMAIL_CHECK CHECK (EMAIL LIKE '%@%')`,
+					Reverse: `ALTER TABLE ATLAS.USERS DROP CONSTRAINT EMAIL_CHECK`,
+				},
+				{
+					Cmd: `ALTER TABLE ATLAS.USERS MODIFY (ID DROP IDENTITY)`,
+				},
+				{
+					Cmd:     `ALTER TABLE ATLAS.USERS ADD (PHONE varchar2(255) NULL)`,
+					Reverse: `ALTER TABLE ATLAS.USERS DROP COLUMN PHONE`,
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.ModifyTable{T: users, Changes: tt.changes}})
+			require.NoError(t, err)
+			require.Len(t, plan.Changes, len(tt.want))
+			for i, c := range plan.Changes {
+				require.Equal(t, tt.want[i].Cmd, c.Cmd)
+				require.Equal(t, tt.want[i].Reverse, c.Reverse)
+			}
+		})
+	}
+}
+
+func TestPlanChanges_QuoteIdentifiers(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	users := &schema.Table{
+		Name:   "USERS",
+		Schema: &schema.Schema{Name: "ATLAS"},
+		Columns: []*schema.Column{
+			{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}},
+			{Name: "FullName", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar2", Size: 100}}},
+			{Name: "NUMBER", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}},
+			{Name: "ZIP CODE", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar2", Size: 10}, Null: true}},
+		},
+	}
+	users.Indexes = []*schema.Index{{Name: "users_name", Table: users, Parts: []*schema.IndexPart{{SeqNo: 1, C: users.Columns[1]}}}}
+	plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.AddTable{T: users}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 2)
+	require.Equal(t, `CREATE TABLE ATLAS.USERS (ID number(10) NOT NULL, "FullName" varchar2(100) NOT NULL, "NUMBER" number(10) NOT NULL, "ZIP CODE" varchar2(10) NULL)`, plan.Changes[0].Cmd)
+	require.Equal(t, `CREATE INDEX ATLAS."users_name" ON ATLAS.USERS ("FullName")`, plan.Changes[1].Cmd)
+	require.Equal(t, `DROP INDEX ATLAS."users_name"`, plan.Changes[1].Reverse)
+
+	for name, want := range map[string]string{
+		"ID":        `ID`,
+		"USER_ID$":  `USER_ID$`,
+		"FullName":  `"FullName"`,
+		"users":     `"users"`,
+		"NUMBER":    `"NUMBER"`,
+		"USER":      `"USER"`,
+		"_ID":       `"_ID"`,
+		"1ST":       `"1ST"`,
+		"ZIP CODE":  `"ZIP CODE"`,
+		"ZIP-CODE":  `"ZIP-CODE"`,
+		"SYS_C0012": `SYS_C0012`,
+	} {
+		require.Equal(t, want, ident(name), name)
+	}
+}
+
+func TestPlanChanges_Tablespace(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	from := &schema.Table{
+		Name:    "USERS",
+		Schema:  &schema.Schema{Name: "ATLAS"},
+		Columns: []*schema.Column{{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}}},
+		Attrs:   []schema.Attr{&Tablespace{Name: "USERS"}},
+	}
+	from.Indexes = []*schema.Index{{Name: "ID_IDX", Table: from, Parts: []*schema.IndexPart{{SeqNo: 1, C: from.Columns[0]}}, Attrs: []schema.Attr{&IndexType{T: "NORMAL"}}}}
+	to := &schema.Table{
+		Name:    "USERS",
+		Schema:  from.Schema,
+		Columns: from.Columns,
+		Attrs:   []schema.Attr{&Tablespace{Name: "ARCHIVE"}},
+	}
+	to.Indexes = []*schema.Index{{Name: "ID_IDX", Table: to, Parts: []*schema.IndexPart{{SeqNo: 1, C: to.Columns[0]}}, Attrs: []schema.Attr{&Tablespace{Name: "INDX"}}}}
+
+	plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.AddTable{T: to}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 2)
+	require.Equal(t, `CREATE TABLE ATLAS.USERS (ID number(10) NOT NULL) TABLESPACE ARCHIVE`, plan.Changes[0].Cmd)
+	require.Equal(t, `CREATE INDEX ATLAS.ID_IDX ON ATLAS.USERS (ID) TABLESPACE INDX`, plan.Changes[1].Cmd)
+
+	changes, err := drv.TableDiff(from, to)
+	require.NoError(t, err)
+	plan, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.ModifyTable{T: to, Changes: changes}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 3)
+	require.Equal(t, `DROP INDEX ATLAS.ID_IDX`, plan.Changes[0].Cmd)
+	require.Equal(t, `ALTER TABLE ATLAS.USERS MOVE TABLESPACE ARCHIVE`, plan.Changes[1].Cmd)
+	require.Equal(t, `ALTER TABLE ATLAS.USERS MOVE TABLESPACE USERS`, plan.Changes[1].Reverse)
+	require.Equal(t, `CREATE INDEX ATLAS.ID_IDX ON ATLAS.USERS (ID) TABLESPACE INDX`, plan.Changes[2].Cmd)
+
+	// Tables without the attribute are kept in their tablespace.
+	to.Attrs, to.Indexes[0].Attrs = nil, nil
+	changes, err = drv.TableDiff(from, to)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	// Indexes that are kept are rebuilt after the table is moved.
+	to.PrimaryKey = &schema.Index{Name: "USERS_PK", Unique: true, Table: to, Parts: []*schema.IndexPart{{SeqNo: 1, C: to.Columns[0]}}}
+	plan, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.ModifyTable{T: to, Changes: []schema.Change{
+		&schema.ModifyAttr{From: &Tablespace{Name: "USERS"}, To: &Tablespace{Name: "ARCHIVE"}},
+	}}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 3)
+	require.Equal(t, `ALTER TABLE ATLAS.USERS MOVE TABLESPACE ARCHIVE`, plan.Changes[0].Cmd)
+	require.Equal(t, `ALTER INDEX ATLAS.USERS_PK REBUILD`, plan.Changes[1].Cmd)
+	require.Equal(t, `ALTER INDEX ATLAS.ID_IDX REBUILD`, plan.Changes[2].Cmd)
+
+	// The index of an unnamed primary key cannot be rebuilt.
+	to.PrimaryKey.Name = ""
+	_, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.ModifyTable{T: to, Changes: []schema.Change{
+		&schema.ModifyAttr{From: &Tablespace{Name: "USERS"}, To: &Tablespace{Name: "ARCHIVE"}},
+	}}})
+	require.Error(t, err)
+}
+
+func TestPlanChanges_Portable(t *testing.T) {
+	users := &schema.Table{
+		Name:   "USERS",
+		Schema: &schema.Schema{Name: "ATLAS"},
+		Columns: []*schema.Column{
+			{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}},
+			{Name: "NAME", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar2", Size: 100}}},
+		},
+		Attrs: []schema.Attr{&Tablespace{Name: "USERS"}, &RowDependencies{}},
+	}
+	users.Indexes = []*schema.Index{
+		{Name: "ID_IDX", Table: users, Parts: []*schema.IndexPart{{SeqNo: 1, C: users.Columns[0]}}, Attrs: []schema.Attr{&IndexType{T: "NORMAL/REV"}, &Tablespace{Name: "INDX"}}},
+	}
+	for _, tt := range []struct {
+		opts   []Option
+		expect []string
+	}{
+		{
+			expect: []string{
+				`CREATE TABLE ATLAS.USERS (ID number(10) NOT NULL, NAME varchar2(100) NOT NULL) TABLESPACE USERS ROWDEPENDENCIES`,
+				`CREATE INDEX ATLAS.ID_IDX ON ATLAS.USERS (ID) REVERSE TABLESPACE INDX`,
+			},
+		},
+		{
+			opts: []Option{WithPortable()},
+			expect: []string{
+				`CREATE TABLE ATLAS.USERS (ID number(10) NOT NULL, NAME varchar2(100) NOT NULL)`,
+				`CREATE INDEX ATLAS.ID_IDX ON ATLAS.USERS (ID)`,
+			},
+		},
+	} {
+		db, m, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{m}.version("19.0.0.0.0")
+		drv, err := Open(db, tt.opts...)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.AddTable{T: users}})
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, len(tt.expect))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.expect[i], c.Cmd)
+		}
+	}
+}
+
+func TestPlanChanges_ConstraintState(t *testing.T) {
+	users := &schema.Table{
+		Name:    "USERS",
+		Schema:  &schema.Schema{Name: "ATLAS"},
+		Columns: []*schema.Column{{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}}},
+	}
+	posts := &schema.Table{
+		Name:   "POSTS",
+		Schema: users.Schema,
+		Columns: []*schema.Column{
+			{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}},
+			{Name: "AUTHOR_ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}},
+		},
+		Attrs: []schema.Attr{
+			&ConstraintState{Name: "AUTHOR_FK", Deferrable: true, InitiallyDeferred: true},
+			&schema.Check{Name: "ID_POSITIVE", Expr: `"ID" > 0`, Attrs: []schema.Attr{&ConstraintState{Name: "ID_POSITIVE", Disabled: true, NoValidate: true}}},
+		},
+	}
+	posts.ForeignKeys = []*schema.ForeignKey{
+		{Symbol: "AUTHOR_FK", Table: posts, Columns: posts.Columns[1:], RefTable: users, RefColumns: users.Columns},
+	}
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.AddTable{T: posts}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `CREATE TABLE ATLAS.POSTS (ID number(10) NOT NULL, AUTHOR_ID number(10) NOT NULL, CONSTRAINT AUTHOR_FK FOREIGN KEY (AUTHOR_ID) REFERENCES ATLAS.USERS (ID) DEFERRABLE INITIALLY DEFERRED, CONSTRAINT ID_POSITIVE CHECK ("ID" > 0) DISABLE NOVALIDATE)`, plan.Changes[0].Cmd)
+	plan, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{
+		&schema.ModifyTable{T: posts, Changes: []schema.Change{&schema.DropForeignKey{F: posts.ForeignKeys[0]}}},
+	})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `ALTER TABLE ATLAS.POSTS ADD CONSTRAINT AUTHOR_FK FOREIGN KEY (AUTHOR_ID) REFERENCES ATLAS.USERS (ID) DEFERRABLE INITIALLY DEFERRED`, plan.Changes[0].Reverse)
+
+	// The ENABLE and VALIDATE states are altered in place.
+	check := &schema.Check{Name: "ID_POSITIVE", Expr: `"ID" > 0`}
+	plan, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{
+		&schema.ModifyTable{T: posts, Changes: []schema.Change{
+			&schema.ModifyCheck{From: posts.Attrs[1].(*schema.Check), To: check},
+			&schema.ModifyAttr{From: &ConstraintState{Name: "AUTHOR_FK", Deferrable: true, InitiallyDeferred: true}, To: &ConstraintState{Name: "AUTHOR_FK", Deferrable: true, InitiallyDeferred: true, NoValidate: true}},
+		}},
+	})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 2)
+	require.Equal(t, `ALTER TABLE ATLAS.POSTS MODIFY CONSTRAINT ID_POSITIVE ENABLE VALIDATE`, plan.Changes[0].Cmd)
+	require.Equal(t, `ALTER TABLE ATLAS.POSTS MODIFY CONSTRAINT ID_POSITIVE DISABLE NOVALIDATE`, plan.Changes[0].Reverse)
+	require.Equal(t, `ALTER TABLE ATLAS.POSTS MODIFY CONSTRAINT AUTHOR_FK ENABLE NOVALIDATE`, plan.Changes[1].Cmd)
+	require.Equal(t, `ALTER TABLE ATLAS.POSTS MODIFY CONSTRAINT AUTHOR_FK ENABLE VALIDATE`, plan.Changes[1].Reverse)
+
+	// DEFERRABLE changes require re-creating the constraint.
+	plan, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{
+		&schema.ModifyTable{T: posts, Changes: []schema.Change{
+			&schema.ModifyCheck{From: check, To: posts.Attrs[1].(*schema.Check)},
+			&schema.ModifyAttr{From: &ConstraintState{Name: "AUTHOR_FK"}, To: &ConstraintState{Name: "AUTHOR_FK", Deferrable: true, InitiallyDeferred: true}},
+		}},
+	})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 3)
+	require.Equal(t, `ALTER TABLE ATLAS.POSTS MODIFY CONSTRAINT ID_POSITIVE DISABLE NOVALIDATE`, plan.Changes[0].Cmd)
+	require.Equal(t, `ALTER TABLE ATLAS.POSTS DROP CONSTRAINT AUTHOR_FK`, plan.Changes[1].Cmd)
+	require.Equal(t, `ALTER TABLE ATLAS.POSTS ADD CONSTRAINT AUTHOR_FK FOREIGN KEY (AUTHOR_ID) REFERENCES ATLAS.USERS (ID)`, plan.Changes[1].Reverse)
+	require.Equal(t, `ALTER TABLE ATLAS.POSTS ADD CONSTRAINT AUTHOR_FK FOREIGN KEY (AUTHOR_ID) REFERENCES ATLAS.USERS (ID) DEFERRABLE INITIALLY DEFERRED`, plan.Changes[2].Cmd)
+	require.Equal(t, `ALTER TABLE ATLAS.POSTS DROP CONSTRAINT AUTHOR_FK`, plan.Changes[2].Reverse)
+
+	check.Attrs = []schema.Attr{&ConstraintState{Name: "ID_POSITIVE", Deferrable: true}}
+	plan, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{
+		&schema.ModifyTable{T: posts, Changes: []schema.Change{
+			&schema.ModifyCheck{From: posts.Attrs[1].(*schema.Check), To: check},
+		}},
+	})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 2)
+	require.Equal(t, `ALTER TABLE ATLAS.POSTS DROP CONSTRAINT ID_POSITIVE`, plan.Changes[0].Cmd)
+	require.Equal(t, `ALTER TABLE ATLAS.POSTS ADD CONSTRAINT ID_POSITIVE CHECK ("ID" > 0) DEFERRABLE`, plan.Changes[1].Cmd)
+}
+
+func TestPlanChanges_NotNullState(t *testing.T) {
+	users := &schema.Table{
+		Name:   "USERS",
+		Schema: &schema.Schema{Name: "ATLAS"},
+		Columns: []*schema.Column{
+			{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}, Attrs: []schema.Attr{&ConstraintState{Name: "SYS_C008012", NoValidate: true}}},
+			{Name: "NAME", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar2", Size: 100}}},
+		},
+	}
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.AddTable{T: users}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `CREATE TABLE ATLAS.USERS (ID number(10) NOT NULL ENABLE NOVALIDATE, NAME varchar2(100) NOT NULL)`, plan.Changes[0].Cmd)
+
+	// Validating the existing rows of an inspected constraint.
+	id := &schema.Column{Name: "ID", Type: users.Columns[0].Type}
+	kind, err := (&diff{drv.conn}).ColumnChange(users.Columns[0], id)
+	require.NoError(t, err)
+	require.Equal(t, schema.ChangeAttr, kind)
+	// Skipping the validation of the rows of a new NOT NULL constraint.
+	name := &schema.Column{Name: "NAME", Type: &schema.ColumnType{Type: users.Columns[1].Type.Type, Null: true}}
+	plan, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{
+		&schema.ModifyTable{T: users, Changes: []schema.Change{
+			&schema.ModifyColumn{From: users.Columns[0], To: id, Change: kind},
+			&schema.ModifyColumn{From: name, To: &schema.Column{Name: "NAME", Type: users.Columns[1].Type, Attrs: []schema.Attr{&ConstraintState{NoValidate: true}}}, Change: schema.ChangeNull},
+		}},
+	})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 2)
+	require.Equal(t, `ALTER TABLE ATLAS.USERS MODIFY (NAME NOT NULL ENABLE NOVALIDATE)`, plan.Changes[0].Cmd)
+	require.Equal(t, `ALTER TABLE ATLAS.USERS MODIFY CONSTRAINT SYS_C008012 ENABLE VALIDATE`, plan.Changes[1].Cmd)
+	require.Equal(t, `ALTER TABLE ATLAS.USERS MODIFY CONSTRAINT SYS_C008012 ENABLE NOVALIDATE`, plan.Changes[1].Reverse)
+
+	// Constraints without a known name are re-created.
+	plan, err = drv.PlanChanges(context.Background(), "plan", []schema.Change{
+		&schema.ModifyTable{T: users, Changes: []schema.Change{
+			&schema.ModifyColumn{From: users.Columns[1], To: &schema.Column{Name: "NAME", Type: users.Columns[1].Type, Attrs: []schema.Attr{&ConstraintState{NoValidate: true}}}, Change: schema.ChangeAttr},
+		}},
+	})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 2)
+	require.Equal(t, `ALTER TABLE ATLAS.USERS MODIFY (NAME NULL)`, plan.Changes[0].Cmd)
+	require.Equal(t, `ALTER TABLE ATLAS.USERS MODIFY (NAME NOT NULL ENABLE NOVALIDATE)`, plan.Changes[1].Cmd)
+	require.Equal(t, `ALTER TABLE ATLAS.USERS MODIFY (NAME NULL)`, plan.Changes[1].Reverse)
+}
+
+func TestPlanChanges_ReferenceOptions(t *testing.T) {
+	users := &schema.Table{
+		Name:    "USERS",
+		Schema:  &schema.Schema{Name: "ATLAS"},
+		Columns: []*schema.Column{{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}}},
+	}
+	posts := &schema.Table{
+		Name:   "POSTS",
+		Schema: users.Schema,
+		Columns: []*schema.Column{
+			{Name: "AUTHOR_ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}, Null: true}},
+		},
+	}
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	for _, tt := range []struct {
+		fk      *schema.ForeignKey
+		wantCmd string
+		wantErr string
+	}{
+		{
+			fk:      &schema.ForeignKey{Symbol: "AUTHOR_FK", OnDelete: schema.Cascade},
+			wantCmd: `ALTER TABLE ATLAS.POSTS ADD CONSTRAINT AUTHOR_FK FOREIGN KEY (AUTHOR_ID) REFERENCES ATLAS.USERS (ID) ON DELETE CASCADE`,
+		},
+		{
+			fk:      &schema.ForeignKey{Symbol: "AUTHOR_FK", OnDelete: schema.SetNull},
+			wantCmd: `ALTER TABLE ATLAS.POSTS ADD CONSTRAINT AUTHOR_FK FOREIGN KEY (AUTHOR_ID) REFERENCES ATLAS.USERS (ID) ON DELETE SET NULL`,
+		},
+		// NO ACTION is the default rule, and it cannot be written explicitly.
+		{
+			fk:      &schema.ForeignKey{Symbol: "AUTHOR_FK", OnUpdate: schema.NoAction, OnDelete: schema.NoAction},
+			wantCmd: `ALTER TABLE ATLAS.POSTS ADD CONSTRAINT AUTHOR_FK FOREIGN KEY (AUTHOR_ID) REFERENCES ATLAS.USERS (ID)`,
+		},
+		{
+			fk:      &schema.ForeignKey{Symbol: "AUTHOR_FK", OnUpdate: schema.Cascade},
+			wantErr: `oracle: unsupported ON UPDATE CASCADE action of foreign key "AUTHOR_FK"`,
+		},
+		{
+			fk:      &schema.ForeignKey{Symbol: "AUTHOR_FK", OnDelete: schema.SetDefault},
+			wantErr: `oracle: unsupported ON DELETE SET DEFAULT action of foreign key "AUTHOR_FK"`,
+		},
+	} {
+		tt.fk.Table, tt.fk.Columns = posts, posts.Columns
+		tt.fk.RefTable, tt.fk.RefColumns = users, users.Columns
+		plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{
+			&schema.ModifyTable{T: posts, Changes: []schema.Change{&schema.AddForeignKey{F: tt.fk}}},
+		})
+		if tt.wantErr != "" {
+			require.EqualError(t, err, tt.wantErr)
+			continue
+		}
+		require.NoError(t, err)
+		require.Len(t, plan.Changes, 1)
+		require.Equal(t, tt.wantCmd, plan.Changes[0].Cmd)
+	}
+}
+
+func TestPlanChanges_Errors(t *testing.T) {
+	t1 := &schema.Table{Name: "T1", Columns: []*schema.Column{{Name: "C1", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number"}}}}}
+	t2 := &schema.Table{Name: "T2", Columns: []*schema.Column{{Name: "C1", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number"}}}}}
+	t2.ForeignKeys = []*schema.ForeignKey{
+		{Symbol: "T2_FK", Table: t2, Columns: t2.Columns, RefTable: t1, RefColumns: t1.Columns, OnUpdate: schema.Cascade},
+	}
+	for _, changes := range [][]schema.Change{
+		{&schema.AddTable{T: t1, Extra: []schema.Clause{&schema.IfNotExists{}}}},
+		{&schema.DropTable{T: t1, Extra: []schema.Clause{&schema.IfExists{}}}},
+		{&schema.AddTable{T: t2}},
+		{&schema.AddSchema{S: &schema.Schema{Name: "ATLAS"}}},
+		{&schema.ModifyTable{T: t1, Changes: []schema.Change{&schema.AddIndex{I: &schema.Index{Name: "T1_IDX", Unique: true, Parts: []*schema.IndexPart{{SeqNo: 1, C: t1.Columns[0]}}, Attrs: []schema.Attr{&IndexType{T: "BITMAP"}}}}}}},
+		{&schema.ModifyTable{T: t1, Changes: []schema.Change{&schema.AddIndex{I: &schema.Index{Name: "T1_IDX", Parts: []*schema.IndexPart{{SeqNo: 1, C: t1.Columns[0]}}, Attrs: []schema.Attr{&IndexType{T: "DOMAIN"}}}}}}},
+		// The START WITH value of emulated identity columns cannot be changed before 12c.
+		{&schema.ModifyTable{T: t1, Changes: []schema.Change{&schema.ModifyColumn{From: &schema.Column{Name: "C1", Type: t1.Columns[0].Type, Attrs: []schema.Attr{&Identity{Sequence: &Sequence{Start: 1}}}}, To: &schema.Column{Name: "C1", Type: t1.Columns[0].Type, Attrs: []schema.Attr{&Identity{Sequence: &Sequence{Start: 100}}}}, Change: schema.ChangeAttr}}}},
+		// DEFAULT ON NULL is not supported before 12c.
+		{&schema.ModifyTable{T: t1, Changes: []schema.Change{&schema.AddColumn{C: &schema.Column{Name: "C2", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number"}}, Default: &schema.Literal{V: "0"}, Attrs: []schema.Attr{&DefaultOnNull{}}}}}}},
+		// Names of emulated identity objects exceed the length limit.
+		{&schema.AddTable{T: &schema.Table{Name: "VERY_LONG_TABLE_NAME", Columns: []*schema.Column{{Name: "IDENTIFIER", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number"}}, Attrs: []schema.Attr{&Identity{}}}}}}},
+	} {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version("11.2.0.4.0")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		_, err = drv.PlanChanges(context.Background(), "plan", changes)
+		require.Error(t, err)
+	}
+}
+
+func TestPlanChanges_Identity(t *testing.T) {
+	users := &schema.Table{
+		Name:   "USERS",
+		Schema: &schema.Schema{Name: "ATLAS"},
+		Columns: []*schema.Column{
+			{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}, Attrs: []schema.Attr{&Identity{Generation: "BY DEFAULT ON NULL", Sequence: &Sequence{Start: 100, Increment: 10}}}},
+			{Name: "SEQ", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}, Attrs: []schema.Attr{&Identity{}}},
+		},
+	}
+	tests := []struct {
+		version string
+		changes []schema.Change
+		plan    *migrate.Plan
+	}{
+		{
+			version: "19.0.0.0.0",
+			changes: []schema.Change{&schema.AddTable{T: users}},
+			plan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{Cmd: `CREATE TABLE ATLAS.USERS (ID number(10) GENERATED BY DEFAULT ON NULL AS IDENTITY (START WITH 100 INCREMENT BY 10) NOT NULL, SEQ number(10) GENERATED ALWAYS AS IDENTITY NOT NULL)`, Reverse: `DROP TABLE ATLAS.USERS`},
+				},
+			},
+		},
+		{
+			version: "19.0.0.0.0",
+			changes: []schema.Change{
+				&schema.ModifyTable{
+					T: users,
+					Changes: []schema.Change{
+						&schema.ModifyColumn{
+							From:   users.Columns[0],
+							To:     &schema.Column{Name: "ID", Type: users.Columns[0].Type, Attrs: []schema.Attr{&Identity{Generation: "BY DEFAULT", Sequence: &Sequence{Start: 100, Increment: 10}}}},
+							Change: schema.ChangeAttr,
+						},
+						&schema.ModifyColumn{
+							From:   users.Columns[1],
+							To:     &schema.Column{Name: "SEQ", Type: users.Columns[1].Type},
+							Change: schema.ChangeAttr,
+						},
+					},
+				},
+			},
+			plan: &migrate.Plan{
+				Changes: []*migrate.Change{
+					{Cmd: `ALTER TABLE ATLAS.USERS MODIFY (ID GENERATED BY DEFAULT AS IDENTITY (START WITH 100 INCREMENT BY 10))`, Reverse: `ALTER TABLE ATLAS.USERS MODIFY (ID GENERATED BY DEFAULT ON NULL AS IDENTITY (START WITH 100 INCREMENT BY 10))`},
+					{Cmd: `ALTER TABLE ATLAS.USERS MODIFY (SEQ DROP IDENTITY)`},
+				},
+			},
+		},
+		// Identity columns are emulated with a sequence and a trigger before 12c.
+		{
+			version: "11.2.0.4.0",
+			changes: []schema.Change{&schema.AddTable{T: users}},
+			plan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{Cmd: `CREATE TABLE ATLAS.USERS (ID number(10) NOT NULL, SEQ number(10) NOT NULL)`, Reverse: `DROP TABLE ATLAS.USERS`},
+					{Cmd: `CREATE SEQUENCE ATLAS.USERS_ID_SEQ START WITH 100 INCREMENT BY 10`, Reverse: `DROP SEQUENCE ATLAS.USERS_ID_SEQ`},
+					{Cmd: `CREATE OR REPLACE TRIGGER ATLAS.USERS_ID_TRG BEFORE INSERT ON ATLAS.USERS FOR EACH ROW WHEN (new.ID IS NULL) BEGIN :new.ID := ATLAS.USERS_ID_SEQ.NEXTVAL; END;`, Reverse: `DROP TRIGGER ATLAS.USERS_ID_TRG`},
+					{Cmd: `CREATE SEQUENCE ATLAS.USERS_SEQ_SEQ`, Reverse: `DROP SEQUENCE ATLAS.USERS_SEQ_SEQ`},
+					{Cmd: `CREATE OR REPLACE TRIGGER ATLAS.USERS_SEQ_TRG BEFORE INSERT ON ATLAS.USERS FOR EACH ROW BEGIN :new.SEQ := ATLAS.USERS_SEQ_SEQ.NEXTVAL; END;`, Reverse: `DROP TRIGGER ATLAS.USERS_SEQ_TRG`},
+				},
+			},
+		},
+		{
+			version: "11.2.0.4.0",
+			changes: []schema.Change{
+				&schema.ModifyTable{
+					T: users,
+					Changes: []schema.Change{
+						&schema.AddColumn{C: &schema.Column{Name: "NO", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}, Attrs: []schema.Attr{&Identity{Generation: "BY DEFAULT"}}}},
+					},
+				},
+			},
+			plan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{Cmd: `ALTER TABLE ATLAS.USERS ADD (NO number(10) NOT NULL)`, Reverse: `ALTER TABLE ATLAS.USERS DROP COLUMN NO`},
+					{Cmd: `CREATE SEQUENCE ATLAS.USERS_NO_SEQ`, Reverse: `DROP SEQUENCE ATLAS.USERS_NO_SEQ`},
+					{Cmd: `CREATE OR REPLACE TRIGGER ATLAS.USERS_NO_TRG BEFORE INSERT ON ATLAS.USERS FOR EACH ROW WHEN (new.NO IS NULL) BEGIN :new.NO := ATLAS.USERS_NO_SEQ.NEXTVAL; END;`, Reverse: `DROP TRIGGER ATLAS.USERS_NO_TRG`},
+				},
+			},
+		},
+		// Emulated identity columns are changed by their sequences and triggers.
+		{
+			version: "11.2.0.4.0",
+			changes: []schema.Change{
+				&schema.ModifyTable{
+					T: users,
+					Changes: []schema.Change{
+						&schema.ModifyColumn{
+							From:   &schema.Column{Name: "ID", Type: users.Columns[0].Type, Attrs: []schema.Attr{&Identity{Generation: "BY DEFAULT", Sequence: &Sequence{Increment: 10, Last: 141}}}},
+							To:     &schema.Column{Name: "ID", Type: users.Columns[0].Type, Attrs: []schema.Attr{&Identity{Sequence: &Sequence{Start: 100, Increment: 5}}}},
+							Change: schema.ChangeAttr,
+						},
+						&schema.ModifyColumn{
+							From:   &schema.Column{Name: "SEQ", Type: users.Columns[1].Type, Attrs: []schema.Attr{&Identity{Generation: "ALWAYS", Sequence: &Sequence{Increment: 1, Last: 21}}}},
+							To:     &schema.Column{Name: "SEQ", Type: users.Columns[1].Type},
+							Change: schema.ChangeAttr,
+						},
+						&schema.ModifyColumn{
+							From:   &schema.Column{Name: "NO", Type: users.Columns[1].Type},
+							To:     &schema.Column{Name: "NO", Type: users.Columns[1].Type, Attrs: []schema.Attr{&Identity{}}},
+							Change: schema.ChangeAttr,
+						},
+					},
+				},
+			},
+			plan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{Cmd: `CREATE OR REPLACE TRIGGER ATLAS.USERS_ID_TRG BEFORE INSERT ON ATLAS.USERS FOR EACH ROW BEGIN :new.ID := ATLAS.USERS_ID_SEQ.NEXTVAL; END;`, Reverse: `CREATE OR REPLACE TRIGGER ATLAS.USERS_ID_TRG BEFORE INSERT ON ATLAS.USERS FOR EACH ROW WHEN (new.ID IS NULL) BEGIN :new.ID := ATLAS.USERS_ID_SEQ.NEXTVAL; END;`},
+					{Cmd: `ALTER SEQUENCE ATLAS.USERS_ID_SEQ INCREMENT BY 5`, Reverse: `ALTER SEQUENCE ATLAS.USERS_ID_SEQ INCREMENT BY 10`},
+					{Cmd: `DROP TRIGGER ATLAS.USERS_SEQ_TRG`, Reverse: `CREATE OR REPLACE TRIGGER ATLAS.USERS_SEQ_TRG BEFORE INSERT ON ATLAS.USERS FOR EACH ROW BEGIN :new.SEQ := ATLAS.USERS_SEQ_SEQ.NEXTVAL; END;`},
+					{Cmd: `DROP SEQUENCE ATLAS.USERS_SEQ_SEQ`, Reverse: `CREATE SEQUENCE ATLAS.USERS_SEQ_SEQ START WITH 21`},
+					{Cmd: `CREATE SEQUENCE ATLAS.USERS_NO_SEQ`, Reverse: `DROP SEQUENCE ATLAS.USERS_NO_SEQ`},
+					{Cmd: `CREATE OR REPLACE TRIGGER ATLAS.USERS_NO_TRG BEFORE INSERT ON ATLAS.USERS FOR EACH ROW BEGIN :new.NO := ATLAS.USERS_NO_SEQ.NEXTVAL; END;`, Reverse: `DROP TRIGGER ATLAS.USERS_NO_TRG`},
+				},
+			},
+		},
+		// The sequences of emulated identity columns are dropped with their tables.
+		{
+			version: "11.2.0.4.0",
+			changes: []schema.Change{&schema.DropTable{T: users}},
+			plan: &migrate.Plan{
+				Changes: []*migrate.Change{
+					{Cmd: `DROP TABLE ATLAS.USERS`},
+					{Cmd: `DROP SEQUENCE ATLAS.USERS_ID_SEQ`},
+					{Cmd: `DROP SEQUENCE ATLAS.USERS_SEQ_SEQ`},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version(tt.version)
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", tt.changes)
+		require.NoError(t, err)
+		require.Equal(t, tt.plan.Reversible, plan.Reversible)
+		require.Len(t, plan.Changes, len(tt.plan.Changes))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.plan.Changes[i].Cmd, c.Cmd)
+			require.Equal(t, tt.plan.Changes[i].Reverse, c.Reverse)
+		}
+	}
+}
+
+func TestPlanChanges_Sequences(t *testing.T) {
+	s := &schema.Schema{Name: "ATLAS"}
+	tests := []struct {
+		changes []schema.Change
+		plan    *migrate.Plan
+	}{
+		{
+			changes: []schema.Change{
+				&schema.AddAttr{A: &Sequence{Name: "ORDERS_SEQ", Start: 1000, Increment: 10, Max: 99999, Cache: 20, Cycle: true}},
+				&schema.AddAttr{A: &Sequence{Name: "TICKETS_SEQ", Start: 1, Increment: 1, Scale: true, Extend: true, Session: true}},
+			},
+			plan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{Cmd: `CREATE SEQUENCE ATLAS.ORDERS_SEQ START WITH 1000 INCREMENT BY 10 MAXVALUE 99999 CYCLE`, Reverse: `DROP SEQUENCE ATLAS.ORDERS_SEQ`},
+					{Cmd: `CREATE SEQUENCE ATLAS.TICKETS_SEQ NOCACHE SCALE EXTEND SESSION`, Reverse: `DROP SEQUENCE ATLAS.TICKETS_SEQ`},
+				},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.ModifyAttr{
+					From: &Sequence{Name: "ORDERS_SEQ", Start: 1000, Increment: 10, Max: 99999, Cache: 20, Cycle: true},
+					To:   &Sequence{Name: "ORDERS_SEQ", Start: 1000, Increment: 5, Cache: 100, Cycle: true, Order: true},
+				},
+			},
+			plan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{Cmd: `ALTER SEQUENCE ATLAS.ORDERS_SEQ INCREMENT BY 5 NOMAXVALUE CACHE 100 ORDER`, Reverse: `ALTER SEQUENCE ATLAS.ORDERS_SEQ INCREMENT BY 10 MAXVALUE 99999 CACHE 20 NOORDER`},
+				},
+			},
+		},
+		// START WITH cannot be altered.
+		{
+			changes: []schema.Change{
+				&schema.ModifyAttr{
+					From: &Sequence{Name: "ORDERS_SEQ", Start: 1000, Increment: 10, Cache: 20},
+					To:   &Sequence{Name: "ORDERS_SEQ", Start: 5000, Increment: 10, Cache: 20},
+				},
+			},
+			plan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{Cmd: `DROP SEQUENCE ATLAS.ORDERS_SEQ`, Reverse: `CREATE SEQUENCE ATLAS.ORDERS_SEQ START WITH 1000 INCREMENT BY 10`},
+					{Cmd: `CREATE SEQUENCE ATLAS.ORDERS_SEQ START WITH 5000 INCREMENT BY 10`, Reverse: `DROP SEQUENCE ATLAS.ORDERS_SEQ`},
+				},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.DropAttr{A: &Sequence{Name: "TICKETS_SEQ", Start: 1, Increment: -1, Cache: 20}},
+			},
+			plan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{Cmd: `DROP SEQUENCE ATLAS.TICKETS_SEQ`, Reverse: `CREATE SEQUENCE ATLAS.TICKETS_SEQ INCREMENT BY -1`},
+				},
+			},
+		},
+		// Inspected sequences are re-created from their last number, and
+		// their START WITH value is not compared, as it is not reported.
+		{
+			changes: []schema.Change{
+				&schema.ModifyAttr{
+					From: &Sequence{Name: "ORDERS_SEQ", Last: 21, Increment: 1, Cache: 20},
+					To:   &Sequence{Name: "ORDERS_SEQ", Start: 1, Increment: 2, Cache: 20},
+				},
+				&schema.DropAttr{A: &Sequence{Name: "TICKETS_SEQ", Last: 41, Increment: 1, Cache: 20}},
+			},
+			plan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{Cmd: `ALTER SEQUENCE ATLAS.ORDERS_SEQ INCREMENT BY 2`, Reverse: `ALTER SEQUENCE ATLAS.ORDERS_SEQ INCREMENT BY 1`},
+					{Cmd: `DROP SEQUENCE ATLAS.TICKETS_SEQ`, Reverse: `CREATE SEQUENCE ATLAS.TICKETS_SEQ START WITH 41`},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version("19.0.0.0.0")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.ModifySchema{S: s, Changes: tt.changes}})
+		require.NoError(t, err)
+		require.Equal(t, tt.plan.Reversible, plan.Reversible)
+		require.Len(t, plan.Changes, len(tt.plan.Changes))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.plan.Changes[i].Cmd, c.Cmd)
+			require.Equal(t, tt.plan.Changes[i].Reverse, c.Reverse)
+		}
+	}
+}
+
+func TestPlanChanges_Comments(t *testing.T) {
+	users := &schema.Table{
+		Name:   "USERS",
+		Schema: &schema.Schema{Name: "ATLAS"},
+		Columns: []*schema.Column{
+			{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}, Attrs: []schema.Attr{&schema.Comment{Text: "the user's id"}}},
+			{Name: "NAME", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar2", Size: 100}}},
+		},
+		Attrs: []schema.Attr{&schema.Comment{Text: "registered users"}},
+	}
+	tests := []struct {
+		changes []schema.Change
+		plan    *migrate.Plan
+	}{
+		{
+			changes: []schema.Change{&schema.AddTable{T: users}},
+			plan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{Cmd: `CREATE TABLE ATLAS.USERS (ID number(10) NOT NULL, NAME varchar2(100) NOT NULL)`, Reverse: `DROP TABLE ATLAS.USERS`},
+					{Cmd: `COMMENT ON TABLE ATLAS.USERS IS 'registered users'`, Reverse: `COMMENT ON TABLE ATLAS.USERS IS ''`},
+					{Cmd: `COMMENT ON COLUMN ATLAS.USERS.ID IS 'the user''s id'`, Reverse: `COMMENT ON COLUMN ATLAS.USERS.ID IS ''`},
+				},
+			},
+		},
+		{
+			changes: []schema.Change{
+				&schema.ModifyTable{
+					T: users,
+					Changes: []schema.Change{
+						&schema.DropAttr{A: &schema.Comment{Text: "registered users"}},
+						&schema.ModifyColumn{
+							From:   users.Columns[0],
+							To:     &schema.Column{Name: "ID", Type: users.Columns[0].Type, Attrs: []schema.Attr{&schema.Comment{Text: "user id"}}},
+							Change: schema.ChangeComment,
+						},
+						&schema.ModifyColumn{
+							From:   users.Columns[1],
+							To:     &schema.Column{Name: "NAME", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar2", Size: 200}}, Attrs: []schema.Attr{&schema.Comment{Text: "full name"}}},
+							Change: schema.ChangeType | schema.ChangeComment,
+						},
+					},
+				},
+			},
+			plan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{Cmd: `ALTER TABLE ATLAS.USERS MODIFY (NAME varchar2(200))`, Reverse: `ALTER TABLE ATLAS.USERS MODIFY (NAME varchar2(100))`},
+					{Cmd: `COMMENT ON TABLE ATLAS.USERS IS ''`, Reverse: `COMMENT ON TABLE ATLAS.USERS IS 'registered users'`},
+					{Cmd: `COMMENT ON COLUMN ATLAS.USERS.ID IS 'user id'`, Reverse: `COMMENT ON COLUMN ATLAS.USERS.ID IS 'the user''s id'`},
+					{Cmd: `COMMENT ON COLUMN ATLAS.USERS.NAME IS 'full name'`, Reverse: `COMMENT ON COLUMN ATLAS.USERS.NAME IS ''`},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version("19.0.0.0.0")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", tt.changes)
+		require.NoError(t, err)
+		require.Equal(t, tt.plan.Reversible, plan.Reversible)
+		require.Len(t, plan.Changes, len(tt.plan.Changes))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.plan.Changes[i].Cmd, c.Cmd)
+			require.Equal(t, tt.plan.Changes[i].Reverse, c.Reverse)
+		}
+	}
+}
+
+func TestPlanChanges_Indexes(t *testing.T) {
+	orders := &schema.Table{
+		Name:   "ORDERS",
+		Schema: &schema.Schema{Name: "ATLAS"},
+		Columns: []*schema.Column{
+			{Name: "ID", Type: &schema.ColumnType{Type: &schema.DecimalType{T: "number", Precision: 10}}},
+			{Name: "STATUS", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar2", Size: 10}}},
+			{Name: "CREATED", Type: &schema.ColumnType{Type: &schema.TimeType{T: "date"}}},
+			{Name: "EMAIL", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar2", Size: 255}}},
+		},
+	}
+	status := &schema.Index{Name: "STATUS_IDX", Table: orders, Parts: []*schema.IndexPart{{SeqNo: 1, C: orders.Columns[1]}}, Attrs: []schema.Attr{&IndexType{T: "BITMAP"}}}
+	tests := []struct {
+		changes []schema.Change
+		plan    *migrate.Plan
+	}{
+		{
+			changes: []schema.Change{
+				&schema.AddIndex{I: status},
+				&schema.AddIndex{I: &schema.Index{Name: "CREATED_IDX", Table: orders, Parts: []*schema.IndexPart{
+					{SeqNo: 1, C: orders.Columns[2], Attrs: []schema.Attr{&IndexColumnProperty{Desc: true}}},
+					{SeqNo: 2, C: orders.Columns[0]},
+				}, Attrs: []schema.Attr{&IndexType{T: "FUNCTION-BASED NORMAL"}}}},
+				&schema.AddIndex{I: &schema.Index{Name: "EMAIL_IDX", Unique: true, Table: orders, Parts: []*schema.IndexPart{
+					{SeqNo: 1, X: &schema.RawExpr{X: `LOWER("EMAIL")`}},
+				}, Attrs: []schema.Attr{&IndexType{T: "FUNCTION-BASED NORMAL"}}}},
+				&schema.AddIndex{I: &schema.Index{Name: "ID_IDX", Table: orders, Parts: []*schema.IndexPart{{SeqNo: 1, C: orders.Columns[0]}}, Attrs: []schema.Attr{&IndexType{T: "NORMAL/REV"}}}},
+			},
+			plan: &migrate.Plan{
+				Reversible: true,
+				Changes: []*migrate.Change{
+					{Cmd: `CREATE BITMAP INDEX ATLAS.STATUS_IDX ON ATLAS.ORDERS (STATUS)`, Reverse: `DROP INDEX ATLAS.STATUS_IDX`},
+					{Cmd: `CREATE INDEX ATLAS.CREATED_IDX ON ATLAS.ORDERS (CREATED DESC, ID)`, Reverse: `DROP INDEX ATLAS.CREATED_IDX`},
+					{Cmd: `CREATE UNIQUE INDEX ATLAS.EMAIL_IDX ON ATLAS.ORDERS (LOWER("EMAIL"))`, Reverse: `DROP INDEX ATLAS.EMAIL_IDX`},
+					{Cmd: `CREATE INDEX ATLAS.ID_IDX ON ATLAS.ORDERS (ID) REVERSE`, Reverse: `DROP INDEX ATLAS.ID_IDX`},
+				},
+			},
+		},
+		// The type of an index cannot be altered.
+		{
+			changes: []schema.Change{
+				&schema.ModifyIndex{
+					From:   &schema.Index{Name: "STATUS_IDX", Table: orders, Parts: status.Parts},
+					To:     status,
+					Change: schema.ChangeAttr,
+				},
+			},
+			plan: &migrate.Plan{
+				Changes: []*migrate.Change{
+					{Cmd: `DROP INDEX ATLAS.STATUS_IDX`},
+					{Cmd: `CREATE BITMAP INDEX ATLAS.STATUS_IDX ON ATLAS.ORDERS (STATUS)`, Reverse: `DROP INDEX ATLAS.STATUS_IDX`},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		db, mk, err := sqlmock.New()
+		require.NoError(t, err)
+		mock{mk}.version("19.0.0.0.0")
+		drv, err := Open(db)
+		require.NoError(t, err)
+		plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.ModifyTable{T: orders, Changes: tt.changes}})
+		require.NoError(t, err)
+		require.Equal(t, tt.plan.Reversible, plan.Reversible)
+		require.Len(t, plan.Changes, len(tt.plan.Changes))
+		for i, c := range plan.Changes {
+			require.Equal(t, tt.plan.Changes[i].Cmd, c.Cmd)
+			require.Equal(t, tt.plan.Changes[i].Reverse, c.Reverse)
+		}
+	}
+}
+
+func TestPlanChanges_External(t *testing.T) {
+	db, mk, err := sqlmock.New()
+	require.NoError(t, err)
+	m := mock{mk}
+	m.version("19.0.0.0.0")
+	params := `RECORDS DELIMITED BY NEWLINE
+    FIELDS TERMINATED BY ','
+    MISSING FIELD VALUES ARE NULL`
+	m.ExpectQuery(sqltest.Escape(tableSchemaQuery)).
+		WithArgs("USERS_EXT", "ATLAS").
+		WillReturnRows(sqlmock.NewRows([]string{"OWNER", "COMMENTS", "DEGREE", "INSTANCES", "DEPENDENCIES", "TEMPORARY", "DURATION", "TYPE_NAME", "DEFAULT_DIRECTORY_NAME", "ACCESS_PARAMETERS", "TABLESPACE_NAME", "IOT_TYPE", "PCT_THRESHOLD", "OVERFLOW_NAME", "MAPPING_NAME"}).
+			AddRow("ATLAS", nil, "         1", "         1", "DISABLED", "N", nil, "ORACLE_LOADER", "DATA_DIR", "\n  "+params+"\n", nil, nil, nil, nil, nil))
+	m.ExpectQuery(sqltest.Escape(externalLocationsQuery)).
+		WithArgs("ATLAS", "USERS_EXT").
+		WillReturnRows(sqlmock.NewRows([]string{"DIRECTORY_NAME", "LOCATION"}).
+			AddRow("DATA_DIR", "users.csv").
+			AddRow("ARCHIVE_DIR", "users_2020.csv"))
+	m.ExpectQuery(sqltest.Escape(columnsQuery)).
+		WithArgs("ATLAS", "USERS_EXT").
+		WillReturnRows(sqlmock.NewRows([]string{"COLUMN_NAME", "DATA_TYPE", "NULLABLE", "DATA_DEFAULT", "DATA_LENGTH", "CHAR_LENGTH", "DATA_PRECISION", "DATA_SCALE", "CHARACTER_SET_NAME", "IDENTITY_COLUMN", "GENERATION_TYPE", "IDENTITY_OPTIONS", "COMMENTS", "VIRTUAL_COLUMN", "HIDDEN_COLUMN", "USER_GENERATED", "CHAR_USED", "COLLATION", "DEFAULT_ON_NULL", "SEQUENCE_NAME"}).
+			AddRow("ID", "NUMBER", "Y", nil, 22, 0, 10, 0, nil, "NO", nil, nil, nil, "NO", "NO", "YES", nil, nil, nil, nil).
+			AddRow("NAME", "VARCHAR2", "Y", nil, 100, 100, nil, nil, "CHAR_CS", "NO", nil, nil, nil, "NO", "NO", "YES", "B", nil, nil, nil))
+	m.noIndexes()
+	m.noFKs()
+	m.noChecks()
+	drv, err := Open(db)
+	require.NoError(t, err)
+	tbl, err := drv.InspectTable(context.Background(), "USERS_EXT", &schema.InspectTableOptions{Schema: "ATLAS"})
+	require.NoError(t, err)
+	require.Equal(t, []schema.Attr{
+		&External{
+			Type:      "ORACLE_LOADER",
+			Directory: "DATA_DIR",
+			Params:    params,
+			Locations: []ExternalLocation{{Name: "users.csv"}, {Directory: "ARCHIVE_DIR", Name: "users_2020.csv"}},
+		},
+	}, tbl.Attrs)
+	plan, err := drv.PlanChanges(context.Background(), "plan", []schema.Change{&schema.AddTable{T: tbl}})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `CREATE TABLE ATLAS.USERS_EXT (ID number(10) NULL, NAME varchar2(100) NULL) ORGANIZATION EXTERNAL (TYPE ORACLE_LOADER DEFAULT DIRECTORY DATA_DIR ACCESS PARAMETERS (`+params+`) LOCATION ('users.csv', ARCHIVE_DIR:'users_2020.csv'))`, plan.Changes[0].Cmd)
+}
@@ -0,0 +1,124 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package oracle
+
+import (
+	"reflect"
+
+	"ariga.io/atlas/schema/schemaspec"
+	"ariga.io/atlas/schema/schemaspec/schemahcl"
+	"ariga.io/atlas/sql/internal/specutil"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlspec"
+)
+
+// UnmarshalSpec unmarshals an Atlas DDL document using an unmarshaler into v.
+// Names and types are kept as they were defined in the document. Use the
+// NormalizeSchema (or NormalizeRealm) method of the Driver for converting
+// the result to the form it is reported by the inspection.
+func UnmarshalSpec(data []byte, unmarshaler schemaspec.Unmarshaler, v interface{}) error {
+	return specutil.Unmarshal(data, unmarshaler, v, convertTable)
+}
+
+// MarshalSpec marshals v into an Atlas DDL document using a schemaspec.Marshaler.
+func MarshalSpec(v interface{}, marshaler schemaspec.Marshaler) ([]byte, error) {
+	return specutil.Marshal(v, marshaler, schemaSpec)
+}
+
+var (
+	hclState = schemahcl.New(schemahcl.WithTypes(TypeRegistry.Specs()))
+	// UnmarshalHCL unmarshals an Atlas HCL DDL document into v.
+	UnmarshalHCL = schemaspec.UnmarshalerFunc(func(bytes []byte, i interface{}) error {
+		return UnmarshalSpec(bytes, hclState, i)
+	})
+	// MarshalHCL marshals v into an Atlas HCL DDL document.
+	MarshalHCL = schemaspec.MarshalerFunc(func(v interface{}) ([]byte, error) {
+		return MarshalSpec(v, hclState)
+	})
+)
+
+// convertTable converts a sqlspec.Table to a schema.Table. Table conversion is done without converting
+// ForeignKeySpecs into ForeignKeys, as the target tables do not necessarily exist in the schema
+// at this point. Instead, the linking is done by the convertSchema function.
+func convertTable(spec *sqlspec.Table, parent *schema.Schema) (*schema.Table, error) {
+	return specutil.Table(spec, parent, convertColumn, specutil.PrimaryKey, specutil.Index, specutil.Check)
+}
+
+// convertColumn converts a sqlspec.Column into a schema.Column.
+func convertColumn(spec *sqlspec.Column, _ *schema.Table) (*schema.Column, error) {
+	return specutil.Column(spec, convertColumnType)
+}
+
+// convertColumnType converts a sqlspec.Column into a concrete Oracle schema.Type.
+func convertColumnType(spec *sqlspec.Column) (schema.Type, error) {
+	return TypeRegistry.Type(spec.Type, spec.Extra.Attrs)
+}
+
+// schemaSpec converts from a concrete Oracle schema to Atlas specification.
+func schemaSpec(s *schema.Schema) (*sqlspec.Schema, []*sqlspec.Table, error) {
+	return specutil.FromSchema(s, tableSpec)
+}
+
+// tableSpec converts from a concrete Oracle sqlspec.Table to a schema.Table.
+func tableSpec(t *schema.Table) (*sqlspec.Table, error) {
+	return specutil.FromTable(
+		t,
+		columnSpec,
+		specutil.FromPrimaryKey,
+		specutil.FromIndex,
+		specutil.FromForeignKey,
+		specutil.FromCheck,
+	)
+}
+
+// columnSpec converts from a concrete Oracle schema.Column into a sqlspec.Column.
+func columnSpec(c *schema.Column, _ *schema.Table) (*sqlspec.Column, error) {
+	return specutil.FromColumn(c, columnTypeSpec)
+}
+
+// columnTypeSpec converts from a concrete Oracle schema.Type into sqlspec.Column Type.
+func columnTypeSpec(t schema.Type) (*sqlspec.Column, error) {
+	st, err := TypeRegistry.Convert(t)
+	if err != nil {
+		return nil, err
+	}
+	return &sqlspec.Column{Type: st}, nil
+}
+
+// TypeRegistry contains the supported TypeSpecs for the Oracle driver.
+// Datetime and interval types are not registered, as their precision
+// arguments are optional, and they are handled by FormatType and ParseType.
+// So is LONG RAW, as type names with spaces cannot be used as HCL idents.
+var TypeRegistry = specutil.NewRegistry(
+	specutil.WithFormatter(FormatType),
+	specutil.WithParser(ParseType),
+	specutil.WithSpecs(
+		specutil.TypeSpec(TypeChar, specutil.SizeTypeAttr(false)),
+		specutil.TypeSpec(TypeNChar, specutil.SizeTypeAttr(false)),
+		specutil.TypeSpec(TypeVarchar2, specutil.SizeTypeAttr(true)),
+		specutil.TypeSpec(TypeNVarchar2, specutil.SizeTypeAttr(true)),
+		specutil.TypeSpec(TypeVarchar, specutil.SizeTypeAttr(true)),
+		specutil.TypeSpec(TypeCLOB),
+		specutil.TypeSpec(TypeNCLOB),
+		specutil.TypeSpec(TypeLong),
+		specutil.TypeSpec(TypeNumber, &schemaspec.TypeAttr{Name: "precision", Kind: reflect.Int}, &schemaspec.TypeAttr{Name: "scale", Kind: reflect.Int}),
+		specutil.TypeSpec(TypeDecimal, &schemaspec.TypeAttr{Name: "precision", Kind: reflect.Int}, &schemaspec.TypeAttr{Name: "scale", Kind: reflect.Int}),
+		specutil.TypeSpec(TypeNumeric, &schemaspec.TypeAttr{Name: "precision", Kind: reflect.Int}, &schemaspec.TypeAttr{Name: "scale", Kind: reflect.Int}),
+		specutil.TypeSpec(TypeInteger),
+		specutil.TypeSpec(TypeInt),
+		specutil.TypeSpec(TypeSmallInt),
+		specutil.TypeSpec(TypeFloat, &schemaspec.TypeAttr{Name: "precision", Kind: reflect.Int}),
+		specutil.TypeSpec(TypeBinaryFloat),
+		specutil.TypeSpec(TypeBinaryDouble),
+		specutil.TypeSpec(TypeRaw, specutil.SizeTypeAttr(true)),
+		specutil.TypeSpec(TypeBLOB),
+		specutil.TypeSpec(TypeBFile),
+		specutil.TypeSpec(TypeDate),
+		specutil.TypeSpec(TypeRowID),
+		specutil.TypeSpec(TypeURowID, specutil.SizeTypeAttr(false)),
+		specutil.TypeSpec(TypeXML),
+		specutil.TypeSpec(TypeSDOGeometry),
+	),
+)
@@ -0,0 +1,217 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"ariga.io/atlas/schema/schemaspec"
+	"ariga.io/atlas/sql/internal/spectest"
+	"ariga.io/atlas/sql/internal/specutil"
+	"ariga.io/atlas/sql/schema"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistrySanity(t *testing.T) {
+	spectest.RegistrySanityTest(t, TypeRegistry, nil)
+}
+
+func TestTypeRegistry_Size(t *testing.T) {
+	// CHAR without length specifier is equivalent to CHAR(1).
+	typ, err := TypeRegistry.Type(&schemaspec.Type{T: TypeChar}, nil)
+	require.NoError(t, err)
+	require.Equal(t, &schema.StringType{T: TypeChar}, typ)
+	f, err := FormatType(typ)
+	require.NoError(t, err)
+	require.Equal(t, "char", f)
+
+	typ, err = TypeRegistry.Type(&schemaspec.Type{T: TypeVarchar2, Attrs: []*schemaspec.Attr{specutil.LitAttr("size", "255")}}, nil)
+	require.NoError(t, err)
+	require.Equal(t, &schema.StringType{T: TypeVarchar2, Size: 255}, typ)
+
+	// VARCHAR2 without size is rejected by Oracle.
+	for _, name := range []string{TypeVarchar2, TypeNVarchar2, TypeVarchar} {
+		_, err = TypeRegistry.Type(&schemaspec.Type{T: name}, nil)
+		require.EqualError(t, err, `oracle: missing size for `+name+` type "`+name+`"`)
+		_, err = FormatType(&schema.StringType{T: name})
+		require.Error(t, err)
+	}
+}
+
+func TestMarshalSpec_Types(t *testing.T) {
+	s := &schema.Schema{Name: "ATLAS"}
+	types := []schema.Type{
+		&schema.StringType{T: TypeChar, Size: 2},
+		&schema.StringType{T: TypeNChar, Size: 2},
+		&schema.StringType{T: TypeVarchar2, Size: 255},
+		&schema.StringType{T: TypeNVarchar2, Size: 255},
+		&schema.StringType{T: TypeCLOB},
+		&schema.StringType{T: TypeNCLOB},
+		&schema.StringType{T: TypeLong},
+		&schema.DecimalType{T: TypeNumber, Precision: 10, Scale: 2},
+		&schema.DecimalType{T: TypeNumber, Precision: 10, Scale: -2},
+		&schema.FloatType{T: TypeFloat, Precision: 126},
+		&schema.FloatType{T: TypeBinaryFloat},
+		&schema.FloatType{T: TypeBinaryDouble},
+		&schema.BinaryType{T: TypeRaw, Size: 16},
+		&schema.BinaryType{T: TypeBLOB},
+		&schema.BinaryType{T: TypeLongRaw},
+		&schema.BinaryType{T: TypeBFile},
+		&schema.TimeType{T: TypeDate},
+		&schema.TimeType{T: TypeTimestamp, Precision: intp(6)},
+		&schema.TimeType{T: TypeTimestampTZ, Precision: intp(3)},
+		&IntervalType{T: TypeIntervalDS, Precision: intp(2), Scale: intp(6)},
+		&IntervalType{T: TypeIntervalYM},
+		&RowIDType{T: TypeRowID},
+		&RowIDType{T: TypeURowID, Size: 100},
+		&XMLType{T: TypeXML},
+		&schema.SpatialType{T: TypeSDOGeometry},
+	}
+	table := &schema.Table{Name: "T"}
+	for i, typ := range types {
+		table.Columns = append(table.Columns, &schema.Column{Name: fmt.Sprintf("C%d", i), Type: &schema.ColumnType{Type: typ}})
+	}
+	s.AddTables(table)
+	buf, err := MarshalHCL(s)
+	require.NoError(t, err)
+	var got schema.Schema
+	require.NoError(t, UnmarshalHCL(buf, &got))
+	require.Len(t, got.Tables, 1)
+	require.Len(t, got.Tables[0].Columns, len(types))
+	for i, c := range got.Tables[0].Columns {
+		require.Equal(t, types[i], c.Type.Type, c.Name)
+	}
+}
+
+func TestMarshalSpec_XMLType(t *testing.T) {
+	s := &schema.Schema{Name: "ATLAS"}
+	s.AddTables(&schema.Table{
+		Name: "DOCS",
+		Columns: []*schema.Column{
+			{Name: "BODY", Type: &schema.ColumnType{Type: &XMLType{T: TypeXML}, Null: true}},
+		},
+	})
+	buf, err := MarshalHCL(s)
+	require.NoError(t, err)
+	require.Contains(t, string(buf), "type = xmltype")
+	var got schema.Schema
+	require.NoError(t, UnmarshalHCL(buf, &got))
+	require.Len(t, got.Tables, 1)
+	require.Equal(t, &XMLType{T: TypeXML}, got.Tables[0].Columns[0].Type.Type)
+	require.True(t, got.Tables[0].Columns[0].Type.Null)
+}
+
+func TestDriver_NormalizeSchema(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mock{m}.version("19.0.0.0.0")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	var desired schema.Schema
+	err = UnmarshalHCL([]byte(`
+schema "atlas" {
+}
+
+table "users" {
+	schema = schema.atlas
+	column "id" {
+		type = int
+	}
+	column "name" {
+		type = varchar(100)
+		default = "it's me"
+	}
+	column "balance" {
+		type = decimal
+		default = 0
+	}
+	column "CamelCase" {
+		type = char
+		null = true
+	}
+	primary_key {
+		columns = [table.users.column.id]
+	}
+	index "users_name" {
+		unique = true
+		columns = [table.users.column.name]
+	}
+	check "positive_balance" {
+		expr = "balance >= 0"
+	}
+}
+
+table "posts" {
+	schema = schema.atlas
+	column "id" {
+		type = integer
+	}
+	column "author_id" {
+		type = numeric
+	}
+	column "created_at" {
+		type = date
+		default = sql("SYSDATE")
+	}
+	foreign_key "posts_author" {
+		columns = [table.posts.column.author_id]
+		ref_columns = [table.users.column.id]
+		on_delete = "CASCADE"
+	}
+}
+`), &desired)
+	require.NoError(t, err)
+	norm, err := drv.NormalizeSchema(context.Background(), &desired)
+	require.NoError(t, err)
+	require.Equal(t, "ATLAS", norm.Name)
+
+	// The schema as it is reported by the inspection.
+	number := func(p int) *schema.ColumnType {
+		return &schema.ColumnType{Raw: "NUMBER", Type: &schema.DecimalType{T: TypeNumber, Precision: p}}
+	}
+	current := &schema.Schema{Name: "ATLAS"}
+	users := &schema.Table{
+		Name:   "USERS",
+		Schema: current,
+		Columns: []*schema.Column{
+			{Name: "ID", Type: number(38)},
+			{Name: "NAME", Type: &schema.ColumnType{Raw: "VARCHAR2", Type: &schema.StringType{T: TypeVarchar2, Size: 100}}, Default: &schema.Literal{V: "'it''s me'"}, Attrs: []schema.Attr{&schema.Charset{V: "AL32UTF8"}}},
+			{Name: "BALANCE", Type: number(38), Default: &schema.Literal{V: "0"}},
+			{Name: "CamelCase", Type: &schema.ColumnType{Raw: "CHAR", Type: &schema.StringType{T: TypeChar, Size: 1}, Null: true}, Attrs: []schema.Attr{&schema.Charset{V: "AL32UTF8"}}},
+		},
+		Attrs: []schema.Attr{
+			&schema.Check{Name: "POSITIVE_BALANCE", Expr: "balance >= 0", Attrs: []schema.Attr{&CheckColumns{Columns: []string{"BALANCE"}}}},
+		},
+	}
+	users.PrimaryKey = &schema.Index{Name: "SYS_C0012345", Unique: true, Table: users, Parts: []*schema.IndexPart{{C: users.Columns[0]}}, Attrs: []schema.Attr{&IndexType{T: "NORMAL"}}}
+	users.Indexes = []*schema.Index{
+		{Name: "USERS_NAME", Unique: true, Table: users, Parts: []*schema.IndexPart{{C: users.Columns[1]}}, Attrs: []schema.Attr{&IndexType{T: "NORMAL"}}},
+	}
+	posts := &schema.Table{
+		Name:   "POSTS",
+		Schema: current,
+		Columns: []*schema.Column{
+			{Name: "ID", Type: number(38)},
+			{Name: "AUTHOR_ID", Type: number(38)},
+			{Name: "CREATED_AT", Type: &schema.ColumnType{Raw: "DATE", Type: &schema.TimeType{T: TypeDate}}, Default: &schema.RawExpr{X: "SYSDATE"}},
+		},
+	}
+	posts.ForeignKeys = []*schema.ForeignKey{
+		{Symbol: "POSTS_AUTHOR", Table: posts, Columns: posts.Columns[1:2], RefTable: users, RefColumns: users.Columns[:1], OnDelete: schema.Cascade},
+	}
+	current.Tables = []*schema.Table{users, posts}
+	changes, err := drv.SchemaDiff(current, norm)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	// Mixed-case names are kept as-is, as they must be quoted.
+	_, ok := norm.Tables[0].Column("CamelCase")
+	require.True(t, ok)
+	require.Equal(t, &schema.Literal{V: "'it''s me'"}, norm.Tables[0].Columns[1].Default)
+}
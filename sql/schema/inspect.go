@@ -33,17 +33,52 @@ type ExecQuerier interface {
 	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
 }
 
+// An InspectMode controls the table elements that are inspected, and can be
+// combined using a set of flags. The zero mode inspects all elements. Note
+// that columns are always inspected, as other table elements refer to them.
+type InspectMode uint
+
+const (
+	// InspectColumns inspects the table columns only.
+	InspectColumns InspectMode = 1 << iota
+
+	// InspectIndexes inspects the table indexes (and its primary key).
+	InspectIndexes
+
+	// InspectForeignKeys inspects the table foreign keys.
+	InspectForeignKeys
+
+	// InspectChecks inspects the table check constraints.
+	InspectChecks
+
+	// InspectAll inspects all table elements.
+	InspectAll = InspectColumns | InspectIndexes | InspectForeignKeys | InspectChecks
+)
+
+// Is reports whether m includes the given mode.
+func (m InspectMode) Is(i InspectMode) bool {
+	return m == 0 || m&i != 0
+}
+
 type (
 	// InspectOptions describes options for Inspector.
 	InspectOptions struct {
 		// Tables to inspect. Empty means all tables in the schema.
 		Tables []string
+
+		// Mode defines the table elements to inspect.
+		// The zero value means all elements.
+		Mode InspectMode
 	}
 
 	// InspectTableOptions describes options for TableInspector.
 	InspectTableOptions struct {
 		// Schema defines an optional schema to inspect.
 		Schema string
+
+		// Mode defines the table elements to inspect.
+		// The zero value means all elements.
+		Mode InspectMode
 	}
 
 	// InspectRealmOption describes options for RealmInspector.
@@ -67,4 +102,16 @@ type (
 		// InspectRealm returns the description of the connected database.
 		InspectRealm(ctx context.Context, opts *InspectRealmOption) (*Realm, error)
 	}
+
+	// Normalizer is the interface implemented by the different database drivers for
+	// "normalizing" schema objects. i.e. converting schema objects defined in natural
+	// form (e.g. in an HCL document) to their representation in the database. Thus,
+	// two schema objects are equal if their normal forms are equal.
+	Normalizer interface {
+		// NormalizeSchema returns the normal form of the given schema.
+		NormalizeSchema(ctx context.Context, s *Schema) (*Schema, error)
+
+		// NormalizeRealm returns the normal form of the given realm.
+		NormalizeRealm(ctx context.Context, r *Realm) (*Realm, error)
+	}
 )